@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jomei/notionapi"
+)
+
+// A large apply can run into Notion's rate limit (see rate_limit_stats.go)
+// and fail part way through. With the provider's estimate_api_calls set,
+// plan logs how many requests each planned change is expected to make,
+// based on the calls the resource makes for it, together with a running
+// total for the plan and the least time that total takes at the average
+// rate Notion allows, so an operator can split the apply before running it.
+
+// notionRequestsPerSecond is the average request rate Notion allows an
+// integration.
+const notionRequestsPerSecond = 3
+
+// clientEstimateAPICalls records, per client, whether the provider was
+// configured with estimate_api_calls; see clientTokens.
+var clientEstimateAPICalls sync.Map
+
+// plannedAPICalls is the running total of estimated calls for this
+// provider process (i.e. the current plan).
+var plannedAPICalls atomic.Int64
+
+// registerEstimateAPICalls records whether plans made with client log API
+// call estimates.
+func registerEstimateAPICalls(client *notionapi.Client, enabled bool) {
+	clientEstimateAPICalls.Store(client, enabled)
+}
+
+// estimateAPICallsEnabled reports whether plans made with client log API
+// call estimates.
+func estimateAPICallsEnabled(client *notionapi.Client) bool {
+	v, ok := clientEstimateAPICalls.Load(client)
+	return ok && v.(bool)
+}
+
+// Planned change actions, as passed to the estimate functions.
+const (
+	planActionCreate  = "create"
+	planActionUpdate  = "update"
+	planActionReplace = "replace"
+	planActionDelete  = "delete"
+)
+
+// planAction returns the action a planned change takes, or "" when the
+// resource is left as it is. Replacement is only seen if it was decided
+// before the call, by attribute plan modifiers or earlier in ModifyPlan.
+func planAction(req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) string {
+	switch {
+	case req.State.Raw.IsNull():
+		return planActionCreate
+	case req.Plan.Raw.IsNull():
+		return planActionDelete
+	case len(resp.RequiresReplace) > 0:
+		return planActionReplace
+	case req.Plan.Raw.Equal(req.State.Raw):
+		return ""
+	default:
+		return planActionUpdate
+	}
+}
+
+// logAPICallEstimate logs the estimated API calls of a planned change to a
+// resource of type resourceType, if the provider is configured to. estimate
+// returns the number of calls for the change's action.
+func logAPICallEstimate(ctx context.Context, client *notionapi.Client, resourceType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, estimate func(action string) int) {
+	if !estimateAPICallsEnabled(client) {
+		return
+	}
+	action := planAction(req, resp)
+	if action == "" {
+		return
+	}
+	calls := estimate(action)
+	total := plannedAPICalls.Add(int64(calls))
+	tflog.Info(ctx, "Estimated Notion API calls for planned change", map[string]interface{}{
+		"resource_type":      resourceType,
+		"action":             action,
+		"estimated_calls":    calls,
+		"estimated_total":    total,
+		"estimated_duration": (time.Duration(total) * time.Second / notionRequestsPerSecond).String(),
+	})
+}
+
+// singleObjectCallEstimate returns the calls to create, update and delete a
+// resource that manages one object: the write, and for creates and updates
+// a read back of the result.
+func singleObjectCallEstimate(action string) int {
+	switch action {
+	case planActionCreate, planActionUpdate:
+		return 2
+	case planActionReplace:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// blockListCallEstimate returns the calls to apply action to a notion_blocks
+// resource going from prior to next: one append per maxAppendChildren
+// created blocks, one update per changed block, and one delete per removed
+// block.
+func blockListCallEstimate(action string, prior, next []BlockListItemModel) int {
+	appends := (len(next) + maxAppendChildren - 1) / maxAppendChildren
+	switch action {
+	case planActionCreate:
+		return appends
+	case planActionUpdate:
+		calls := 0
+		for i, item := range next {
+			if i >= len(prior) || blockListItemChanged(prior[i], item) {
+				calls++
+			}
+		}
+		return calls
+	case planActionReplace:
+		return len(prior) + appends
+	default:
+		return len(prior)
+	}
+}
+
+// bulkEntriesCallEstimate returns the calls to apply action to a
+// notion_database_entries_bulk resource going from prior to next: the key
+// property check, a query for existing rows when any are added, one write
+// per added, changed or removed row, and one trash per row on delete.
+func bulkEntriesCallEstimate(action string, prior, next map[string]BulkEntryRowModel) int {
+	write := func(prior, next map[string]BulkEntryRowModel) int {
+		toCreate, toUpdate, toArchive := diffBulkRows(prior, next)
+		calls := 1 + len(toCreate) + len(toUpdate) + len(toArchive)
+		if len(toCreate) > 0 {
+			calls++
+		}
+		return calls
+	}
+	switch action {
+	case planActionCreate:
+		return write(nil, next)
+	case planActionUpdate:
+		return write(prior, next)
+	case planActionReplace:
+		return len(prior) + write(nil, next)
+	default:
+		return len(prior)
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBlockListCallEstimate(t *testing.T) {
+	item := func(text string) BlockListItemModel {
+		return BlockListItemModel{
+			Type:         types.StringValue("paragraph"),
+			RichText:     NewRichTextStringValue(text),
+			RichTextJSON: types.StringNull(),
+			Color:        types.StringValue(""),
+			IsToggleable: types.BoolValue(false),
+			Checked:      types.BoolValue(false),
+			Icon:         types.StringValue(""),
+			Language:     types.StringValue(""),
+			Caption:      NewRichTextStringValue(""),
+			URL:          types.StringValue(""),
+			Expression:   types.StringValue(""),
+			SyncedFrom:   NewNotionIDNull(),
+		}
+	}
+	items := func(n int, prefix string) []BlockListItemModel {
+		out := make([]BlockListItemModel, n)
+		for i := range out {
+			out[i] = item(fmt.Sprintf("%s %d", prefix, i))
+		}
+		return out
+	}
+
+	prior := items(3, "Line")
+	next := items(3, "Line")
+	next[1] = item("Changed")
+
+	cases := []struct {
+		action      string
+		prior, next []BlockListItemModel
+		want        int
+	}{
+		{planActionCreate, nil, items(250, "Line"), 3},
+		{planActionUpdate, prior, next, 1},
+		{planActionReplace, prior, items(4, "Line"), 4},
+		{planActionDelete, prior, nil, 3},
+	}
+	for _, tc := range cases {
+		if got := blockListCallEstimate(tc.action, tc.prior, tc.next); got != tc.want {
+			t.Errorf("%s: got %d calls, want %d", tc.action, got, tc.want)
+		}
+	}
+}
+
+func TestBulkEntriesCallEstimate(t *testing.T) {
+	row := func(title string) BulkEntryRowModel {
+		nullMap := types.MapNull(types.StringType)
+		return BulkEntryRowModel{
+			Title:                 NewRichTextStringValue(title),
+			RichTextProperties:    types.MapNull(RichTextStringType{}),
+			NumberProperties:      types.MapNull(types.Float64Type),
+			CheckboxProperties:    types.MapNull(types.BoolType),
+			SelectProperties:      nullMap,
+			MultiSelectProperties: types.MapNull(types.SetType{ElemType: types.StringType}),
+			StatusProperties:      nullMap,
+			URLProperties:         nullMap,
+			EmailProperties:       nullMap,
+			PhoneNumberProperties: nullMap,
+			DateProperties:        types.MapNull(DateStringType{}),
+		}
+	}
+	prior := map[string]BulkEntryRowModel{"se": row("Sweden"), "no": row("Norway")}
+	next := map[string]BulkEntryRowModel{"se": row("Sweden"), "no": row("Norge"), "fi": row("Finland")}
+
+	cases := []struct {
+		action      string
+		prior, next map[string]BulkEntryRowModel
+		want        int
+	}{
+		// Key property check, query for existing rows, three writes.
+		{planActionCreate, nil, next, 5},
+		// Key property check, query for existing rows, one update, one create.
+		{planActionUpdate, prior, next, 4},
+		// Key property check only.
+		{planActionUpdate, prior, prior, 1},
+		{planActionReplace, prior, next, 7},
+		{planActionDelete, prior, nil, 2},
+	}
+	for _, tc := range cases {
+		if got := bulkEntriesCallEstimate(tc.action, tc.prior, tc.next); got != tc.want {
+			t.Errorf("%s: got %d calls, want %d", tc.action, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Error responses from Notion end up in diagnostics, either as the message
+// of an SDK error or as the whole body of a raw call's error, and their
+// messages can quote page content, such as the value a validation error
+// rejected. With sanitize_api_errors, sanitizingTransport rewrites error
+// bodies before anything reads them: quoted text in a JSON error's message
+// is elided and the message cut short, and any other body is truncated.
+// The status, error code and request ID are kept for troubleshooting.
+
+// sanitizeAPIErrors is whether error bodies are sanitized. notionHTTPClient
+// is shared by every configuration of the provider in the process, so it
+// is enabled for all of them once any of them enables it.
+var sanitizeAPIErrors atomic.Bool
+
+// sanitizedErrorLimit is the number of characters of an error message or
+// non-JSON error body kept by sanitizeErrorBody.
+const sanitizedErrorLimit = 200
+
+// quotedText matches the quoted values Notion's error messages echo.
+var quotedText = regexp.MustCompile(`"[^"]*"|“[^”]*”`)
+
+// newSanitizingHTTPClient returns a copy of base whose error response
+// bodies are sanitized while sanitizeAPIErrors is set.
+func newSanitizingHTTPClient(base *http.Client) *http.Client {
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client := *base
+	client.Transport = &sanitizingTransport{next: transport}
+	return &client
+}
+
+// sanitizingTransport replaces the body of each error response with
+// sanitizeErrorBody's.
+type sanitizingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *sanitizingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 || !sanitizeAPIErrors.Load() {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read error response: %w", err)
+	}
+	body = sanitizeErrorBody(body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return resp, nil
+}
+
+// apiErrorBody is the body of a Notion error response.
+type apiErrorBody struct {
+	Object    string `json:"object"`
+	Status    int    `json:"status"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// sanitizeErrorBody returns body without the content it may quote. A Notion
+// JSON error keeps its shape, so the SDK still decodes it, with the quoted
+// text in its message replaced by "…" and the message truncated. Any other
+// body, such as an HTML error page, is truncated.
+func sanitizeErrorBody(body []byte) []byte {
+	var e apiErrorBody
+	if err := json.Unmarshal(body, &e); err != nil || e.Object != "error" {
+		return []byte(truncateRunes(string(body), sanitizedErrorLimit))
+	}
+	e.Message = truncateRunes(quotedText.ReplaceAllString(e.Message, `"…"`), sanitizedErrorLimit)
+	out, err := json.Marshal(e)
+	if err != nil {
+		return []byte(truncateRunes(string(body), sanitizedErrorLimit))
+	}
+	return out
+}
+
+// truncateRunes cuts s to at most n characters, marking the cut with the
+// number of bytes left out.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	cut := 0
+	for i := range s {
+		if n == 0 {
+			cut = i
+			break
+		}
+		n--
+	}
+	return fmt.Sprintf("%s… (%d bytes truncated)", s[:cut], len(s)-cut)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeErrorBody(t *testing.T) {
+	body := `{"object":"error","status":400,"code":"validation_error",` +
+		`"message":"body.properties.Salary.email: \"125000 for Ada\" is not a valid email.","request_id":"req-1"}`
+	got := string(sanitizeErrorBody([]byte(body)))
+	want := `{"object":"error","status":400,"code":"validation_error",` +
+		`"message":"body.properties.Salary.email: \"…\" is not a valid email.","request_id":"req-1"}`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	html := "<html>" + strings.Repeat("x", 300) + "</html>"
+	got = string(sanitizeErrorBody([]byte(html)))
+	if !strings.HasPrefix(got, "<html>") || !strings.HasSuffix(got, "… (113 bytes truncated)") {
+		t.Errorf("expected the HTML body to be truncated, got %s", got)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("héllo", 5); got != "héllo" {
+		t.Errorf("expected a short string to be kept, got %q", got)
+	}
+	if got := truncateRunes("héllo wörld", 4); got != "héll… (8 bytes truncated)" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestSanitizingTransport(t *testing.T) {
+	prev := notionHTTPClient
+	t.Cleanup(func() {
+		notionHTTPClient = prev
+		sanitizeAPIErrors.Store(false)
+	})
+
+	notionHTTPClient = newSanitizingHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body: io.NopCloser(strings.NewReader(
+					`{"object":"error","status":400,"code":"validation_error","message":"\"Secret plan\" is too long."}`)),
+				Request: req,
+			}, nil
+		}),
+	})
+
+	ctx := context.Background()
+	_, err := notionAPICall(ctx, http.MethodGet, notionAPIBaseURL+"/pages/p", "token", notionSDKAPIVersion, nil)
+	if err == nil || !strings.Contains(err.Error(), "Secret plan") {
+		t.Errorf("expected the full body without sanitize_api_errors, got %v", err)
+	}
+
+	sanitizeAPIErrors.Store(true)
+	_, err = notionAPICall(ctx, http.MethodGet, notionAPIBaseURL+"/pages/p", "token", notionSDKAPIVersion, nil)
+	if err == nil || strings.Contains(err.Error(), "Secret plan") || !strings.Contains(err.Error(), "validation_error") {
+		t.Errorf("expected a sanitized body keeping the error code, got %v", err)
+	}
+}
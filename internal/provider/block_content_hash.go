@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/jomei/notionapi"
+)
+
+// notion_block keeps a hash of the content of its block, as last written by
+// Terraform, in private state. Read compares it with the hash of the block
+// it fetches, which tells whether the block was edited in Notion without
+// comparing every attribute, and covers content the attributes don't
+// represent, such as the annotations of rich_text set through the Notion
+// UI. The hash is of the block's JSON less the fields that change without
+// its content changing, so it only differs when the content does. That
+// includes the URL of a file uploaded to Notion, which is signed and
+// expires, so image, file, PDF and video blocks get a new one on every read.
+
+// blockContentHashPrivateKey is the private state key holding the content
+// hash of a managed block.
+const blockContentHashPrivateKey = "content_hash"
+
+// blockMetadataFields are the fields of a block that aren't its content.
+var blockMetadataFields = []string{
+	"object", "id", "parent", "created_time", "created_by", "last_edited_time",
+	"last_edited_by", "has_children", "archived", "in_trash", "request_id",
+}
+
+// blockContentHash returns the hash of the content of b.
+func blockContentHash(b notionapi.Block) (string, error) {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		return "", fmt.Errorf("encoding block %s: %w", b.GetID(), err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", fmt.Errorf("decoding block %s: %w", b.GetID(), err)
+	}
+	for _, name := range blockMetadataFields {
+		delete(fields, name)
+	}
+	typeKey := string(b.GetType())
+	if content, ok := fields[typeKey]; ok {
+		if fields[typeKey], err = withoutSignedFileURL(content); err != nil {
+			return "", fmt.Errorf("decoding block %s: %w", b.GetID(), err)
+		}
+	}
+	// Maps are encoded with sorted keys, so equal content hashes equally
+	// whatever order the fields came in.
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("encoding block %s: %w", b.GetID(), err)
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// withoutSignedFileURL returns the type-specific content of a block with
+// the signed URL and expiry time of a file uploaded to Notion left out.
+// Content that isn't an object, or has no uploaded file, is returned as is.
+func withoutSignedFileURL(content json.RawMessage) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(content, &obj) != nil || obj["file"] == nil {
+		return content, nil
+	}
+	var file map[string]json.RawMessage
+	if err := json.Unmarshal(obj["file"], &file); err != nil {
+		return nil, err
+	}
+	delete(file, "url")
+	delete(file, "expiry_time")
+	raw, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	obj["file"] = raw
+	return json.Marshal(obj)
+}
+
+// trackedBlockContentHash returns the content hash recorded in private
+// state, or "" when none is.
+func trackedBlockContentHash(ctx context.Context, private privateStateGetter) (string, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, blockContentHashPrivateKey)
+	if diags.HasError() || len(raw) == 0 {
+		return "", diags
+	}
+	var hash string
+	if err := json.Unmarshal(raw, &hash); err != nil {
+		diags.AddError("Error reading private state", fmt.Sprintf("Decoding %s: %s", blockContentHashPrivateKey, err))
+	}
+	return hash, diags
+}
+
+// trackBlockContentHash records the content hash of b, as written by
+// Terraform, in private state.
+func trackBlockContentHash(ctx context.Context, private privateStateSetter, b notionapi.Block) diag.Diagnostics {
+	var diags diag.Diagnostics
+	hash, err := blockContentHash(b)
+	if err != nil {
+		diags.AddError("Error writing private state", err.Error())
+		return diags
+	}
+	raw, err := json.Marshal(hash)
+	if err != nil {
+		diags.AddError("Error writing private state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, blockContentHashPrivateKey, raw)
+}
+
+// blockChangedExternally reports whether b's content differs from the
+// content hash recorded in private state. A block without a recorded hash,
+// such as one imported or created before hashes were tracked, reports
+// false.
+func blockChangedExternally(ctx context.Context, private privateStateGetter, b notionapi.Block) (bool, diag.Diagnostics) {
+	tracked, diags := trackedBlockContentHash(ctx, private)
+	if diags.HasError() || tracked == "" {
+		return false, diags
+	}
+	hash, err := blockContentHash(b)
+	if err != nil {
+		diags.AddError("Error hashing block content", err.Error())
+		return false, diags
+	}
+	return hash != tracked, diags
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/jomei/notionapi"
+)
+
+// privateStateMap is an in-memory stand-in for the framework's private
+// state.
+type privateStateMap map[string][]byte
+
+func (m privateStateMap) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return m[key], nil
+}
+
+func (m privateStateMap) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	m[key] = value
+	return nil
+}
+
+func TestBlockContentHash(t *testing.T) {
+	paragraph := func(text string, edited time.Time) *notionapi.ParagraphBlock {
+		return &notionapi.ParagraphBlock{
+			BasicBlock: notionapi.BasicBlock{
+				Object:         notionapi.ObjectTypeBlock,
+				ID:             "11111111-1111-1111-1111-111111111111",
+				Type:           notionapi.BlockTypeParagraph,
+				LastEditedTime: &edited,
+			},
+			Paragraph: notionapi.Paragraph{
+				RichText: []notionapi.RichText{{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: text}, PlainText: text}},
+			},
+		}
+	}
+	written := paragraph("Hello", time.Unix(0, 0))
+	ctx := context.Background()
+	private := privateStateMap{}
+
+	if changed, diags := blockChangedExternally(ctx, private, written); diags.HasError() || changed {
+		t.Fatalf("untracked block: got changed=%t, %v", changed, diags)
+	}
+	if diags := trackBlockContentHash(ctx, private, written); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if changed, _ := blockChangedExternally(ctx, private, paragraph("Hello", time.Now())); changed {
+		t.Error("a new last_edited_time alone reported a change")
+	}
+	if changed, _ := blockChangedExternally(ctx, private, paragraph("Hello, world", time.Now())); !changed {
+		t.Error("edited text was not reported as a change")
+	}
+}
+
+func TestBlockContentHashIgnoresSignedFileURL(t *testing.T) {
+	image := func(url, caption string, expiry time.Time) *notionapi.ImageBlock {
+		return &notionapi.ImageBlock{
+			BasicBlock: notionapi.BasicBlock{
+				Object: notionapi.ObjectTypeBlock,
+				ID:     "11111111-1111-1111-1111-111111111111",
+				Type:   notionapi.BlockTypeImage,
+			},
+			Image: notionapi.Image{
+				Caption: []notionapi.RichText{{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: caption}, PlainText: caption}},
+				Type:    notionapi.FileTypeFile,
+				File:    &notionapi.FileObject{URL: url, ExpiryTime: &expiry},
+			},
+		}
+	}
+	ctx := context.Background()
+	private := privateStateMap{}
+	if diags := trackBlockContentHash(ctx, private, image("https://files.example/a.png?X-Amz-Signature=1", "Chart", time.Unix(0, 0))); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if changed, _ := blockChangedExternally(ctx, private, image("https://files.example/a.png?X-Amz-Signature=2", "Chart", time.Now())); changed {
+		t.Error("a re-signed file URL alone reported a change")
+	}
+	if changed, _ := blockChangedExternally(ctx, private, image("https://files.example/a.png?X-Amz-Signature=2", "New chart", time.Now())); !changed {
+		t.Error("an edited caption was not reported as a change")
+	}
+}
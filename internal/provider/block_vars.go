@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// notion_block's vars fill ${key} placeholders in rich_text and caption when
+// the block is written, so a shared module can hold the template and each
+// caller only passes the values. State keeps the templates: after a write or
+// refresh, text that is the rendering of the template is put back in the
+// template's form, so only a change made in Notion, or to the template or
+// vars, shows up in a plan.
+
+// blockVarPattern matches a ${key} placeholder.
+var blockVarPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_.-]+)\}`)
+
+// substituteBlockVars replaces every ${key} placeholder in s with vars[key].
+// A placeholder whose key isn't in vars is an error, so a typo doesn't end
+// up published.
+func substituteBlockVars(s string, vars map[string]string) (string, error) {
+	var undefined []string
+	out := blockVarPattern.ReplaceAllStringFunc(s, func(m string) string {
+		key := blockVarPattern.FindStringSubmatch(m)[1]
+		val, ok := vars[key]
+		if !ok {
+			undefined = append(undefined, key)
+			return m
+		}
+		return val
+	})
+	if len(undefined) > 0 {
+		sort.Strings(undefined)
+		return "", fmt.Errorf("no value in vars for %s", strings.Join(undefined, ", "))
+	}
+	return out, nil
+}
+
+// blockVars returns the vars of m, or nil when there are none.
+func blockVars(ctx context.Context, m *BlockResourceModel) (map[string]string, diag.Diagnostics) {
+	if m.Vars.IsNull() || m.Vars.IsUnknown() {
+		return nil, nil
+	}
+	var vars map[string]string
+	diags := m.Vars.ElementsAs(ctx, &vars, false)
+	return vars, diags
+}
+
+// renderBlockVars returns a copy of plan with its vars substituted into
+// rich_text and caption, ready to be sent to Notion.
+func renderBlockVars(ctx context.Context, plan BlockResourceModel) (BlockResourceModel, diag.Diagnostics) {
+	vars, diags := blockVars(ctx, &plan)
+	if diags.HasError() || vars == nil {
+		return plan, diags
+	}
+	for name, field := range map[string]*RichTextStringValue{"rich_text": &plan.RichText, "caption": &plan.Caption} {
+		if field.IsNull() || field.IsUnknown() {
+			continue
+		}
+		rendered, err := substituteBlockVars(field.ValueString(), vars)
+		if err != nil {
+			diags.AddError("Error substituting block vars", fmt.Sprintf("%s: %s.", name, err))
+			continue
+		}
+		*field = NewRichTextStringValue(rendered)
+	}
+	return plan, diags
+}
+
+// keepBlockTemplates puts the rich_text and caption of template back into
+// state where state holds their rendering with template's vars.
+func keepBlockTemplates(ctx context.Context, template, state *BlockResourceModel) diag.Diagnostics {
+	vars, diags := blockVars(ctx, template)
+	if diags.HasError() || vars == nil {
+		return diags
+	}
+	for _, f := range []struct{ template, state *RichTextStringValue }{
+		{&template.RichText, &state.RichText},
+		{&template.Caption, &state.Caption},
+	} {
+		if f.template.IsNull() || f.template.IsUnknown() || f.state.IsNull() {
+			continue
+		}
+		rendered, err := substituteBlockVars(f.template.ValueString(), vars)
+		if err != nil {
+			continue
+		}
+		if normalizeRichTextPlain(rendered) == normalizeRichTextPlain(f.state.ValueString()) {
+			*f.state = *f.template
+		}
+	}
+	return diags
+}
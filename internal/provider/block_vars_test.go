@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSubstituteBlockVars(t *testing.T) {
+	vars := map[string]string{"env": "prod", "team.name": "Platform"}
+
+	got, err := substituteBlockVars("Deploy **${env}** for ${team.name}, cost $5 {not a var}", vars)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "Deploy **prod** for Platform, cost $5 {not a var}"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := substituteBlockVars("${region} and ${env} and ${owner}", vars); err == nil || err.Error() != "no value in vars for owner, region" {
+		t.Errorf("expected the undefined keys to be listed, got %v", err)
+	}
+}
+
+func TestKeepBlockTemplates(t *testing.T) {
+	ctx := context.Background()
+	template := &BlockResourceModel{
+		RichText: NewRichTextStringValue("Deploy to ${env}"),
+		Caption:  NewRichTextStringValue("${env} only"),
+		Vars: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"env": types.StringValue("prod"),
+		}),
+	}
+
+	rendered, diags := renderBlockVars(ctx, *template)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if rendered.RichText.ValueString() != "Deploy to prod" || rendered.Caption.ValueString() != "prod only" {
+		t.Fatalf("got rich_text %q, caption %q", rendered.RichText.ValueString(), rendered.Caption.ValueString())
+	}
+
+	// Notion returned the rendered rich text, and a caption edited in the UI.
+	state := &BlockResourceModel{
+		RichText: NewRichTextStringValue("Deploy to prod "),
+		Caption:  NewRichTextStringValue("staging only"),
+	}
+	if diags := keepBlockTemplates(ctx, template, state); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := state.RichText.ValueString(); got != "Deploy to ${env}" {
+		t.Errorf("expected the rich_text template to be kept, got %q", got)
+	}
+	if got := state.Caption.ValueString(); got != "staging only" {
+		t.Errorf("expected the edited caption to show as drift, got %q", got)
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The properties map flattens every value to one string, which loses the
+// type of a formula's result and joins the items of a rollup's array. The
+// computed_values map of the entries data source keeps both.
+
+// computedValueType is the type of the value of a formula or rollup
+// property in the computed_values map.
+var computedValueType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":   types.StringType,
+	"value":  types.StringType,
+	"values": types.ListType{ElemType: types.StringType},
+}}
+
+// rawComputedValues returns the results of the formula and rollup
+// properties among props, keyed by property name.
+func rawComputedValues(props map[string]rawProperty, diags *diag.Diagnostics) types.Map {
+	vals := map[string]attr.Value{}
+	for name, prop := range props {
+		var resultType string
+		value := types.StringNull()
+		values := types.ListNull(types.StringType)
+		switch {
+		case prop.Type == "formula" && prop.Formula != nil:
+			resultType = prop.Formula.Type
+			value = formulaResultValue(prop.Formula)
+		case prop.Type == "rollup" && prop.Rollup != nil:
+			resultType = prop.Rollup.Type
+			switch prop.Rollup.Type {
+			case "number":
+				value = numberResultValue(prop.Rollup.Number)
+			case "date":
+				value = dateResultValue(prop.Rollup.Date)
+			case "array":
+				items := make([]attr.Value, len(prop.Rollup.Array))
+				for i, item := range prop.Rollup.Array {
+					items[i] = types.StringValue(rawPropertyToString(item))
+				}
+				var d diag.Diagnostics
+				values, d = types.ListValue(types.StringType, items)
+				diags.Append(d...)
+			}
+		default:
+			continue
+		}
+		obj, d := types.ObjectValue(computedValueType.AttrTypes, map[string]attr.Value{
+			"type":   types.StringValue(resultType),
+			"value":  value,
+			"values": values,
+		})
+		diags.Append(d...)
+		vals[name] = obj
+	}
+	m, d := types.MapValue(computedValueType, vals)
+	diags.Append(d...)
+	return m
+}
+
+// formulaResultValue renders the result of a formula, or returns null when
+// it has none.
+func formulaResultValue(f *rawFormula) types.String {
+	switch f.Type {
+	case "string":
+		return types.StringValue(f.String)
+	case "number":
+		return numberResultValue(f.Number)
+	case "boolean":
+		if f.Boolean == nil {
+			return types.StringNull()
+		}
+		return types.StringValue(strconv.FormatBool(*f.Boolean))
+	case "date":
+		return dateResultValue(f.Date)
+	default:
+		return types.StringNull()
+	}
+}
+
+// numberResultValue renders a number in full, unlike the %g of the
+// properties map, which switches to an exponent for large values.
+func numberResultValue(n *float64) types.String {
+	if n == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(strconv.FormatFloat(*n, 'f', -1, 64))
+}
+
+// dateResultValue renders a date result, including the end of a range, or
+// returns null when it is empty.
+func dateResultValue(d *rawDate) types.String {
+	if d == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(d.String())
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRawComputedValues(t *testing.T) {
+	var page rawPage
+	err := json.Unmarshal([]byte(`{
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Row"}]},
+			"Overdue": {"id": "a", "type": "formula", "formula": {"type": "boolean", "boolean": false}},
+			"Budget": {"id": "b", "type": "formula", "formula": {"type": "number", "number": 12500000}},
+			"Due": {"id": "c", "type": "formula", "formula": {"type": "date", "date": null}},
+			"Tags": {"id": "d", "type": "rollup", "rollup": {"type": "array", "array": [
+				{"type": "rich_text", "rich_text": [{"plain_text": "infra, ops"}]},
+				{"type": "checkbox", "checkbox": true}
+			]}},
+			"Weird": {"id": "e", "type": "rollup", "rollup": {"type": "unsupported"}}
+		}
+	}`), &page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var diags diag.Diagnostics
+	got := rawComputedValues(page.Properties, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(got.Elements()) != 5 {
+		t.Fatalf("expected the five formula and rollup properties, got %v", got)
+	}
+
+	field := func(name, attr string) string {
+		v := got.Elements()[name].(types.Object).Attributes()[attr]
+		if v.IsNull() {
+			return "<null>"
+		}
+		return v.(types.String).ValueString()
+	}
+	cases := []struct{ name, typ, value string }{
+		{"Overdue", "boolean", "false"},
+		{"Budget", "number", "12500000"},
+		{"Due", "date", "<null>"},
+		{"Tags", "array", "<null>"},
+		{"Weird", "unsupported", "<null>"},
+	}
+	for _, tc := range cases {
+		if typ, value := field(tc.name, "type"), field(tc.name, "value"); typ != tc.typ || value != tc.value {
+			t.Errorf("%s: got type %q value %q, want %q and %q", tc.name, typ, value, tc.typ, tc.value)
+		}
+	}
+
+	var tags []string
+	values := got.Elements()["Tags"].(types.Object).Attributes()["values"].(types.List)
+	for _, v := range values.Elements() {
+		tags = append(tags, v.(types.String).ValueString())
+	}
+	if want := []string{"infra, ops", "true"}; !slices.Equal(tags, want) {
+		t.Errorf("Tags values: got %q, want %q", tags, want)
+	}
+	if !got.Elements()["Budget"].(types.Object).Attributes()["values"].IsNull() {
+		t.Error("expected no values for a scalar result")
+	}
+}
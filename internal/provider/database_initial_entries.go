@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// A new database has only its title column, and the properties added by
+// notion_database_property resources are applied after it, so rows setting
+// other properties can't be written when the database is created. Those
+// rows are marked pending in private state instead; ModifyPlan plans
+// initial_entry_ids as unknown once the database has every property they
+// set, and Update writes them. Rows are only ever written once.
+
+const initialEntriesPrivateKey = "initial_entries_pending"
+
+type DatabaseInitialEntryModel struct {
+	Title      types.String `tfsdk:"title"`
+	Properties types.Map    `tfsdk:"properties"`
+}
+
+// pendingInitialEntries reports whether the database's initial entries are
+// waiting for their properties.
+func pendingInitialEntries(ctx context.Context, private privateStateGetter) (bool, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, initialEntriesPrivateKey)
+	if diags.HasError() || len(raw) == 0 {
+		return false, diags
+	}
+	var pending bool
+	if err := json.Unmarshal(raw, &pending); err != nil {
+		diags.AddError("Error reading private state", fmt.Sprintf("Decoding %s: %s", initialEntriesPrivateKey, err))
+	}
+	return pending, diags
+}
+
+// trackPendingInitialEntries records whether the initial entries are still
+// to be written.
+func trackPendingInitialEntries(ctx context.Context, private privateStateSetter, pending bool) diag.Diagnostics {
+	if !pending {
+		return private.SetKey(ctx, initialEntriesPrivateKey, nil)
+	}
+	return private.SetKey(ctx, initialEntriesPrivateKey, []byte("true"))
+}
+
+// initialEntries decodes an initial_entries value.
+func initialEntries(ctx context.Context, list types.List) ([]DatabaseInitialEntryModel, diag.Diagnostics) {
+	var entries []DatabaseInitialEntryModel
+	if list.IsNull() || list.IsUnknown() {
+		return entries, nil
+	}
+	diags := list.ElementsAs(ctx, &entries, false)
+	return entries, diags
+}
+
+// missingInitialEntryProperties returns the sorted names of the properties
+// set by entries that props doesn't have.
+func missingInitialEntryProperties(entries []DatabaseInitialEntryModel, props notionapi.PropertyConfigs) []string {
+	seen := map[string]bool{}
+	var missing []string
+	for _, entry := range entries {
+		for name := range entry.Properties.Elements() {
+			if _, ok := props[name]; !ok && !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// initialEntryProperties converts entry to the properties of a new row,
+// converting each value according to its property's type in props.
+func initialEntryProperties(entry DatabaseInitialEntryModel, titleName string, props notionapi.PropertyConfigs) (notionapi.Properties, error) {
+	properties := notionapi.Properties{
+		titleName: notionapi.TitleProperty{
+			Type:  notionapi.PropertyTypeTitle,
+			Title: plainToRichText(entry.Title.ValueString()),
+		},
+	}
+	for name, v := range entry.Properties.Elements() {
+		value, ok := v.(types.String)
+		if !ok || value.IsNull() {
+			continue
+		}
+		prop, err := sensitiveProperty(props[name].GetType(), value.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %s", name, err)
+		}
+		properties[name] = prop
+	}
+	return properties, nil
+}
+
+// writeInitialEntries creates plan's initial entries in order and sets
+// initial_entry_ids, or marks them pending when the database lacks some of
+// their properties. The IDs of the rows created before an error are kept.
+func (r *DatabaseResource) writeInitialEntries(ctx context.Context, plan *DatabaseResourceModel, private privateStateSetter) diag.Diagnostics {
+	plan.InitialEntryIDs = types.ListNull(types.StringType)
+	entries, diags := initialEntries(ctx, plan.InitialEntries)
+	if diags.HasError() {
+		return diags
+	}
+	if len(entries) == 0 {
+		diags.Append(trackPendingInitialEntries(ctx, private, false)...)
+		return diags
+	}
+
+	db, err := getDatabaseSchema(ctx, r.client, plan.ID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading database", err.Error())
+		return diags
+	}
+	if missing := missingInitialEntryProperties(entries, db.Properties); len(missing) > 0 {
+		diags.AddWarning("Initial entries deferred",
+			fmt.Sprintf("The database has no properties %s yet, so initial_entries will be created on the first "+
+				"apply after they are added.", strings.Join(missing, ", ")))
+		diags.Append(trackPendingInitialEntries(ctx, private, true)...)
+		return diags
+	}
+	// Whatever happens below, the rows aren't written again.
+	diags.Append(trackPendingInitialEntries(ctx, private, false)...)
+
+	ids := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		id, err := r.createInitialEntry(ctx, plan, entry, db.Properties)
+		if err != nil {
+			diags.AddError("Error creating initial entry", fmt.Sprintf("Entry %d (%q): %s", i, entry.Title.ValueString(), err))
+			break
+		}
+		ids = append(ids, id)
+	}
+	list, d := types.ListValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	plan.InitialEntryIDs = list
+	return diags
+}
+
+// createInitialEntry creates entry as a row of the database and returns its
+// ID.
+func (r *DatabaseResource) createInitialEntry(ctx context.Context, plan *DatabaseResourceModel, entry DatabaseInitialEntryModel, props notionapi.PropertyConfigs) (string, error) {
+	properties, err := initialEntryProperties(entry, plan.TitleColumnTitle.ValueString(), props)
+	if err != nil {
+		return "", err
+	}
+	page, err := r.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+		Parent: notionapi.Parent{
+			Type:       notionapi.ParentTypeDatabaseID,
+			DatabaseID: notionapi.DatabaseID(plan.ID.ValueString()),
+		},
+		Properties: properties,
+	})
+	if err != nil {
+		return "", err
+	}
+	return normalizeID(string(page.ID)), nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestInitialEntryProperties(t *testing.T) {
+	props := notionapi.PropertyConfigs{
+		"Name":   &notionapi.TitlePropertyConfig{Type: notionapi.PropertyConfigTypeTitle},
+		"Status": &notionapi.SelectPropertyConfig{Type: notionapi.PropertyConfigTypeSelect},
+		"Points": &notionapi.NumberPropertyConfig{Type: notionapi.PropertyConfigTypeNumber},
+	}
+	entry := func(title string, values map[string]string) DatabaseInitialEntryModel {
+		if values == nil {
+			return DatabaseInitialEntryModel{Title: types.StringValue(title), Properties: types.MapNull(types.StringType)}
+		}
+		elems := map[string]attr.Value{}
+		for k, v := range values {
+			elems[k] = types.StringValue(v)
+		}
+		return DatabaseInitialEntryModel{Title: types.StringValue(title), Properties: types.MapValueMust(types.StringType, elems)}
+	}
+
+	entries := []DatabaseInitialEntryModel{
+		entry("Backlog", nil),
+		entry("Doing", map[string]string{"Status": "Doing", "Owner": "Ada"}),
+		entry("Done", map[string]string{"Estimate": "3", "Owner": "Grace"}),
+	}
+	if got, want := missingInitialEntryProperties(entries, props), []string{"Estimate", "Owner"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("missing = %v, want %v", got, want)
+	}
+	if got := missingInitialEntryProperties(entries[:1], props); len(got) != 0 {
+		t.Errorf("title-only entries: missing = %v", got)
+	}
+
+	got, err := initialEntryProperties(entry("Doing", map[string]string{"Status": "Doing", "Points": "5"}), "Name", props)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if title, ok := got["Name"].(notionapi.TitleProperty); !ok || title.Title[0].Text.Content != "Doing" {
+		t.Errorf("title = %#v", got["Name"])
+	}
+	if sel, ok := got["Status"].(notionapi.SelectProperty); !ok || sel.Select.Name != "Doing" {
+		t.Errorf("status = %#v", got["Status"])
+	}
+	if n, ok := got["Points"].(notionapi.NumberProperty); !ok || n.Number != 5 {
+		t.Errorf("points = %#v", got["Points"])
+	}
+
+	if _, err := initialEntryProperties(entry("Doing", map[string]string{"Points": "five"}), "Name", props); err == nil {
+		t.Error("expected a non-numeric Points to be rejected")
+	}
+}
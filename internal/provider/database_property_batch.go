@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// Terraform applies the property resources of a database concurrently, so
+// separate Database.Update requests would race each other on the same
+// schema. Property resources go through batchedDatabaseUpdate, which holds
+// each change for propertyBatchWindow and sends every change queued for the
+// same database in that window as one request.
+
+// Notion answers a schema write that raced another write to the same
+// database with 409 conflict_error. The API has no endpoint for a single
+// property, so the narrowest request is a Database.Update carrying only the
+// properties being changed, keyed by ID (see propertyUpdateKey). Sending it
+// again once the other write is done is safe, so updateDatabaseProperties
+// retries it a few times before failing.
+
+// propertyConflictRetries is how many times a conflicting update is sent
+// again, and propertyConflictDelay the wait before the first retry, which
+// doubles for each one after it.
+const (
+	propertyConflictRetries = 3
+	propertyConflictDelay   = 500 * time.Millisecond
+)
+
+// propertyBatchWindow is how long the first change to a database waits for
+// others to join its request. Resources Terraform applies in parallel start
+// within milliseconds of each other.
+const propertyBatchWindow = 200 * time.Millisecond
+
+// propertyBatch is one pending Database.Update. Its result is shared by
+// every caller whose properties it carries.
+type propertyBatch struct {
+	properties notionapi.PropertyConfigs
+	done       chan struct{}
+	db         *notionapi.Database
+	err        error
+}
+
+// batchedDatabaseUpdate is client.Database.Update for requests that only
+// change properties, coalescing concurrent requests for the same database
+// into one. If a combined request fails, each caller retries its own
+// properties alone, so an invalid property only fails its own resource.
+func (p *providerData) batchedDatabaseUpdate(ctx context.Context, databaseID notionapi.DatabaseID, req *notionapi.DatabaseUpdateRequest) (*notionapi.Database, error) {
+	client := p.client
+	if len(req.Title) > 0 || len(req.Properties) == 0 {
+		return updateDatabaseProperties(ctx, client, databaseID, req)
+	}
+
+	key := canonicalNotionID(string(databaseID))
+
+	p.propertyBatchesMu.Lock()
+	batch, ok := p.propertyBatches[key]
+	if ok && batchHasAny(batch, req.Properties) {
+		// Two changes to the same property can't share a request.
+		p.propertyBatchesMu.Unlock()
+		return updateDatabaseProperties(ctx, client, databaseID, req)
+	}
+	if !ok {
+		batch = &propertyBatch{properties: notionapi.PropertyConfigs{}, done: make(chan struct{})}
+		p.propertyBatches[key] = batch
+		// The request outlives this caller's context if others join it,
+		// so it must not be cancelled with it.
+		flushCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(propertyBatchWindow, func() {
+			p.propertyBatchesMu.Lock()
+			delete(p.propertyBatches, key)
+			p.propertyBatchesMu.Unlock()
+
+			batch.db, batch.err = updateDatabaseProperties(flushCtx, client, databaseID, &notionapi.DatabaseUpdateRequest{
+				Properties: batch.properties,
+			})
+			close(batch.done)
+		})
+	}
+	for name, config := range req.Properties {
+		batch.properties[name] = config
+	}
+	p.propertyBatchesMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-batch.done:
+	}
+
+	// The batch left propertyBatches before it was sent, so its properties
+	// no longer change.
+	if batch.err != nil && len(batch.properties) > len(req.Properties) {
+		return updateDatabaseProperties(ctx, client, databaseID, req)
+	}
+	return batch.db, batch.err
+}
+
+// batchHasAny reports whether batch already changes any of properties.
+func batchHasAny(batch *propertyBatch, properties notionapi.PropertyConfigs) bool {
+	for name := range properties {
+		if _, ok := batch.properties[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// updateDatabaseProperties is client.Database.Update, retried when Notion
+// reports a conflict with a concurrent write.
+func updateDatabaseProperties(ctx context.Context, client *notionapi.Client, databaseID notionapi.DatabaseID, req *notionapi.DatabaseUpdateRequest) (*notionapi.Database, error) {
+	delay := propertyConflictDelay
+	for attempt := 0; ; attempt++ {
+		db, err := client.Database.Update(ctx, databaseID, req)
+		if err == nil || attempt == propertyConflictRetries || !isConflictError(err) {
+			return db, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// isConflictError reports whether err is Notion's conflict_error.
+func isConflictError(err error) bool {
+	var apiErr *notionapi.Error
+	return errors.As(err, &apiErr) && (apiErr.Status == http.StatusConflict || apiErr.Code == "conflict_error")
+}
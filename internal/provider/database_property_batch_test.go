@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// fakeDatabaseUpdates returns a client whose Database.Update calls are
+// answered locally. Each request's property names are recorded; requests
+// that touch a property named "Bad" fail with a validation error.
+func fakeDatabaseUpdates(t *testing.T) (*notionapi.Client, func() [][]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests [][]string
+
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			var names []string
+			for name := range body.Properties {
+				names = append(names, name)
+			}
+			mu.Lock()
+			requests = append(requests, names)
+			mu.Unlock()
+
+			status, resp := http.StatusOK, `{"object":"database","id":"0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"}`
+			if _, ok := body.Properties["Bad"]; ok {
+				status, resp = http.StatusBadRequest, `{"object":"error","status":400,"code":"validation_error","message":"bad property"}`
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(resp)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+
+	return client, func() [][]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func runBatchedUpdates(client *notionapi.Client, names []string) []error {
+	data := newProviderData(client)
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = data.batchedDatabaseUpdate(context.Background(), "0f1e2d3c4b5a69788796a5b4c3d2e1f0", &notionapi.DatabaseUpdateRequest{
+				Properties: notionapi.PropertyConfigs{
+					name: notionapi.RichTextPropertyConfig{Type: notionapi.PropertyConfigTypeRichText},
+				},
+			})
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestBatchedDatabaseUpdate_Coalesces(t *testing.T) {
+	client, requests := fakeDatabaseUpdates(t)
+
+	names := []string{"A", "B", "C", "D", "E"}
+	for i, err := range runBatchedUpdates(client, names) {
+		if err != nil {
+			t.Errorf("update %s: %s", names[i], err)
+		}
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 request, got %d: %v", len(got), got)
+	}
+	if len(got[0]) != len(names) {
+		t.Errorf("expected the request to carry %d properties, got %v", len(names), got[0])
+	}
+}
+
+func TestBatchedDatabaseUpdate_FailureIsolated(t *testing.T) {
+	client, requests := fakeDatabaseUpdates(t)
+
+	names := []string{"A", "Bad", "C"}
+	errs := runBatchedUpdates(client, names)
+	for i, err := range errs {
+		if wantErr := names[i] == "Bad"; (err != nil) != wantErr {
+			t.Errorf("update %s: expected error %t, got %v", names[i], wantErr, err)
+		}
+	}
+
+	// One combined request, then one retry per property.
+	if got := requests(); len(got) != 1+len(names) {
+		t.Errorf("expected %d requests, got %d: %v", 1+len(names), len(got), got)
+	}
+}
+
+func TestBatchedDatabaseUpdate_SamePropertyNotMerged(t *testing.T) {
+	client, requests := fakeDatabaseUpdates(t)
+
+	for _, err := range runBatchedUpdates(client, []string{"A", "A"}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := requests(); len(got) != 2 {
+		t.Errorf("expected 2 requests, got %d: %v", len(got), got)
+	}
+}
+
+func TestUpdateDatabaseProperties_RetriesConflicts(t *testing.T) {
+	var calls int
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			status, resp := http.StatusOK, `{"object":"database","id":"0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"}`
+			if calls == 1 {
+				status, resp = http.StatusConflict, `{"object":"error","status":409,"code":"conflict_error","message":"Conflict occurred while saving. Please try again."}`
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(resp)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+
+	_, err := updateDatabaseProperties(context.Background(), client, "0f1e2d3c4b5a69788796a5b4c3d2e1f0", &notionapi.DatabaseUpdateRequest{
+		Properties: notionapi.PropertyConfigs{
+			"A": notionapi.RichTextPropertyConfig{Type: notionapi.PropertyConfigTypeRichText},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected the conflict to be retried, got %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestUpdateDatabaseProperties_OtherErrorsNotRetried(t *testing.T) {
+	client, requests := fakeDatabaseUpdates(t)
+
+	_, err := updateDatabaseProperties(context.Background(), client, "0f1e2d3c4b5a69788796a5b4c3d2e1f0", &notionapi.DatabaseUpdateRequest{
+		Properties: notionapi.PropertyConfigs{
+			"Bad": notionapi.RichTextPropertyConfig{Type: notionapi.PropertyConfigTypeRichText},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected the validation error")
+	}
+	if got := requests(); len(got) != 1 {
+		t.Errorf("expected 1 request, got %d", len(got))
+	}
+}
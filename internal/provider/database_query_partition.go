@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Query cursors are opaque and each page's cursor comes from the previous
+// response, so one query can only be paged through sequentially: reading a
+// 20k-row database took 200 round trips back to back. Instead, once the
+// first page shows there is more, notion_database_entries splits the
+// database into created_time slices and pages through the slices
+// concurrently. Each slice is an independent query, which also keeps slices
+// under the per-query pagination cap on very large databases.
+
+const (
+	// databaseQueryConcurrency bounds the slices fetched at once. Notion
+	// allows an average of three requests per second per integration, so more
+	// workers would only trade latency for 429 retries.
+	databaseQueryConcurrency = 3
+	// databaseQuerySlices is how many created_time slices a large database
+	// is split into. More slices than workers evens out uneven row
+	// distributions over time.
+	databaseQuerySlices = 4 * databaseQueryConcurrency
+)
+
+// createdTimeOrder sorts rows oldest first. Every entries query uses it, so
+// a database comes back in the same order whether it is read in one chain
+// of pages or in slices.
+var createdTimeOrder = []map[string]string{{"timestamp": "created_time", "direction": "ascending"}}
+
+// entriesQuery returns the query for the rows matching filter (nil for
+// all), oldest first.
+func entriesQuery(filter map[string]interface{}) map[string]interface{} {
+	return filteredQuery(filter, map[string]interface{}{"sorts": createdTimeOrder})
+}
+
+// queryIncomplete reports whether the API cut a query's pagination short.
+func queryIncomplete(r *rawQueryResponse) bool {
+	return r.RequestStatus != nil && r.RequestStatus.Type == "incomplete"
+}
+
+// incompleteReason returns the reason given for an incomplete query.
+func incompleteReason(r *rawQueryResponse) string {
+	if r.RequestStatus == nil || r.RequestStatus.IncompleteReason == "" {
+		return "(no incomplete_reason returned)"
+	}
+	return r.RequestStatus.IncompleteReason
+}
+
+// collectQuery pages through one query from startCursor to the end,
+// converting each page of results with collector as it arrives. It returns
+// the entries and, if the API truncated the query, the reason.
+func (d *DatabaseEntriesDataSource) collectQuery(ctx context.Context, databaseID string, query map[string]interface{}, filterProperties []string, startCursor string, collector *entryCollector) ([]DatabaseEntryDataModel, string, error) {
+	var entries []DatabaseEntryDataModel
+	for {
+		result, err := d.queryDatabaseRaw(ctx, databaseID, query, filterProperties, startCursor)
+		if err != nil {
+			return nil, "", err
+		}
+		page, err := collector.collect(result.Results)
+		if err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, page...)
+		if queryIncomplete(result) {
+			return entries, incompleteReason(result), nil
+		}
+		if !result.HasMore {
+			return entries, "", nil
+		}
+		startCursor = result.NextCursor
+	}
+}
+
+// queryDatabasePartitioned reads every entry of a database matching filter
+// (nil for all) whose first page (first) reported more results. It returns the entries in
+// created_time order and the reasons of any slices the API truncated.
+func (d *DatabaseEntriesDataSource) queryDatabasePartitioned(ctx context.Context, databaseID string, filter map[string]interface{}, filterProperties []string, first *rawQueryResponse, collector *entryCollector) ([]DatabaseEntryDataModel, []string, error) {
+	oldest, newest, err := d.createdTimeRange(ctx, databaseID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	slices := createdTimeSlices(oldest, newest, databaseQuerySlices)
+	if len(slices) < 2 {
+		// Every row was created within the same minute (e.g. a bulk
+		// import), so there is nothing to split on: continue the first
+		// query's cursor chain instead.
+		entries, err := collector.collect(first.Results)
+		if err != nil {
+			return nil, nil, err
+		}
+		rest, reason, err := d.collectQuery(ctx, databaseID, entriesQuery(filter), filterProperties, first.NextCursor, collector)
+		if err != nil {
+			return nil, nil, err
+		}
+		var incomplete []string
+		if reason != "" {
+			incomplete = append(incomplete, reason)
+		}
+		return append(entries, rest...), incomplete, nil
+	}
+
+	type sliceResult struct {
+		entries []DatabaseEntryDataModel
+		reason  string
+		err     error
+	}
+	results := make([]sliceResult, len(slices))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, databaseQueryConcurrency)
+	var wg sync.WaitGroup
+	for i, slice := range slices {
+		wg.Add(1)
+		go func(i int, slice map[string]interface{}) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i].err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			query := filteredQuery(filter, map[string]interface{}{
+				"filter": slice,
+				"sorts":  createdTimeOrder,
+			})
+			entries, reason, err := d.collectQuery(ctx, databaseID, query, filterProperties, "", collector)
+			results[i] = sliceResult{entries: entries, reason: reason, err: err}
+			if err != nil {
+				cancel()
+			}
+		}(i, slice)
+	}
+	wg.Wait()
+
+	// Report the error that stopped the read, not the cancellations it
+	// caused in the other slices.
+	for _, r := range results {
+		if r.err != nil && !errors.Is(r.err, context.Canceled) {
+			return nil, nil, r.err
+		}
+	}
+
+	var entries []DatabaseEntryDataModel
+	var incomplete []string
+	for _, r := range results {
+		if r.err != nil {
+			return nil, nil, r.err
+		}
+		entries = append(entries, r.entries...)
+		if r.reason != "" {
+			incomplete = append(incomplete, r.reason)
+		}
+	}
+	return entries, incomplete, nil
+}
+
+// createdTimeRange returns the created_time of the oldest and newest rows
+// matching filter.
+func (d *DatabaseEntriesDataSource) createdTimeRange(ctx context.Context, databaseID string, filter map[string]interface{}) (time.Time, time.Time, error) {
+	var bounds [2]time.Time
+	for i, direction := range []string{"ascending", "descending"} {
+		result, err := d.queryDatabaseRaw(ctx, databaseID, filteredQuery(filter, map[string]interface{}{
+			"sorts":     []map[string]string{{"timestamp": "created_time", "direction": direction}},
+			"page_size": 1,
+		}), nil, "")
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if len(result.Results) == 0 {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, result.Results[0].CreatedTime)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		bounds[i] = t
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// createdTimeSlices splits [oldest, newest] into up to n created_time
+// filters on whole-minute boundaries (Notion records created_time to the
+// minute). The first slice has no lower bound and the last no upper bound,
+// so rows created outside the sampled range, e.g. while the read is in
+// progress, still land in exactly one slice. Returns nil when the range is
+// too narrow to split.
+func createdTimeSlices(oldest, newest time.Time, n int) []map[string]interface{} {
+	oldest = oldest.UTC().Truncate(time.Minute)
+	newest = newest.UTC().Truncate(time.Minute)
+	minutes := int(newest.Sub(oldest) / time.Minute)
+	if n > minutes+1 {
+		n = minutes + 1
+	}
+	if n < 2 {
+		return nil
+	}
+
+	// Boundaries between slices; slice i covers [bounds[i-1], bounds[i]).
+	bounds := make([]time.Time, 0, n-1)
+	for i := 1; i < n; i++ {
+		b := oldest.Add(time.Duration(minutes*i/n+1) * time.Minute)
+		if len(bounds) > 0 && !b.After(bounds[len(bounds)-1]) {
+			continue
+		}
+		bounds = append(bounds, b)
+	}
+
+	condition := func(op string, t time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			"timestamp":    "created_time",
+			"created_time": map[string]string{op: t.Format(time.RFC3339)},
+		}
+	}
+
+	filters := make([]map[string]interface{}, 0, len(bounds)+1)
+	filters = append(filters, condition("before", bounds[0]))
+	for i := 1; i < len(bounds); i++ {
+		filters = append(filters, map[string]interface{}{
+			"and": []map[string]interface{}{
+				condition("on_or_after", bounds[i-1]),
+				condition("before", bounds[i]),
+			},
+		})
+	}
+	filters = append(filters, condition("on_or_after", bounds[len(bounds)-1]))
+	return filters
+}
+
+// filteredQuery returns query with filter, if any, combined with the
+// query's own filter. A copy is returned; query is left unchanged. Notion
+// only nests compound filters two deep, so an "and" in the query's filter
+// is merged into the combined one rather than nested under it.
+func filteredQuery(filter, query map[string]interface{}) map[string]interface{} {
+	if filter == nil {
+		return query
+	}
+	out := make(map[string]interface{}, len(query)+1)
+	for k, v := range query {
+		out[k] = v
+	}
+	own, ok := query["filter"].(map[string]interface{})
+	if !ok {
+		out["filter"] = filter
+		return out
+	}
+	conditions := []map[string]interface{}{filter}
+	if and, ok := own["and"].([]map[string]interface{}); ok {
+		conditions = append(conditions, and...)
+	} else {
+		conditions = append(conditions, own)
+	}
+	out["filter"] = map[string]interface{}{"and": conditions}
+	return out
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCreatedTimeSlices(t *testing.T) {
+	oldest := time.Date(2026, 1, 1, 0, 0, 30, 0, time.UTC)
+
+	if got := createdTimeSlices(oldest, oldest.Add(20*time.Second), 12); got != nil {
+		t.Errorf("expected no slices within a single minute, got %d", len(got))
+	}
+
+	got := createdTimeSlices(oldest, oldest.Add(time.Minute), 12)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 slices for a two-minute range, got %d", len(got))
+	}
+	assertSliceJSON(t, got[0], `{"created_time":{"before":"2026-01-01T00:01:00Z"},"timestamp":"created_time"}`)
+	assertSliceJSON(t, got[1], `{"created_time":{"on_or_after":"2026-01-01T00:01:00Z"},"timestamp":"created_time"}`)
+
+	got = createdTimeSlices(oldest, oldest.Add(30*24*time.Hour), 12)
+	if len(got) != 12 {
+		t.Fatalf("expected 12 slices, got %d", len(got))
+	}
+	assertSliceJSON(t, got[1], `{"and":[`+
+		`{"created_time":{"on_or_after":"2026-01-03T12:01:00Z"},"timestamp":"created_time"},`+
+		`{"created_time":{"before":"2026-01-06T00:01:00Z"},"timestamp":"created_time"}]}`)
+}
+
+func assertSliceJSON(t *testing.T, filter map[string]interface{}, want string) {
+	t.Helper()
+	raw, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if string(raw) != want {
+		t.Errorf("filter = %s, want %s", raw, want)
+	}
+}
+
+func TestFilteredQuery(t *testing.T) {
+	filter, err := modifiedAfterFilter("2026-03-01T09:15:42+02:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assertSliceJSON(t, filter, `{"last_edited_time":{"on_or_after":"2026-03-01T07:15:00Z"},"timestamp":"last_edited_time"}`)
+	if _, err := modifiedAfterFilter("yesterday"); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+
+	if got := filteredQuery(nil, nil); got != nil {
+		t.Errorf("expected no query without a filter, got %v", got)
+	}
+	assertSliceJSON(t, filteredQuery(filter, nil),
+		`{"filter":{"last_edited_time":{"on_or_after":"2026-03-01T07:15:00Z"},"timestamp":"last_edited_time"}}`)
+
+	slices := createdTimeSlices(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 2, 0, 0, time.UTC), 3)
+	query := map[string]interface{}{"filter": slices[1], "page_size": 1}
+	assertSliceJSON(t, filteredQuery(filter, query), `{"filter":{"and":[`+
+		`{"last_edited_time":{"on_or_after":"2026-03-01T07:15:00Z"},"timestamp":"last_edited_time"},`+
+		`{"created_time":{"on_or_after":"2026-01-01T00:01:00Z"},"timestamp":"created_time"},`+
+		`{"created_time":{"before":"2026-01-01T00:02:00Z"},"timestamp":"created_time"}]},"page_size":1}`)
+	if and := query["filter"].(map[string]interface{})["and"].([]map[string]interface{}); len(and) != 2 {
+		t.Error("expected the original query to be left unchanged")
+	}
+}
+
+func TestEntriesQuery(t *testing.T) {
+	// Small databases are read with this query and large ones continue or
+	// slice it, so both come back oldest first.
+	assertSliceJSON(t, entriesQuery(nil), `{"sorts":[{"direction":"ascending","timestamp":"created_time"}]}`)
+
+	filter, err := modifiedAfterFilter("2026-03-01T09:15:42+02:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assertSliceJSON(t, entriesQuery(filter), `{"filter":{"last_edited_time":{"on_or_after":"2026-03-01T07:15:00Z"},`+
+		`"timestamp":"last_edited_time"},"sorts":[{"direction":"ascending","timestamp":"created_time"}]}`)
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/jomei/notionapi"
+)
+
+// Database.Get assumes one response carries the whole schema. Newer API
+// versions page the property list of very wide databases (has_more /
+// next_cursor on the database object), and the SDK silently drops every
+// property past the first page, so property resources on those columns
+// looked deleted. getDatabaseSchema follows the cursor and merges the pages.
+
+// maxDatabaseSchemaPages bounds the cursor loop in case the API keeps
+// returning the same cursor.
+const maxDatabaseSchemaPages = 100
+
+// databaseSchemaPage is one page of a database retrieval.
+type databaseSchemaPage struct {
+	notionapi.Database
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+	InTrash    bool   `json:"in_trash"`
+
+	// PropertyOrder lists the property names in the order the response
+	// has them; decoding into Properties loses it.
+	PropertyOrder []string `json:"-"`
+
+	// RawProperties holds each property's configuration as the API returned
+	// it, including fields the SDK doesn't decode.
+	RawProperties map[string]json.RawMessage `json:"-"`
+}
+
+func (p *databaseSchemaPage) UnmarshalJSON(data []byte) error {
+	type plain databaseSchemaPage
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	var raw struct {
+		Properties orderedKeys `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.PropertyOrder = raw.Properties
+	var configs struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return err
+	}
+	p.RawProperties = configs.Properties
+	return nil
+}
+
+// orderedKeys decodes a JSON object into its keys, in document order.
+type orderedKeys []string
+
+func (k *orderedKeys) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected an object key, got %v", tok)
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return err
+		}
+		*k = append(*k, key)
+	}
+	return nil
+}
+
+// getDatabaseSchema retrieves a database with its complete property schema,
+// following schema pagination when the API splits it across responses.
+func getDatabaseSchema(ctx context.Context, client *notionapi.Client, databaseID string) (*notionapi.Database, error) {
+	full, err := getFullDatabaseSchema(ctx, client, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	return &full.Database, nil
+}
+
+// getFullDatabaseSchema is getDatabaseSchema that also keeps the property
+// order, raw property configurations and in_trash flag, which
+// notionapi.Database doesn't have.
+func getFullDatabaseSchema(ctx context.Context, client *notionapi.Client, databaseID string) (*databaseSchemaPage, error) {
+	token, err := tokenForClient(client)
+	if err != nil {
+		return nil, err
+	}
+	return collectFullDatabaseSchema(func(cursor string) (*databaseSchemaPage, error) {
+		return fetchDatabaseSchemaPage(ctx, token, databaseID, cursor)
+	})
+}
+
+// collectDatabaseSchema requests schema pages until the API reports no more,
+// merging their properties into the first page's database object.
+func collectDatabaseSchema(fetch func(cursor string) (*databaseSchemaPage, error)) (*notionapi.Database, error) {
+	full, err := collectFullDatabaseSchema(fetch)
+	if err != nil {
+		return nil, err
+	}
+	return &full.Database, nil
+}
+
+// collectFullDatabaseSchema is collectDatabaseSchema returning the first
+// page with the properties, and property order, of all pages merged in.
+func collectFullDatabaseSchema(fetch func(cursor string) (*databaseSchemaPage, error)) (*databaseSchemaPage, error) {
+	first, err := fetch("")
+	if err != nil {
+		return nil, err
+	}
+	full := *first
+	full.PropertyOrder = slices.Clone(first.PropertyOrder)
+	full.RawProperties = maps.Clone(first.RawProperties)
+	if full.RawProperties == nil {
+		full.RawProperties = map[string]json.RawMessage{}
+	}
+	db := &full.Database
+	if db.Properties == nil {
+		db.Properties = notionapi.PropertyConfigs{}
+	}
+
+	page := first
+	for i := 1; page.HasMore; i++ {
+		if page.NextCursor == "" {
+			return nil, fmt.Errorf("database %s schema reported more properties without a next_cursor", db.ID)
+		}
+		if i >= maxDatabaseSchemaPages {
+			return nil, fmt.Errorf("database %s schema still incomplete after %d pages", db.ID, maxDatabaseSchemaPages)
+		}
+		page, err = fetch(page.NextCursor)
+		if err != nil {
+			return nil, err
+		}
+		for name, prop := range page.Properties {
+			db.Properties[name] = prop
+		}
+		full.PropertyOrder = append(full.PropertyOrder, page.PropertyOrder...)
+		maps.Copy(full.RawProperties, page.RawProperties)
+	}
+	full.HasMore, full.NextCursor = false, ""
+	return &full, nil
+}
+
+// fetchDatabaseSchemaPage retrieves one page of a database's schema.
+func fetchDatabaseSchemaPage(ctx context.Context, token, databaseID, cursor string) (*databaseSchemaPage, error) {
+	reqURL := fmt.Sprintf("%s/databases/%s", notionAPIBaseURL, databaseID)
+	if cursor != "" {
+		reqURL += "?start_cursor=" + url.QueryEscape(cursor)
+	}
+
+	resp, err := doNotionRequestWithVersion(ctx, http.MethodGet, reqURL, token, notionSDKAPIVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("notion API %d fetching databases/%s: %s", resp.StatusCode, databaseID, string(body))
+	}
+
+	var page databaseSchemaPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("decoding database %s: %w", databaseID, err)
+	}
+	return &page, nil
+}
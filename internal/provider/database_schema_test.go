@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestCollectDatabaseSchema(t *testing.T) {
+	pages := map[string]string{
+		"": `{"object":"database","id":"db1","properties":{
+			"Name":{"id":"title","type":"title","title":{}},
+			"A":{"id":"a","type":"rich_text","rich_text":{}}
+		},"has_more":true,"next_cursor":"c1"}`,
+		"c1": `{"object":"database","id":"db1","properties":{
+			"B":{"id":"b","type":"number","number":{"format":"number"}}
+		},"has_more":true,"next_cursor":"c2"}`,
+		"c2": `{"object":"database","id":"db1","properties":{
+			"C":{"id":"c","type":"checkbox","checkbox":{}}
+		},"has_more":false,"next_cursor":null}`,
+	}
+	var requested []string
+	fetch := func(cursor string) (*databaseSchemaPage, error) {
+		requested = append(requested, cursor)
+		raw, ok := pages[cursor]
+		if !ok {
+			return nil, fmt.Errorf("unexpected cursor %q", cursor)
+		}
+		var page databaseSchemaPage
+		if err := json.Unmarshal([]byte(raw), &page); err != nil {
+			return nil, err
+		}
+		return &page, nil
+	}
+
+	full, err := collectFullDatabaseSchema(fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	db, order := &full.Database, full.PropertyOrder
+	if len(requested) != 3 {
+		t.Errorf("expected 3 requests, got %v", requested)
+	}
+	if string(db.ID) != "db1" {
+		t.Errorf("expected database ID db1, got %q", db.ID)
+	}
+	for _, name := range []string{"Name", "A", "B", "C"} {
+		if _, ok := db.Properties[name]; !ok {
+			t.Errorf("property %q missing from merged schema", name)
+		}
+	}
+	if prop, ok := db.Properties["B"].(*notionapi.NumberPropertyConfig); !ok || prop.Number.Format != "number" {
+		t.Errorf("expected B to decode as a number property, got %#v", db.Properties["B"])
+	}
+	if want := []string{"Name", "A", "B", "C"}; !slices.Equal(order, want) {
+		t.Errorf("expected property order %v, got %v", want, order)
+	}
+	if got := string(full.RawProperties["B"]); got != `{"id":"b","type":"number","number":{"format":"number"}}` {
+		t.Errorf("expected B's raw configuration to be kept, got %s", got)
+	}
+	if len(full.RawProperties) != 4 {
+		t.Errorf("expected 4 raw properties, got %d", len(full.RawProperties))
+	}
+}
+
+func TestCollectDatabaseSchemaMissingCursor(t *testing.T) {
+	fetch := func(cursor string) (*databaseSchemaPage, error) {
+		return &databaseSchemaPage{HasMore: true}, nil
+	}
+	if _, err := collectDatabaseSchema(fetch); err == nil {
+		t.Fatal("expected an error when has_more is set without a next_cursor")
+	}
+}
+
+func TestCollectDatabaseSchemaRepeatingCursor(t *testing.T) {
+	calls := 0
+	fetch := func(cursor string) (*databaseSchemaPage, error) {
+		calls++
+		return &databaseSchemaPage{HasMore: true, NextCursor: "same"}, nil
+	}
+	if _, err := collectDatabaseSchema(fetch); err == nil {
+		t.Fatal("expected an error when the cursor never ends")
+	}
+	if calls != maxDatabaseSchemaPages {
+		t.Errorf("expected %d requests, got %d", maxDatabaseSchemaPages, calls)
+	}
+}
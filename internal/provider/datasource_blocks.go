@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
@@ -17,15 +18,19 @@ type BlocksDataSource struct {
 }
 
 type BlocksDataSourceModel struct {
-	ParentID types.String     `tfsdk:"parent_id"`
+	ParentID NotionIDValue    `tfsdk:"parent_id"`
+	Depth    types.Int64      `tfsdk:"depth"`
 	Blocks   []BlockDataModel `tfsdk:"blocks"`
 }
 
 type BlockDataModel struct {
 	ID          types.String `tfsdk:"id"`
+	ParentID    types.String `tfsdk:"parent_id"`
+	Depth       types.Int64  `tfsdk:"depth"`
 	Type        types.String `tfsdk:"type"`
 	HasChildren types.Bool   `tfsdk:"has_children"`
 	PlainText   types.String `tfsdk:"plain_text"`
+	Title       types.String `tfsdk:"title"`
 	Archived    types.Bool   `tfsdk:"archived"`
 }
 
@@ -39,14 +44,20 @@ func (d *BlocksDataSource) Metadata(_ context.Context, req datasource.MetadataRe
 
 func (d *BlocksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "List the immediate child blocks of a Notion page or block. Wraps /v1/blocks/{id}/children.",
+		Description: "List the child blocks of a Notion page or block, optionally with their nested blocks. Wraps /v1/blocks/{id}/children.",
 		Attributes: map[string]schema.Attribute{
 			"parent_id": schema.StringAttribute{
 				Description: "The ID of the page or block whose children should be listed.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 			},
+			"depth": schema.Int64Attribute{
+				Description: fmt.Sprintf("How many levels of blocks to list: 1, the default, lists the immediate children, "+
+					"2 also their children, and so on, up to %d. The content of child pages and databases is not listed.", maxSnapshotDepth),
+				Optional: true,
+			},
 			"blocks": schema.ListNestedAttribute{
-				Description: "Immediate children of parent_id, in document order.",
+				Description: "The blocks under parent_id, flattened in document order: each block is followed by its nested blocks.",
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -54,18 +65,30 @@ func (d *BlocksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							Description: "The block ID.",
 							Computed:    true,
 						},
+						"parent_id": schema.StringAttribute{
+							Description: "The ID of the page or block this block is a child of.",
+							Computed:    true,
+						},
+						"depth": schema.Int64Attribute{
+							Description: "The level of the block: 1 for the children of parent_id, 2 for their children, and so on.",
+							Computed:    true,
+						},
 						"type": schema.StringAttribute{
 							Description: "The block type (e.g. paragraph, heading_1, code, image).",
 							Computed:    true,
 						},
 						"has_children": schema.BoolAttribute{
-							Description: "Whether this block has nested children. Use a separate notion_blocks data source with parent_id set to this block's ID to fetch them.",
+							Description: "Whether this block has nested children. They are listed after it unless the block is at the last level of depth.",
 							Computed:    true,
 						},
 						"plain_text": schema.StringAttribute{
 							Description: "Best-effort plain-text representation of the block's content. Empty for blocks without textual content (dividers, images, etc.).",
 							Computed:    true,
 						},
+						"title": schema.StringAttribute{
+							Description: "The title of a child_page or child_database block, whose ID is the ID of the page or database. Empty for other blocks.",
+							Computed:    true,
+						},
 						"archived": schema.BoolAttribute{
 							Description: "Whether the block is archived.",
 							Computed:    true,
@@ -81,13 +104,13 @@ func (d *BlocksDataSource) Configure(_ context.Context, req datasource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.client = data.client
 }
 
 func (d *BlocksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -97,35 +120,62 @@ func (d *BlocksDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	parentID := normalizeID(config.ParentID.ValueString())
-	var cursor notionapi.Cursor
-	for {
-		page, err := d.client.Block.GetChildren(ctx, notionapi.BlockID(parentID), &notionapi.Pagination{
-			StartCursor: cursor,
-			PageSize:    100,
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error listing block children", err.Error())
+	depth := 1
+	if !config.Depth.IsNull() {
+		if d := config.Depth.ValueInt64(); d < 1 || d > maxSnapshotDepth {
+			resp.Diagnostics.AddAttributeError(path.Root("depth"), "Invalid depth",
+				fmt.Sprintf("depth must be between 1 and %d, got %d.", maxSnapshotDepth, d))
 			return
 		}
-
-		for _, b := range page.Results {
-			config.Blocks = append(config.Blocks, blockDataModel(b))
-		}
-
-		if !page.HasMore {
-			break
-		}
-		cursor = notionapi.Cursor(page.NextCursor)
+		depth = int(config.Depth.ValueInt64())
 	}
 
-	if config.Blocks == nil {
-		config.Blocks = []BlockDataModel{}
+	blocks, err := flattenBlocks(ctx, func(ctx context.Context, id string) ([]notionapi.Block, error) {
+		return listChildBlocks(ctx, d.client, id)
+	}, config.ParentID.ValueNotionID(), depth)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing block children", err.Error())
+		return
 	}
+	config.Blocks = blocks
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
 
+// flattenBlocks returns the blocks under parentID down to depth levels, in
+// document order, each followed by its nested blocks. As in page snapshots,
+// child pages and databases are not descended into.
+func flattenBlocks(ctx context.Context, children func(ctx context.Context, id string) ([]notionapi.Block, error), parentID string, depth int) ([]BlockDataModel, error) {
+	blocks := []BlockDataModel{}
+	var walk func(id string, level int) error
+	walk = func(id string, level int) error {
+		list, err := children(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, b := range list {
+			model := blockDataModel(b)
+			model.ParentID = types.StringValue(normalizeID(id))
+			model.Depth = types.Int64Value(int64(level))
+			blocks = append(blocks, model)
+			switch b.GetType() {
+			case notionapi.BlockTypeChildPage, notionapi.BlockTypeChildDatabase:
+				continue
+			}
+			if b.GetHasChildren() && level < depth {
+				if err := walk(string(b.GetID()), level+1); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(parentID, 1); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
 // blockDataModel converts an SDK Block into the flat representation we expose
 // to Terraform. plain_text extraction is best-effort: for block types whose
 // textual content is exposed via well-known fields we surface it; otherwise
@@ -137,6 +187,13 @@ func blockDataModel(b notionapi.Block) BlockDataModel {
 		HasChildren: types.BoolValue(b.GetHasChildren()),
 		Archived:    types.BoolValue(b.GetArchived()),
 		PlainText:   types.StringValue(blockPlainText(b)),
+		Title:       types.StringValue(""),
+	}
+	switch v := b.(type) {
+	case *notionapi.ChildPageBlock:
+		model.Title = types.StringValue(v.ChildPage.Title)
+	case *notionapi.ChildDatabaseBlock:
+		model.Title = types.StringValue(v.ChildDatabase.Title)
 	}
 	return model
 }
@@ -165,6 +222,10 @@ func blockPlainText(b notionapi.Block) string {
 		return richTextPlain(v.Callout.RichText)
 	case *notionapi.CodeBlock:
 		return richTextPlain(v.Code.RichText)
+	case *notionapi.ChildPageBlock:
+		return v.ChildPage.Title
+	case *notionapi.ChildDatabaseBlock:
+		return v.ChildDatabase.Title
 	}
 	return ""
 }
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestFlattenBlocks(t *testing.T) {
+	basic := func(id string, typ notionapi.BlockType, hasChildren bool) notionapi.BasicBlock {
+		return notionapi.BasicBlock{Object: "block", ID: notionapi.BlockID(id), Type: typ, HasChildren: hasChildren}
+	}
+	tree := map[string][]notionapi.Block{
+		"page": {
+			&notionapi.ToggleBlock{BasicBlock: basic("a", notionapi.BlockTypeToggle, true)},
+			&notionapi.ChildPageBlock{BasicBlock: basic("b", notionapi.BlockTypeChildPage, true)},
+			&notionapi.DividerBlock{BasicBlock: basic("c", notionapi.BlockTypeDivider, false)},
+		},
+		"a":  {&notionapi.ToggleBlock{BasicBlock: basic("a1", notionapi.BlockTypeToggle, true)}},
+		"a1": {&notionapi.DividerBlock{BasicBlock: basic("a2", notionapi.BlockTypeDivider, false)}},
+	}
+	children := func(_ context.Context, id string) ([]notionapi.Block, error) {
+		blocks, ok := tree[id]
+		if !ok {
+			return nil, fmt.Errorf("unexpected fetch of %s", id)
+		}
+		return blocks, nil
+	}
+	flat := func(blocks []BlockDataModel) []string {
+		var out []string
+		for _, b := range blocks {
+			out = append(out, fmt.Sprintf("%s<%s@%d", b.ID.ValueString(), b.ParentID.ValueString(), b.Depth.ValueInt64()))
+		}
+		return out
+	}
+
+	blocks, err := flattenBlocks(context.Background(), children, "page", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := flat(blocks), []string{"a<page@1", "b<page@1", "c<page@1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("depth 1: got %v, want %v", got, want)
+	}
+
+	blocks, err = flattenBlocks(context.Background(), children, "page", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []string{"a<page@1", "a1<a@2", "a2<a1@3", "b<page@1", "c<page@1"}
+	if got := flat(blocks); !reflect.DeepEqual(got, want) {
+		t.Errorf("depth 3: got %v, want %v", got, want)
+	}
+}
@@ -1,15 +1,15 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
@@ -21,10 +21,16 @@ type DatabaseDataSource struct {
 }
 
 type DatabaseDataSourceModel struct {
-	Query types.String `tfsdk:"query"`
-	ID    types.String `tfsdk:"id"`
-	Title types.String `tfsdk:"title"`
-	URL   types.String `tfsdk:"url"`
+	Query        types.String `tfsdk:"query"`
+	ExactMatch   types.Bool   `tfsdk:"exact_match"`
+	ParentPageID types.String `tfsdk:"parent_page_id"`
+	Sort         types.String `tfsdk:"sort"`
+	SortDir      types.String `tfsdk:"sort_direction"`
+	ID           types.String `tfsdk:"id"`
+	Title        types.String `tfsdk:"title"`
+	URL          types.String `tfsdk:"url"`
+	PropertyIDs  types.Map    `tfsdk:"property_ids"`
+	SchemaJSON   types.String `tfsdk:"schema_json"`
 }
 
 func NewDatabaseDataSource() datasource.DataSource {
@@ -43,6 +49,27 @@ func (d *DatabaseDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 				Description: "Search query to find the database by title.",
 				Required:    true,
 			},
+			"exact_match": schema.BoolAttribute{
+				Description: "Only match databases whose title is exactly the query, rather than taking the first search hit. " +
+					"Fails, listing the candidates, when more than one database matches.",
+				Optional: true,
+			},
+			"parent_page_id": schema.StringAttribute{
+				Description: "Only match databases under this page, directly or nested at any depth. Fails, listing the " +
+					"candidates, when more than one database matches.",
+				Optional: true,
+			},
+			"sort": schema.StringAttribute{
+				Description: `Sort search hits by "last_edited_time" instead of by relevance. When set, the first database ` +
+					`matching exact_match and parent_page_id is selected instead of failing on several candidates.`,
+				Optional:   true,
+				Validators: []validator.String{SearchSortValidator()},
+			},
+			"sort_direction": schema.StringAttribute{
+				Description: `Direction of sort: "ascending" or "descending" (default). Ignored unless sort is set.`,
+				Optional:    true,
+				Validators:  []validator.String{SortDirectionValidator()},
+			},
 			"id": schema.StringAttribute{
 				Description: "The ID of the database.",
 				Computed:    true,
@@ -55,6 +82,15 @@ func (d *DatabaseDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 				Description: "The URL of the database.",
 				Computed:    true,
 			},
+			"property_ids": schema.MapAttribute{
+				Description: "Map of the database's property names to their IDs, which don't change when a property is renamed.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"schema_json": schema.StringAttribute{
+				Description: "The database's property configurations as JSON, keyed by property name, as the API returns them.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -63,13 +99,13 @@ func (d *DatabaseDataSource) Configure(_ context.Context, req datasource.Configu
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.client = data.client
 }
 
 func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -79,28 +115,97 @@ func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	result, err := d.searchRaw(ctx, config.Query.ValueString(), "database")
-	if err != nil {
-		resp.Diagnostics.AddError("Error searching for database", err.Error())
-		return
+	query := config.Query.ValueString()
+	exact := config.ExactMatch.ValueBool()
+	parentPageID := normalizeID(config.ParentPageID.ValueString())
+
+	sort := searchSort(config.Sort, config.SortDir)
+
+	var candidates []rawSearchResult
+	if !exact && parentPageID == "" {
+		result, err := d.searchRaw(ctx, query, "database", 1, "", sort)
+		if err != nil {
+			resp.Diagnostics.AddError("Error searching for database", err.Error())
+			return
+		}
+		candidates = result.Results
+	} else {
+		// Narrowing needs every hit, not just the best one.
+		token, err := tokenForClient(d.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Error searching for database", err.Error())
+			return
+		}
+		scope := newSearchScope(token)
+		var cursor string
+		for {
+			result, err := d.searchRaw(ctx, query, "database", 100, cursor, sort)
+			if err != nil {
+				resp.Diagnostics.AddError("Error searching for database", err.Error())
+				return
+			}
+			for _, r := range filterDatabaseCandidates(result.Results, query, exact) {
+				if parentPageID != "" {
+					under, err := scope.under(ctx, r.Parent, parentPageID)
+					if err != nil {
+						resp.Diagnostics.AddError("Error searching for database",
+							fmt.Sprintf("Resolving the parents of database %s: %s", normalizeID(r.ID), err))
+						return
+					}
+					if !under {
+						continue
+					}
+				}
+				candidates = append(candidates, r)
+			}
+			if !result.HasMore || result.NextCursor == "" {
+				break
+			}
+			cursor = result.NextCursor
+		}
 	}
 
-	if len(result.Results) == 0 {
+	if len(candidates) == 0 {
 		resp.Diagnostics.AddError("Database not found",
-			fmt.Sprintf("No database found matching query: %s", config.Query.ValueString()))
+			fmt.Sprintf("No database found matching query: %s", query))
+		return
+	}
+	if len(candidates) > 1 && sort == nil {
+		resp.Diagnostics.AddError("Multiple databases found",
+			fmt.Sprintf("%d databases match query %q; narrow it down with exact_match or parent_page_id, or pick the most recently edited with sort:\n%s",
+				len(candidates), query, describeSearchCandidates(candidates)))
 		return
 	}
 
-	db := result.Results[0]
+	db := candidates[0]
 	config.ID = types.StringValue(normalizeID(db.ID))
 	config.Title = types.StringValue(extractRawTitle(db.Title))
 	config.URL = types.StringValue(db.URL)
 
+	// A search hit carries only the first page of a wide database's
+	// properties, so read the whole schema.
+	full, err := getFullDatabaseSchema(ctx, d.client, config.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+	config.PropertyIDs = propertyIDsMap(full.Properties, &resp.Diagnostics)
+	// Marshalling a map sorts its keys, so the JSON only changes when the
+	// schema does.
+	schemaJSON, err := json.Marshal(full.RawProperties)
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding database schema", err.Error())
+		return
+	}
+	config.SchemaJSON = types.StringValue(string(schemaJSON))
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
 
 type rawSearchResponse struct {
-	Results []rawSearchResult `json:"results"`
+	Results    []rawSearchResult `json:"results"`
+	HasMore    bool              `json:"has_more"`
+	NextCursor string            `json:"next_cursor"`
 }
 
 type rawSearchResult struct {
@@ -108,6 +213,32 @@ type rawSearchResult struct {
 	URL    string          `json:"url"`
 	Title  json.RawMessage `json:"title"`
 	Object string          `json:"object"`
+	Parent rawParent       `json:"parent"`
+}
+
+// filterDatabaseCandidates keeps the search results titled exactly query,
+// or all of them when exact isn't set.
+func filterDatabaseCandidates(results []rawSearchResult, query string, exact bool) []rawSearchResult {
+	if !exact {
+		return results
+	}
+	var out []rawSearchResult
+	for _, r := range results {
+		if extractRawTitle(r.Title) == query {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// describeSearchCandidates lists search results one per line for an error
+// message.
+func describeSearchCandidates(results []rawSearchResult) string {
+	lines := make([]string, len(results))
+	for i, r := range results {
+		lines[i] = fmt.Sprintf("  - %q (%s) %s", extractRawTitle(r.Title), normalizeID(r.ID), r.URL)
+	}
+	return strings.Join(lines, "\n")
 }
 
 func extractRawTitle(raw json.RawMessage) string {
@@ -127,44 +258,35 @@ func extractRawTitle(raw json.RawMessage) string {
 
 // searchRaw queries the Notion search API directly, bypassing the SDK's
 // strict property type checking.
-func (d *DatabaseDataSource) searchRaw(ctx context.Context, query string, objectType string) (*rawSearchResponse, error) {
+func (d *DatabaseDataSource) searchRaw(ctx context.Context, query string, objectType string, pageSize int, cursor string, sort *notionapi.SortObject) (*rawSearchResponse, error) {
 	body := map[string]interface{}{
 		"query":     query,
-		"page_size": 1,
+		"page_size": pageSize,
 		"filter": map[string]string{
 			"value":    objectType,
 			"property": "object",
 		},
 	}
+	if cursor != "" {
+		body["start_cursor"] = cursor
+	}
+	if sort != nil {
+		body["sort"] = sort
+	}
 
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.notion.com/v1/search", bytes.NewReader(bodyBytes))
+	token, err := tokenForClient(d.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", d.client.Token.String()))
-	httpReq.Header.Set("Notion-Version", "2022-06-28")
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	httpResp, err := http.DefaultClient.Do(httpReq)
+	respBody, err := notionAPICall(ctx, http.MethodPost, notionAPIBaseURL+"/search", token, notionSDKAPIVersion, bodyBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search: %w", err)
 	}
-	defer httpResp.Body.Close()
-
-	respBody, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if httpResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Notion API error (status %d): %s", httpResp.StatusCode, string(respBody))
-	}
 
 	var result rawSearchResponse
 	if err := json.Unmarshal(respBody, &result); err != nil {
@@ -1,16 +1,20 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
@@ -22,15 +26,27 @@ type DatabaseEntriesDataSource struct {
 }
 
 type DatabaseEntriesDataSourceModel struct {
-	Database types.String             `tfsdk:"database"`
-	Entries  []DatabaseEntryDataModel `tfsdk:"entries"`
+	Database         NotionIDValue                     `tfsdk:"database"`
+	IncludeArchived  types.Bool                        `tfsdk:"include_archived"`
+	ModifiedAfter    types.String                      `tfsdk:"modified_after"`
+	SelectProperties types.List                        `tfsdk:"select_properties"`
+	CountBy          types.List                        `tfsdk:"count_by"`
+	Entries          []DatabaseEntryDataModel          `tfsdk:"entries"`
+	EntriesByID      map[string]DatabaseEntryDataModel `tfsdk:"entries_by_id"`
+	TotalCount       types.Int64                       `tfsdk:"total_count"`
+	Counts           types.Map                         `tfsdk:"counts"`
 }
 
 type DatabaseEntryDataModel struct {
-	ID         types.String `tfsdk:"id"`
-	Title      types.String `tfsdk:"title"`
-	URL        types.String `tfsdk:"url"`
-	Properties types.Map    `tfsdk:"properties"`
+	ID             types.String `tfsdk:"id"`
+	Title          types.String `tfsdk:"title"`
+	URL            types.String `tfsdk:"url"`
+	PublicURL      types.String `tfsdk:"public_url"`
+	Archived       types.Bool   `tfsdk:"archived"`
+	Properties     types.Map    `tfsdk:"properties"`
+	People         types.Map    `tfsdk:"people"`
+	Dates          types.Map    `tfsdk:"dates"`
+	ComputedValues types.Map    `tfsdk:"computed_values"`
 }
 
 func NewDatabaseEntriesDataSource() datasource.DataSource {
@@ -47,48 +63,119 @@ func (d *DatabaseEntriesDataSource) Schema(_ context.Context, _ datasource.Schem
 		Attributes: map[string]schema.Attribute{
 			"database": schema.StringAttribute{
 				Description: "The ID of the database to query.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 			},
+			"include_archived": schema.BoolAttribute{
+				Description: "Whether to include archived (trashed) entries in the results. Defaults to false.",
+				Optional:    true,
+			},
+			"modified_after": schema.StringAttribute{
+				Description: "Only return entries last edited at or after this time, an RFC 3339 timestamp or a " +
+					"date. Notion records edit times to the minute, so the time is rounded down to the minute.",
+				Optional: true,
+			},
+			"select_properties": schema.ListAttribute{
+				Description: "Names of the properties to return in each entry's properties map, in addition to the title. " +
+					"If unset, every property is returned.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"count_by": schema.ListAttribute{
+				Description: "Names of properties to count the entries by, such as a select or status. For each, counts " +
+					"maps every value to the number of entries that have it.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"total_count": schema.Int64Attribute{
+				Description: "The number of entries returned.",
+				Computed:    true,
+			},
+			"counts": schema.MapAttribute{
+				Description: "For each property in count_by, the number of entries per value. A multi-select entry counts " +
+					"once for each of its options; an empty value is counted under \"\".",
+				Computed:    true,
+				ElementType: types.MapType{ElemType: types.Int64Type},
+			},
 			"entries": schema.ListNestedAttribute{
-				Description: "List of database entries.",
+				Description: "List of database entries, oldest first.",
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							Description: "The ID of the entry.",
-							Computed:    true,
-						},
-						"title": schema.StringAttribute{
-							Description: "The title of the entry.",
-							Computed:    true,
-						},
-						"url": schema.StringAttribute{
-							Description: "The URL of the entry.",
-							Computed:    true,
-						},
-						"properties": schema.MapAttribute{
-							Description: "A map of property names to their string values.",
-							Computed:    true,
-							ElementType: types.StringType,
-						},
-					},
+					Attributes: databaseEntryDataAttributes(),
+				},
+			},
+			"entries_by_id": schema.MapNestedAttribute{
+				Description: "The same entries, keyed by ID. Unlike the list, which is ordered by creation time, " +
+					"keys don't shift when rows are added or removed, so for_each over it is stable.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: databaseEntryDataAttributes(),
 				},
 			},
 		},
 	}
 }
 
+// databaseEntryDataAttributes returns the attributes of an entry in entries
+// and entries_by_id.
+func databaseEntryDataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The ID of the entry.",
+			Computed:    true,
+		},
+		"title": schema.StringAttribute{
+			Description: "The title of the entry.",
+			Computed:    true,
+		},
+		"url": schema.StringAttribute{
+			Description: "The URL of the entry.",
+			Computed:    true,
+		},
+		"public_url": schema.StringAttribute{
+			Description: "The public URL of the entry when it is published to the web, and empty otherwise.",
+			Computed:    true,
+		},
+		"archived": schema.BoolAttribute{
+			Description: "Whether the entry is archived (in the trash). Always false unless include_archived is set.",
+			Computed:    true,
+		},
+		"properties": schema.MapAttribute{
+			Description: "A map of property names to their string values.",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"people": schema.MapAttribute{
+			Description: "The users of every people property, by property name, as objects with the user's id and name.",
+			Computed:    true,
+			ElementType: peoplePropertyType,
+		},
+		"dates": schema.MapAttribute{
+			Description: "The value of every date property, by property name, as an object with its start, " +
+				"end and time_zone. end and time_zone are null unless the date is a range or has a time zone.",
+			Computed:    true,
+			ElementType: datePropertyType,
+		},
+		"computed_values": schema.MapAttribute{
+			Description: "The result of every formula and rollup property, by property name, as an object with " +
+				"the result type, its value as a string, and the values of an array rollup as a list.",
+			Computed:    true,
+			ElementType: computedValueType,
+		},
+	}
+}
+
 func (d *DatabaseEntriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.client = data.client
 }
 
 func (d *DatabaseEntriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -98,76 +185,297 @@ func (d *DatabaseEntriesDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	var entries []DatabaseEntryDataModel
-	var startCursor string
+	includeArchived := config.IncludeArchived.ValueBool()
+	databaseID := config.Database.ValueNotionID()
 
-	for {
-		result, err := d.queryDatabaseRaw(ctx, config.Database.ValueString(), startCursor)
+	var countBy []string
+	if !config.CountBy.IsNull() {
+		resp.Diagnostics.Append(config.CountBy.ElementsAs(ctx, &countBy, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var filter map[string]interface{}
+	if !config.ModifiedAfter.IsNull() {
+		var err error
+		filter, err = modifiedAfterFilter(config.ModifiedAfter.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Error querying database", err.Error())
+			resp.Diagnostics.AddAttributeError(path.Root("modified_after"), "Invalid modified_after", err.Error())
 			return
 		}
+	}
 
-		for _, page := range result.Results {
-			entry := DatabaseEntryDataModel{
-				ID:  types.StringValue(normalizeID(page.ID)),
-				URL: types.StringValue(page.URL),
-			}
+	var filterProperties []string
+	if !config.SelectProperties.IsNull() {
+		var names []string
+		resp.Diagnostics.Append(config.SelectProperties.ElementsAs(ctx, &names, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// The counted properties have to come back with the entries too.
+		var err error
+		filterProperties, err = d.selectedPropertyIDs(ctx, databaseID, append(names, countBy...))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("select_properties"), "Error resolving select_properties", err.Error())
+			return
+		}
+	} else if len(countBy) > 0 {
+		if _, err := d.selectedPropertyIDs(ctx, databaseID, countBy); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("count_by"), "Error resolving count_by", err.Error())
+			return
+		}
+	}
 
-			props := make(map[string]string)
-			for name, prop := range page.Properties {
-				val := rawPropertyToString(prop)
-				props[name] = val
-				if prop.Type == "title" {
-					entry.Title = types.StringValue(val)
-				}
-			}
+	// Pages are converted to entries as they arrive, so the raw responses
+	// of a large database are never all held in memory at once.
+	collector := newEntryCollector(includeArchived)
+	collector.countBy = countBy
 
-			if entry.Title.IsNull() {
-				entry.Title = types.StringValue("")
-			}
+	first, err := d.queryDatabaseRaw(ctx, databaseID, entriesQuery(filter), filterProperties, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error querying database", err.Error())
+		return
+	}
 
-			propMap := make(map[string]types.String, len(props))
-			for k, v := range props {
-				propMap[k] = types.StringValue(v)
-			}
-			mapVal, diags := types.MapValueFrom(ctx, types.StringType, propMap)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-			entry.Properties = mapVal
+	var entries []DatabaseEntryDataModel
+	var incomplete []string
+	if first.HasMore && !queryIncomplete(first) {
+		// Large database: read it as concurrent created_time slices rather
+		// than following one cursor chain (see database_query_partition.go).
+		entries, incomplete, err = d.queryDatabasePartitioned(ctx, databaseID, filter, filterProperties, first, collector)
+	} else {
+		entries, err = collector.collect(first.Results)
+		if queryIncomplete(first) {
+			incomplete = append(incomplete, incompleteReason(first))
+		}
+	}
+	if errors.Is(err, errTooManyEntries) {
+		resp.Diagnostics.AddError(
+			"Too many database entries",
+			fmt.Sprintf("Database %s has more than %d entries, the most notion_database_entries will read, "+
+				"since every entry is held in memory and in Terraform state. Split the data across several "+
+				"databases or manage the rows with resources instead.", databaseID, maxDatabaseEntries),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error querying database", err.Error())
+		return
+	}
+
+	for _, reason := range incomplete {
+		resp.Diagnostics.AddWarning(
+			"Database query results truncated",
+			fmt.Sprintf("Notion returned request_status.type=\"incomplete\" (reason: %s). "+
+				"As of the 2026-04-20 API change the Query a data source endpoint caps pagination "+
+				"at 10,000 rows per query. The returned entries are a partial result. "+
+				"Narrow your filter or process the data source in smaller chunks.", reason),
+		)
+	}
+
+	config.Entries = entries
+	if config.Entries == nil {
+		config.Entries = []DatabaseEntryDataModel{}
+	}
+	config.EntriesByID = make(map[string]DatabaseEntryDataModel, len(config.Entries))
+	for _, entry := range config.Entries {
+		config.EntriesByID[entry.ID.ValueString()] = entry
+	}
+	config.TotalCount = types.Int64Value(int64(len(config.Entries)))
+	config.Counts = collector.countsValue(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// maxDatabaseEntries caps the entries one read returns. The entries end up
+// in memory and in state in full, so an unbounded read of a 100k-row
+// database could exhaust the provider's memory.
+const maxDatabaseEntries = 50000
+
+// errTooManyEntries is returned once a read passes maxDatabaseEntries.
+var errTooManyEntries = errors.New("too many database entries")
+
+// entryCollector converts query results to entries as they arrive. It is
+// shared by concurrent slice reads: it drops rows already seen (slices can
+// overlap at their boundaries) and enforces maxDatabaseEntries across all
+// of them.
+type entryCollector struct {
+	includeArchived bool
+	// countBy names the properties whose values are tallied in counts.
+	countBy []string
+
+	mu     sync.Mutex
+	seen   map[string]bool
+	count  int
+	counts map[string]map[string]int64
+}
+
+func newEntryCollector(includeArchived bool) *entryCollector {
+	return &entryCollector{includeArchived: includeArchived, seen: map[string]bool{}, counts: map[string]map[string]int64{}}
+}
 
-			entries = append(entries, entry)
+// collect returns the entries for one page of query results.
+func (c *entryCollector) collect(pages []rawPage) ([]DatabaseEntryDataModel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entries []DatabaseEntryDataModel
+	for _, page := range pages {
+		if c.seen[page.ID] {
+			continue
 		}
+		c.seen[page.ID] = true
+
+		// Archived rows would otherwise churn for_each keys built from
+		// the entries list, so they're opt-in.
+		archived := page.Archived || page.InTrash
+		if archived && !c.includeArchived {
+			continue
+		}
+
+		c.count++
+		if c.count > maxDatabaseEntries {
+			return nil, errTooManyEntries
+		}
+		entry, err := entryFromRawPage(page, archived)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+		c.countValues(page)
+	}
+	return entries, nil
+}
 
-		if result.RequestStatus != nil && result.RequestStatus.Type == "incomplete" {
-			reason := result.RequestStatus.IncompleteReason
-			if reason == "" {
-				reason = "(no incomplete_reason returned)"
+// countValues tallies page's values of the countBy properties. Callers hold
+// c.mu.
+func (c *entryCollector) countValues(page rawPage) {
+	for _, name := range c.countBy {
+		prop, ok := page.Properties[name]
+		if !ok {
+			continue
+		}
+		values := []string{rawPropertyToString(prop)}
+		if prop.Type == "multi_select" && len(prop.MultiSelect) > 0 {
+			values = values[:0]
+			for _, opt := range prop.MultiSelect {
+				values = append(values, opt.Name)
 			}
-			resp.Diagnostics.AddWarning(
-				"Database query results truncated",
-				fmt.Sprintf("Notion returned request_status.type=\"incomplete\" (reason: %s). "+
-					"As of the 2026-04-20 API change the Query a data source endpoint caps pagination "+
-					"at 10,000 rows per query. The returned entries are a partial result. "+
-					"Narrow your filter or process the data source in smaller chunks.", reason),
-			)
-			break
 		}
+		if c.counts[name] == nil {
+			c.counts[name] = map[string]int64{}
+		}
+		for _, v := range values {
+			c.counts[name][v]++
+		}
+	}
+}
 
-		if !result.HasMore {
-			break
+// countsValue returns the tallies as the counts attribute, with an empty
+// map for a counted property no entry had.
+func (c *entryCollector) countsValue(diags *diag.Diagnostics) types.Map {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elemType := types.MapType{ElemType: types.Int64Type}
+	vals := make(map[string]attr.Value, len(c.countBy))
+	for _, name := range c.countBy {
+		counts := make(map[string]attr.Value, len(c.counts[name]))
+		for v, n := range c.counts[name] {
+			counts[v] = types.Int64Value(n)
 		}
-		startCursor = result.NextCursor
+		m, d := types.MapValue(types.Int64Type, counts)
+		diags.Append(d...)
+		vals[name] = m
 	}
+	m, d := types.MapValue(elemType, vals)
+	diags.Append(d...)
+	return m
+}
 
-	config.Entries = entries
-	if config.Entries == nil {
-		config.Entries = []DatabaseEntryDataModel{}
+// entryFromRawPage converts a query result row to an entry.
+func entryFromRawPage(page rawPage, archived bool) (DatabaseEntryDataModel, error) {
+	entry := DatabaseEntryDataModel{
+		ID:        types.StringValue(normalizeID(page.ID)),
+		Title:     types.StringValue(""),
+		URL:       types.StringValue(page.URL),
+		PublicURL: types.StringValue(page.PublicURL),
+		Archived:  types.BoolValue(archived),
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+	props := make(map[string]attr.Value, len(page.Properties))
+	for name, prop := range page.Properties {
+		val := rawPropertyToString(prop)
+		props[name] = types.StringValue(val)
+		if prop.Type == "title" {
+			entry.Title = types.StringValue(val)
+		}
+	}
+
+	mapVal, diags := types.MapValue(types.StringType, props)
+	if diags.HasError() {
+		return entry, fmt.Errorf("building properties of entry %s: %v", entry.ID.ValueString(), diags)
+	}
+	entry.Properties = mapVal
+
+	var peopleDiags diag.Diagnostics
+	entry.People = rawPeopleProperties(page.Properties, &peopleDiags)
+	if peopleDiags.HasError() {
+		return entry, fmt.Errorf("building people of entry %s: %v", entry.ID.ValueString(), peopleDiags)
+	}
+
+	var datesDiags diag.Diagnostics
+	entry.Dates = rawDateProperties(page.Properties, &datesDiags)
+	if datesDiags.HasError() {
+		return entry, fmt.Errorf("building dates of entry %s: %v", entry.ID.ValueString(), datesDiags)
+	}
+
+	var computedDiags diag.Diagnostics
+	entry.ComputedValues = rawComputedValues(page.Properties, &computedDiags)
+	if computedDiags.HasError() {
+		return entry, fmt.Errorf("building computed values of entry %s: %v", entry.ID.ValueString(), computedDiags)
+	}
+	return entry, nil
+}
+
+// datePropertyType is the type of the value of a date property in the dates
+// map.
+var datePropertyType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"start":     types.StringType,
+	"end":       types.StringType,
+	"time_zone": types.StringType,
+}}
+
+// rawDateProperties returns the values of the date properties among props,
+// keyed by property name. An empty date is null.
+func rawDateProperties(props map[string]rawProperty, diags *diag.Diagnostics) types.Map {
+	optional := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+	vals := map[string]attr.Value{}
+	for name, prop := range props {
+		if prop.Type != "date" {
+			continue
+		}
+		if prop.Date == nil {
+			vals[name] = types.ObjectNull(datePropertyType.AttrTypes)
+			continue
+		}
+		obj, d := types.ObjectValue(datePropertyType.AttrTypes, map[string]attr.Value{
+			"start":     types.StringValue(prop.Date.Start),
+			"end":       optional(prop.Date.End),
+			"time_zone": optional(prop.Date.TimeZone),
+		})
+		diags.Append(d...)
+		vals[name] = obj
+	}
+	m, d := types.MapValue(datePropertyType, vals)
+	diags.Append(d...)
+	return m
 }
 
 // Raw JSON types for manual parsing (bypasses SDK's strict type checking)
@@ -190,9 +498,13 @@ type rawRequestStatus struct {
 }
 
 type rawPage struct {
-	ID         string                 `json:"id"`
-	URL        string                 `json:"url"`
-	Properties map[string]rawProperty `json:"properties"`
+	ID          string                 `json:"id"`
+	URL         string                 `json:"url"`
+	PublicURL   string                 `json:"public_url"`
+	Archived    bool                   `json:"archived"`
+	InTrash     bool                   `json:"in_trash"`
+	CreatedTime string                 `json:"created_time"`
+	Properties  map[string]rawProperty `json:"properties"`
 }
 
 type rawProperty struct {
@@ -225,8 +537,23 @@ type rawOption struct {
 }
 
 type rawDate struct {
-	Start string `json:"start"`
-	End   string `json:"end,omitempty"`
+	Start    string `json:"start"`
+	End      string `json:"end,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// String renders the date as its start, followed by "/" and its end for a
+// range (an ISO 8601 interval), and its time zone in parentheses when it
+// has one.
+func (d *rawDate) String() string {
+	s := d.Start
+	if d.End != "" {
+		s += "/" + d.End
+	}
+	if d.TimeZone != "" {
+		s += " (" + d.TimeZone + ")"
+	}
+	return s
 }
 
 type rawUser struct {
@@ -247,9 +574,10 @@ type rawFormula struct {
 }
 
 type rawRollup struct {
-	Type   string   `json:"type"`
-	Number *float64 `json:"number,omitempty"`
-	Date   *rawDate `json:"date,omitempty"`
+	Type   string        `json:"type"`
+	Number *float64      `json:"number,omitempty"`
+	Date   *rawDate      `json:"date,omitempty"`
+	Array  []rawProperty `json:"array,omitempty"`
 }
 
 type rawUniqueID struct {
@@ -265,12 +593,59 @@ type rawRichText struct {
 	PlainText string `json:"plain_text"`
 }
 
+// selectedPropertyIDs returns the IDs of the named properties plus the title
+// property, for the filter_properties query parameter, which takes IDs.
+func (d *DatabaseEntriesDataSource) selectedPropertyIDs(ctx context.Context, databaseID string, names []string) ([]string, error) {
+	db, err := getDatabaseSchema(ctx, d.client, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, prop := range db.Properties {
+		if prop.GetType() == notionapi.PropertyConfigTypeTitle {
+			ids = append(ids, string(prop.GetID()))
+		}
+	}
+	for _, name := range names {
+		prop, ok := db.Properties[name]
+		if !ok {
+			return nil, fmt.Errorf("database %s has no property named %q", databaseID, name)
+		}
+		if prop.GetType() != notionapi.PropertyConfigTypeTitle {
+			ids = append(ids, string(prop.GetID()))
+		}
+	}
+	return ids, nil
+}
+
+// modifiedAfterFilter returns the query filter for entries last edited at or
+// after since, an RFC 3339 timestamp or a date. The time is rounded down to
+// the minute, the precision of last_edited_time, so an entry edited in the
+// same minute as since is included rather than missed.
+func modifiedAfterFilter(since string) (map[string]interface{}, error) {
+	t, _, err := parseNotionDate(since)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"timestamp": "last_edited_time",
+		"last_edited_time": map[string]string{
+			"on_or_after": t.UTC().Truncate(time.Minute).Format(time.RFC3339),
+		},
+	}, nil
+}
+
 // queryDatabaseRaw queries the Notion API directly, bypassing the SDK's
 // strict property type checking that fails on unsupported types like "place".
-func (d *DatabaseEntriesDataSource) queryDatabaseRaw(ctx context.Context, databaseID string, startCursor string) (*rawQueryResponse, error) {
+// A non-nil filterProperties limits each returned page to those property IDs.
+func (d *DatabaseEntriesDataSource) queryDatabaseRaw(ctx context.Context, databaseID string, query map[string]interface{}, filterProperties []string, startCursor string) (*rawQueryResponse, error) {
 	body := map[string]interface{}{
 		"page_size": 100,
 	}
+	for k, v := range query {
+		body[k] = v
+	}
 	if startCursor != "" {
 		body["start_cursor"] = startCursor
 	}
@@ -280,29 +655,17 @@ func (d *DatabaseEntriesDataSource) queryDatabaseRaw(ctx context.Context, databa
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := fmt.Sprintf("https://api.notion.com/v1/databases/%s/query", databaseID)
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	url := fmt.Sprintf("%s/databases/%s/query", notionAPIBaseURL, databaseID)
+	if filterProperties != nil {
+		url += "?" + filterPropertiesQuery(filterProperties)
 	}
-
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", d.client.Token.String()))
-	httpReq.Header.Set("Notion-Version", "2022-06-28")
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	httpResp, err := http.DefaultClient.Do(httpReq)
+	token, err := tokenForClient(d.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query database: %w", err)
+		return nil, err
 	}
-	defer httpResp.Body.Close()
-
-	respBody, err := io.ReadAll(httpResp.Body)
+	respBody, err := notionAPICall(ctx, http.MethodPost, url, token, notionSDKAPIVersion, bodyBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if httpResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Notion API error (status %d): %s", httpResp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("failed to query database: %w", err)
 	}
 
 	var result rawQueryResponse
@@ -313,6 +676,17 @@ func (d *DatabaseEntriesDataSource) queryDatabaseRaw(ctx context.Context, databa
 	return &result, nil
 }
 
+// filterPropertiesQuery builds the filter_properties query string for ids.
+// Property IDs come back from the API already percent-encoded (e.g.
+// "%3AUPp"), so they are appended verbatim rather than encoded again.
+func filterPropertiesQuery(ids []string) string {
+	params := make([]string, len(ids))
+	for i, id := range ids {
+		params[i] = "filter_properties=" + id
+	}
+	return strings.Join(params, "&")
+}
+
 // rawPropertyToString converts a raw property to its string representation.
 // Unknown property types are gracefully returned as empty strings.
 func rawPropertyToString(prop rawProperty) string {
@@ -339,7 +713,7 @@ func rawPropertyToString(prop rawProperty) string {
 		return strings.Join(names, ", ")
 	case "date":
 		if prop.Date != nil {
-			return prop.Date.Start
+			return prop.Date.String()
 		}
 		return ""
 	case "checkbox":
@@ -422,7 +796,7 @@ func rawPropertyToString(prop rawProperty) string {
 				}
 			case "date":
 				if prop.Formula.Date != nil {
-					return prop.Formula.Date.Start
+					return prop.Formula.Date.String()
 				}
 			}
 		}
@@ -436,8 +810,14 @@ func rawPropertyToString(prop rawProperty) string {
 				}
 			case "date":
 				if prop.Rollup.Date != nil {
-					return prop.Rollup.Date.Start
+					return prop.Rollup.Date.String()
+				}
+			case "array":
+				items := make([]string, len(prop.Rollup.Array))
+				for i, item := range prop.Rollup.Array {
+					items[i] = rawPropertyToString(item)
 				}
+				return strings.Join(items, ", ")
 			}
 		}
 		return ""
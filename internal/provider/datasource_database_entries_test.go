@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEntryCollector(t *testing.T) {
+	title := rawProperty{Type: "title", Title: []byte(`[{"plain_text":"Row"}]`)}
+	page := func(id string, archived bool) rawPage {
+		return rawPage{ID: id, Archived: archived, Properties: map[string]rawProperty{"Name": title}}
+	}
+
+	c := newEntryCollector(false)
+	entries, err := c.collect([]rawPage{page("a", false), page("b", true), page("c", false)})
+	if err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Title.ValueString() != "Row" {
+		t.Fatalf("expected 2 unarchived entries titled Row, got %+v", entries)
+	}
+
+	// A later slice returning an overlapping row doesn't duplicate it.
+	entries, err = c.collect([]rawPage{page("c", false), page("d", false)})
+	if err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID.ValueString() != "d" {
+		t.Errorf("expected only entry d, got %+v", entries)
+	}
+
+	if entries, _ := newEntryCollector(true).collect([]rawPage{page("b", true)}); len(entries) != 1 || !entries[0].Archived.ValueBool() {
+		t.Errorf("expected the archived entry when include_archived is set, got %+v", entries)
+	}
+}
+
+func TestEntryCollectorCap(t *testing.T) {
+	pages := make([]rawPage, maxDatabaseEntries+1)
+	for i := range pages {
+		pages[i] = rawPage{ID: fmt.Sprint(i)}
+	}
+
+	c := newEntryCollector(false)
+	if _, err := c.collect(pages[:maxDatabaseEntries]); err != nil {
+		t.Fatalf("collect() error = %v, want none at the cap", err)
+	}
+	if _, err := c.collect(pages[maxDatabaseEntries:]); !errors.Is(err, errTooManyEntries) {
+		t.Errorf("collect() error = %v, want errTooManyEntries past the cap", err)
+	}
+}
+
+func TestEntryCollectorCounts(t *testing.T) {
+	row := func(id, status string, tags ...string) rawPage {
+		opts := make([]rawOption, len(tags))
+		for i, tag := range tags {
+			opts[i] = rawOption{Name: tag}
+		}
+		props := map[string]rawProperty{
+			"Tags": {Type: "multi_select", MultiSelect: opts},
+		}
+		if status != "" {
+			props["Status"] = rawProperty{Type: "select", Select: &rawOption{Name: status}}
+		} else {
+			props["Status"] = rawProperty{Type: "select"}
+		}
+		return rawPage{ID: id, Properties: props}
+	}
+
+	c := newEntryCollector(false)
+	c.countBy = []string{"Status", "Tags", "Missing"}
+	if _, err := c.collect([]rawPage{
+		row("a", "Done", "ops", "infra"),
+		row("b", "Done", "ops"),
+		row("c", ""),
+		row("a", "Done", "ops", "infra"),
+	}); err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+
+	var diags diag.Diagnostics
+	counts := c.countsValue(&diags)
+	if diags.HasError() {
+		t.Fatalf("countsValue() diags = %v", diags)
+	}
+	want := map[string]map[string]int64{
+		"Status":  {"Done": 2, "": 1},
+		"Tags":    {"ops": 2, "infra": 1, "": 1},
+		"Missing": {},
+	}
+	var got map[string]map[string]int64
+	if d := counts.ElementsAs(context.Background(), &got, false); d.HasError() {
+		t.Fatalf("ElementsAs() diags = %v", d)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("counts = %v, want %v", got, want)
+	}
+}
+
+func TestRawDateProperties(t *testing.T) {
+	var page rawPage
+	err := json.Unmarshal([]byte(`{
+		"properties": {
+			"Due": {"id": "a", "type": "date", "date": {"start": "2026-01-15", "end": null, "time_zone": null}},
+			"Sprint": {"id": "b", "type": "date", "date": {"start": "2026-01-15T09:00:00.000", "end": "2026-01-29T17:00:00.000", "time_zone": "Europe/Berlin"}},
+			"Unset": {"id": "c", "type": "date", "date": null},
+			"Points": {"id": "d", "type": "number", "number": 3}
+		}
+	}`), &page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := rawPropertyToString(page.Properties["Due"]), "2026-01-15"; got != want {
+		t.Errorf("Due: got %q, want %q", got, want)
+	}
+	if got, want := rawPropertyToString(page.Properties["Sprint"]), "2026-01-15T09:00:00.000/2026-01-29T17:00:00.000 (Europe/Berlin)"; got != want {
+		t.Errorf("Sprint: got %q, want %q", got, want)
+	}
+
+	var diags diag.Diagnostics
+	got := rawDateProperties(page.Properties, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(got.Elements()) != 3 {
+		t.Fatalf("expected the three date properties, got %v", got)
+	}
+	if !got.Elements()["Unset"].IsNull() {
+		t.Errorf("Unset: expected null, got %v", got.Elements()["Unset"])
+	}
+	due := got.Elements()["Due"].(types.Object).Attributes()
+	if !due["start"].Equal(types.StringValue("2026-01-15")) || !due["end"].IsNull() || !due["time_zone"].IsNull() {
+		t.Errorf("Due: got %v", due)
+	}
+	sprint := got.Elements()["Sprint"].(types.Object).Attributes()
+	if !sprint["end"].Equal(types.StringValue("2026-01-29T17:00:00.000")) || !sprint["time_zone"].Equal(types.StringValue("Europe/Berlin")) {
+		t.Errorf("Sprint: got %v", sprint)
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// The schema check never fails on a mismatch itself: it reports one, and
+// the configuration decides what to do with it, typically a postcondition
+// or check block that gates a CI run.
+
+var _ datasource.DataSource = &DatabaseSchemaCheckDataSource{}
+
+type DatabaseSchemaCheckDataSource struct {
+	client *notionapi.Client
+}
+
+type DatabaseSchemaCheckDataSourceModel struct {
+	Database           NotionIDValue                   `tfsdk:"database"`
+	ExpectedProperties map[string]types.String         `tfsdk:"expected_properties"`
+	AllowAdditional    types.Bool                      `tfsdk:"allow_additional_properties"`
+	Passed             types.Bool                      `tfsdk:"passed"`
+	Differences        []DatabaseSchemaDifferenceModel `tfsdk:"differences"`
+}
+
+type DatabaseSchemaDifferenceModel struct {
+	Property     types.String `tfsdk:"property"`
+	Issue        types.String `tfsdk:"issue"`
+	ExpectedType types.String `tfsdk:"expected_type"`
+	ActualType   types.String `tfsdk:"actual_type"`
+}
+
+func NewDatabaseSchemaCheckDataSource() datasource.DataSource {
+	return &DatabaseSchemaCheckDataSource{}
+}
+
+func (d *DatabaseSchemaCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_schema_check"
+}
+
+func (d *DatabaseSchemaCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Compare a database's schema against the properties it is expected to have.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "The ID of the database to check.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+			},
+			"expected_properties": schema.MapAttribute{
+				Description: "Map of the property names the database must have to their types, such as title, rich_text or select.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"allow_additional_properties": schema.BoolAttribute{
+				Description: "Whether the database may have properties that aren't in expected_properties. Defaults to true.",
+				Optional:    true,
+			},
+			"passed": schema.BoolAttribute{
+				Description: "Whether the database's schema matches expected_properties.",
+				Computed:    true,
+			},
+			"differences": schema.ListNestedAttribute{
+				Description: "The ways the database's schema differs from expected_properties, ordered by property name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"property": schema.StringAttribute{
+							Description: "The name of the property.",
+							Computed:    true,
+						},
+						"issue": schema.StringAttribute{
+							Description: "missing, type_mismatch or unexpected.",
+							Computed:    true,
+						},
+						"expected_type": schema.StringAttribute{
+							Description: "The type in expected_properties, or null for an unexpected property.",
+							Computed:    true,
+						},
+						"actual_type": schema.StringAttribute{
+							Description: "The type in the database, or null for a missing property.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DatabaseSchemaCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	d.client = data.client
+}
+
+func (d *DatabaseSchemaCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config DatabaseSchemaCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	db, err := getDatabaseSchema(ctx, d.client, config.Database.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+
+	actual := make(map[string]string, len(db.Properties))
+	for name, prop := range db.Properties {
+		actual[name] = string(prop.GetType())
+	}
+	expected := make(map[string]string, len(config.ExpectedProperties))
+	for name, t := range config.ExpectedProperties {
+		expected[name] = t.ValueString()
+	}
+	allowAdditional := config.AllowAdditional.IsNull() || config.AllowAdditional.ValueBool()
+
+	config.Differences = schemaDifferences(expected, actual, allowAdditional)
+	config.Passed = types.BoolValue(len(config.Differences) == 0)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// schemaDifferences compares the property types of a database, actual,
+// against expected, both keyed by property name. Properties only in actual
+// are differences unless allowAdditional is set.
+func schemaDifferences(expected, actual map[string]string, allowAdditional bool) []DatabaseSchemaDifferenceModel {
+	names := make([]string, 0, len(expected)+len(actual))
+	for name := range expected {
+		names = append(names, name)
+	}
+	for name := range actual {
+		if _, ok := expected[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diffs := []DatabaseSchemaDifferenceModel{}
+	for _, name := range names {
+		want, inExpected := expected[name]
+		got, inActual := actual[name]
+		diff := DatabaseSchemaDifferenceModel{
+			Property:     types.StringValue(name),
+			ExpectedType: types.StringNull(),
+			ActualType:   types.StringNull(),
+		}
+		switch {
+		case !inActual:
+			diff.Issue = types.StringValue("missing")
+			diff.ExpectedType = types.StringValue(want)
+		case !inExpected:
+			if allowAdditional {
+				continue
+			}
+			diff.Issue = types.StringValue("unexpected")
+			diff.ActualType = types.StringValue(got)
+		case want != got:
+			diff.Issue = types.StringValue("type_mismatch")
+			diff.ExpectedType = types.StringValue(want)
+			diff.ActualType = types.StringValue(got)
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
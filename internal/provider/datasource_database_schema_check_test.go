@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSchemaDifferences(t *testing.T) {
+	expected := map[string]string{
+		"Name":     "title",
+		"Status":   "status",
+		"Priority": "select",
+		"Due":      "date",
+	}
+	actual := map[string]string{
+		"Name":     "title",
+		"Status":   "select",
+		"Due":      "date",
+		"Notes":    "rich_text",
+		"Assignee": "people",
+	}
+
+	if got := schemaDifferences(expected, expected, false); len(got) != 0 {
+		t.Errorf("expected no differences for a matching schema, got %+v", got)
+	}
+
+	got := schemaDifferences(expected, actual, true)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 differences, got %+v", got)
+	}
+	if got[0].Property.ValueString() != "Priority" || got[0].Issue.ValueString() != "missing" ||
+		got[0].ExpectedType.ValueString() != "select" || !got[0].ActualType.IsNull() {
+		t.Errorf("unexpected first difference: %+v", got[0])
+	}
+	if got[1].Property.ValueString() != "Status" || got[1].Issue.ValueString() != "type_mismatch" ||
+		got[1].ExpectedType.ValueString() != "status" || got[1].ActualType.ValueString() != "select" {
+		t.Errorf("unexpected second difference: %+v", got[1])
+	}
+
+	got = schemaDifferences(expected, actual, false)
+	var props []string
+	for _, d := range got {
+		props = append(props, d.Property.ValueString()+":"+d.Issue.ValueString())
+	}
+	want := []string{"Assignee:unexpected", "Notes:unexpected", "Priority:missing", "Status:type_mismatch"}
+	if !slices.Equal(props, want) {
+		t.Errorf("expected %v, got %v", want, props)
+	}
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestFilterDatabaseCandidates(t *testing.T) {
+	title := func(s string) json.RawMessage {
+		raw, _ := json.Marshal([]rawRichText{{PlainText: s}})
+		return raw
+	}
+	results := []rawSearchResult{
+		{ID: "a", Title: title("Tasks")},
+		{ID: "b", Title: title("Tasks Archive")},
+		{ID: "c", Title: title("Tasks")},
+	}
+
+	ids := func(rs []rawSearchResult) []string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	if got := ids(filterDatabaseCandidates(results, "Tasks", false)); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("fuzzy: got %v", got)
+	}
+	if got := ids(filterDatabaseCandidates(results, "Tasks", true)); !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("exact: got %v", got)
+	}
+}
+
+func TestSearchScopeUnder(t *testing.T) {
+	// team -> column block -> wiki -> tasks database; other is a sibling
+	// of team at the workspace root.
+	parents := map[string]rawParent{
+		"team":   {Type: "workspace"},
+		"col":    {Type: "page_id", PageID: "team"},
+		"wiki":   {Type: "block_id", BlockID: "col"},
+		"tasks":  {Type: "page_id", PageID: "wiki"},
+		"other":  {Type: "workspace"},
+		"looped": {Type: "page_id", PageID: "looped"},
+	}
+	fetches := 0
+	scope := &searchScope{
+		parentOf: func(_ context.Context, p rawParent) (rawParent, error) {
+			fetches++
+			parent, ok := parents[p.objectID()]
+			if !ok {
+				return rawParent{}, fmt.Errorf("unknown object %s", p.objectID())
+			}
+			return parent, nil
+		},
+	}
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		parent rawParent
+		want   bool
+	}{
+		{"direct child", rawParent{Type: "page_id", PageID: "team"}, true},
+		{"row of nested database", rawParent{Type: "database_id", DatabaseID: "tasks"}, true},
+		{"elsewhere", rawParent{Type: "page_id", PageID: "other"}, false},
+		{"workspace", rawParent{Type: "workspace"}, false},
+	}
+	for _, tc := range cases {
+		got, err := scope.under(ctx, tc.parent, "team")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %t, want %t", tc.name, got, tc.want)
+		}
+	}
+
+	before := fetches
+	if _, err := scope.under(ctx, rawParent{Type: "database_id", DatabaseID: "tasks"}, "team"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fetches != before {
+		t.Errorf("expected cached parents to be reused, got %d more fetches", fetches-before)
+	}
+
+	if _, err := scope.under(ctx, rawParent{Type: "page_id", PageID: "looped"}, "team"); err == nil {
+		t.Error("expected an error for a parent cycle")
+	}
+}
@@ -136,6 +136,8 @@ func TestAccBlocksDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.notion_blocks.children", "blocks.#", "2"),
 					resource.TestCheckResourceAttr("data.notion_blocks.children", "blocks.0.plain_text", "Welcome"),
 					resource.TestCheckResourceAttr("data.notion_blocks.children", "blocks.1.plain_text", "Hello, world!"),
+					resource.TestCheckResourceAttr("data.notion_blocks.children", "blocks.0.depth", "1"),
+					resource.TestCheckResourceAttrPair("data.notion_blocks.children", "blocks.0.parent_id", "notion_page.test", "id"),
 				),
 			},
 		},
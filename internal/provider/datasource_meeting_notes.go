@@ -113,13 +113,13 @@ func (d *MeetingNotesDataSource) Configure(_ context.Context, req datasource.Con
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.client = data.client
 }
 
 // meetingNoteRaw is the subset of fields surfaced as typed attributes. Any
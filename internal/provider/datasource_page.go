@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
@@ -24,8 +23,13 @@ type PageDataSourceModel struct {
 	Query        types.String `tfsdk:"query"`
 	ID           types.String `tfsdk:"id"`
 	ParentPageID types.String `tfsdk:"parent_page_id"`
+	ParentType   types.String `tfsdk:"parent_type"`
+	ParentDBID   types.String `tfsdk:"parent_database_id"`
+	Sort         types.String `tfsdk:"sort"`
+	SortDir      types.String `tfsdk:"sort_direction"`
 	Title        types.String `tfsdk:"title"`
 	URL          types.String `tfsdk:"url"`
+	PublicURL    types.String `tfsdk:"public_url"`
 }
 
 func NewPageDataSource() datasource.DataSource {
@@ -49,9 +53,30 @@ func (d *PageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Computed:    true,
 			},
 			"parent_page_id": schema.StringAttribute{
-				Description: "The ID of the parent page, if applicable.",
+				Description: "When set, only match pages under this page, directly or nested at any depth, and take the first " +
+					"such search hit. Otherwise, the ID of the matched page's parent page, if applicable.",
+				Optional: true,
+				Computed: true,
+			},
+			"parent_type": schema.StringAttribute{
+				Description: `The kind of the matched page's parent: "page_id", "database_id", "block_id" or "workspace".`,
+				Computed:    true,
+			},
+			"parent_database_id": schema.StringAttribute{
+				Description: "The ID of the database the matched page is a row of, and empty otherwise.",
 				Computed:    true,
 			},
+			"sort": schema.StringAttribute{
+				Description: `Sort search hits by "last_edited_time" instead of by relevance, so that, for example, the most ` +
+					`recently edited page matching the query is selected.`,
+				Optional:   true,
+				Validators: []validator.String{SearchSortValidator()},
+			},
+			"sort_direction": schema.StringAttribute{
+				Description: `Direction of sort: "ascending" or "descending" (default). Ignored unless sort is set.`,
+				Optional:    true,
+				Validators:  []validator.String{SortDirectionValidator()},
+			},
 			"title": schema.StringAttribute{
 				Description: "The title of the page.",
 				Computed:    true,
@@ -60,6 +85,10 @@ func (d *PageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Description: "The URL of the page.",
 				Computed:    true,
 			},
+			"public_url": schema.StringAttribute{
+				Description: "The public URL of the page when it is published to the web, and empty otherwise.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -68,13 +97,13 @@ func (d *PageDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.client = data.client
 }
 
 func (d *PageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -84,26 +113,75 @@ func (d *PageDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	result, err := d.searchPageRaw(ctx, config.Query.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error searching for page", err.Error())
-		return
+	sort := searchSort(config.Sort, config.SortDir)
+	scoped := !config.ParentPageID.IsNull() && !config.ParentPageID.IsUnknown() && config.ParentPageID.ValueString() != ""
+
+	var page *rawPageResult
+	if !scoped {
+		result, err := d.searchPageRaw(ctx, config.Query.ValueString(), 1, "", sort)
+		if err != nil {
+			resp.Diagnostics.AddError("Error searching for page", err.Error())
+			return
+		}
+		if len(result.Results) > 0 {
+			page = &result.Results[0]
+		}
+	} else {
+		token, err := tokenForClient(d.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Error searching for page", err.Error())
+			return
+		}
+		scope := newSearchScope(token)
+		var cursor string
+	search:
+		for {
+			result, err := d.searchPageRaw(ctx, config.Query.ValueString(), 100, cursor, sort)
+			if err != nil {
+				resp.Diagnostics.AddError("Error searching for page", err.Error())
+				return
+			}
+			for i := range result.Results {
+				under, err := scope.under(ctx, result.Results[i].Parent, config.ParentPageID.ValueString())
+				if err != nil {
+					resp.Diagnostics.AddError("Error searching for page",
+						fmt.Sprintf("Resolving the parents of page %s: %s", normalizeID(result.Results[i].ID), err))
+					return
+				}
+				if under {
+					page = &result.Results[i]
+					break search
+				}
+			}
+			if !result.HasMore || result.NextCursor == "" {
+				break
+			}
+			cursor = result.NextCursor
+		}
 	}
 
-	if len(result.Results) == 0 {
+	if page == nil {
 		resp.Diagnostics.AddError("Page not found",
 			fmt.Sprintf("No page found matching query: %s", config.Query.ValueString()))
 		return
 	}
 
-	page := result.Results[0]
 	config.ID = types.StringValue(normalizeID(page.ID))
 	config.URL = types.StringValue(page.URL)
+	config.PublicURL = types.StringValue(page.PublicURL)
 
-	if page.Parent.Type == "page_id" && page.Parent.PageID != "" {
-		config.ParentPageID = types.StringValue(normalizeID(page.Parent.PageID))
+	config.ParentType = types.StringValue(page.Parent.Type)
+	if page.Parent.Type == "database_id" {
+		config.ParentDBID = types.StringValue(normalizeID(page.Parent.DatabaseID))
 	} else {
-		config.ParentPageID = types.StringValue("")
+		config.ParentDBID = types.StringValue("")
+	}
+	if !scoped {
+		if page.Parent.Type == "page_id" && page.Parent.PageID != "" {
+			config.ParentPageID = types.StringValue(normalizeID(page.Parent.PageID))
+		} else {
+			config.ParentPageID = types.StringValue("")
+		}
 	}
 
 	// Extract title from properties
@@ -118,59 +196,55 @@ func (d *PageDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 }
 
 type rawPageSearchResponse struct {
-	Results []rawPageResult `json:"results"`
+	Results    []rawPageResult `json:"results"`
+	HasMore    bool            `json:"has_more"`
+	NextCursor string          `json:"next_cursor"`
 }
 
 type rawPageResult struct {
 	ID         string                 `json:"id"`
 	URL        string                 `json:"url"`
+	PublicURL  string                 `json:"public_url"`
 	Parent     rawParent              `json:"parent"`
 	Properties map[string]rawProperty `json:"properties"`
 }
 
 type rawParent struct {
-	Type   string `json:"type"`
-	PageID string `json:"page_id,omitempty"`
+	Type       string `json:"type"`
+	PageID     string `json:"page_id,omitempty"`
+	DatabaseID string `json:"database_id,omitempty"`
+	BlockID    string `json:"block_id,omitempty"`
 }
 
-func (d *PageDataSource) searchPageRaw(ctx context.Context, query string) (*rawPageSearchResponse, error) {
+func (d *PageDataSource) searchPageRaw(ctx context.Context, query string, pageSize int, cursor string, sort *notionapi.SortObject) (*rawPageSearchResponse, error) {
 	body := map[string]interface{}{
 		"query":     query,
-		"page_size": 1,
+		"page_size": pageSize,
 		"filter": map[string]string{
 			"value":    "page",
 			"property": "object",
 		},
 	}
+	if cursor != "" {
+		body["start_cursor"] = cursor
+	}
+	if sort != nil {
+		body["sort"] = sort
+	}
 
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.notion.com/v1/search", bytes.NewReader(bodyBytes))
+	token, err := tokenForClient(d.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", d.client.Token.String()))
-	httpReq.Header.Set("Notion-Version", "2022-06-28")
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	httpResp, err := http.DefaultClient.Do(httpReq)
+	respBody, err := notionAPICall(ctx, http.MethodPost, notionAPIBaseURL+"/search", token, notionSDKAPIVersion, bodyBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search: %w", err)
 	}
-	defer httpResp.Body.Close()
-
-	respBody, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if httpResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Notion API error (status %d): %s", httpResp.StatusCode, string(respBody))
-	}
 
 	var result rawPageSearchResponse
 	if err := json.Unmarshal(respBody, &result); err != nil {
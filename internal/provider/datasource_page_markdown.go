@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/jomei/notionapi"
 )
 
 var _ datasource.DataSource = &PageMarkdownDataSource{}
@@ -17,8 +16,8 @@ type PageMarkdownDataSource struct {
 }
 
 type PageMarkdownDataSourceModel struct {
-	PageID   types.String `tfsdk:"page_id"`
-	Markdown types.String `tfsdk:"markdown"`
+	PageID   NotionIDValue `tfsdk:"page_id"`
+	Markdown types.String  `tfsdk:"markdown"`
 }
 
 func NewPageMarkdownDataSource() datasource.DataSource {
@@ -35,6 +34,7 @@ func (d *PageMarkdownDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 		Attributes: map[string]schema.Attribute{
 			"page_id": schema.StringAttribute{
 				Description: "The ID of the page to retrieve markdown for.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 			},
 			"markdown": schema.StringAttribute{
@@ -49,13 +49,13 @@ func (d *PageMarkdownDataSource) Configure(_ context.Context, req datasource.Con
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.mdClient = newMarkdownClient(client)
+	d.mdClient = newMarkdownClient(data.client)
 }
 
 func (d *PageMarkdownDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -65,7 +65,7 @@ func (d *PageMarkdownDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	mdResp, err := d.mdClient.GetPageMarkdown(ctx, config.PageID.ValueString())
+	mdResp, err := d.mdClient.GetPageMarkdown(ctx, config.PageID.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading page markdown", err.Error())
 		return
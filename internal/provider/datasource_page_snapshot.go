@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// The snapshot is built from the raw block objects rather than the SDK's, so
+// block types and fields the SDK doesn't know survive into the archive.
+
+// maxSnapshotDepth bounds how deep a snapshot descends into nested blocks.
+const maxSnapshotDepth = 32
+
+var _ datasource.DataSource = &PageSnapshotDataSource{}
+
+type PageSnapshotDataSource struct {
+	client *notionapi.Client
+}
+
+type PageSnapshotDataSourceModel struct {
+	PageID      NotionIDValue `tfsdk:"page_id"`
+	ContentJSON types.String  `tfsdk:"content_json"`
+	BlockCount  types.Int64   `tfsdk:"block_count"`
+}
+
+func NewPageSnapshotDataSource() datasource.DataSource {
+	return &PageSnapshotDataSource{}
+}
+
+func (d *PageSnapshotDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_snapshot"
+}
+
+func (d *PageSnapshotDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Capture the full content of a Notion page, with its blocks nested recursively, as a single JSON document.",
+		Attributes: map[string]schema.Attribute{
+			"page_id": schema.StringAttribute{
+				Description: "The ID of the page to snapshot.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+			},
+			"content_json": schema.StringAttribute{
+				Description: "The page object and its blocks as JSON. Each block with children has them under a children key.",
+				Computed:    true,
+			},
+			"block_count": schema.Int64Attribute{
+				Description: "The number of blocks in the snapshot, at every depth.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *PageSnapshotDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	d.client = data.client
+}
+
+func (d *PageSnapshotDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PageSnapshotDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading page snapshot", err.Error())
+		return
+	}
+	pageID := config.PageID.ValueNotionID()
+
+	page, err := fetchPageBody(ctx, token, pageID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading page", err.Error())
+		return
+	}
+	blocks, count, err := snapshotBlocks(ctx, func(ctx context.Context, id string) ([]json.RawMessage, error) {
+		return fetchRawChildren(ctx, token, id)
+	}, pageID, 0)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading page content", err.Error())
+		return
+	}
+
+	content, err := json.Marshal(map[string]any{
+		"page":   json.RawMessage(page),
+		"blocks": blocks,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding page snapshot", err.Error())
+		return
+	}
+	config.ContentJSON = types.StringValue(string(content))
+	config.BlockCount = types.Int64Value(int64(count))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// snapshotBlocks returns the children of the block or page id, each with its
+// own children nested under a "children" key, and the number of blocks at
+// every depth. Child pages and databases are separate pages, so their
+// content is left out; they appear as the block that links to them.
+func snapshotBlocks(ctx context.Context, children func(ctx context.Context, id string) ([]json.RawMessage, error), id string, depth int) ([]map[string]any, int, error) {
+	if depth >= maxSnapshotDepth {
+		return nil, 0, fmt.Errorf("blocks nested deeper than %d levels under %s", maxSnapshotDepth, id)
+	}
+	raw, err := children(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	blocks := make([]map[string]any, 0, len(raw))
+	count := 0
+	for _, r := range raw {
+		var block map[string]any
+		if err := json.Unmarshal(r, &block); err != nil {
+			return nil, 0, fmt.Errorf("decoding a child of %s: %w", id, err)
+		}
+		count++
+		hasChildren, _ := block["has_children"].(bool)
+		blockType, _ := block["type"].(string)
+		if hasChildren && blockType != "child_page" && blockType != "child_database" {
+			blockID, _ := block["id"].(string)
+			nested, n, err := snapshotBlocks(ctx, children, blockID, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			block["children"] = nested
+			count += n
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, count, nil
+}
+
+// fetchRawChildren returns the raw child block objects of a block or page,
+// following the cursor through every page of results.
+func fetchRawChildren(ctx context.Context, token, id string) ([]json.RawMessage, error) {
+	var results []json.RawMessage
+	cursor := ""
+	for {
+		reqURL := fmt.Sprintf("%s/blocks/%s/children?page_size=100", notionAPIBaseURL, id)
+		if cursor != "" {
+			reqURL += "&start_cursor=" + url.QueryEscape(cursor)
+		}
+		body, err := notionAPICall(ctx, http.MethodGet, reqURL, token, notionSDKAPIVersion, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Results    []json.RawMessage `json:"results"`
+			HasMore    bool              `json:"has_more"`
+			NextCursor string            `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding children of %s: %w", id, err)
+		}
+		results = append(results, page.Results...)
+		if !page.HasMore || page.NextCursor == "" {
+			return results, nil
+		}
+		cursor = page.NextCursor
+	}
+}
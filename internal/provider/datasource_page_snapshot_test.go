@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestSnapshotBlocks(t *testing.T) {
+	tree := map[string][]string{
+		"page": {
+			`{"id":"a","type":"toggle","has_children":true}`,
+			`{"id":"b","type":"child_page","has_children":true}`,
+			`{"id":"c","type":"some_future_type","has_children":false,"some_future_type":{"x":1}}`,
+		},
+		"a": {`{"id":"a1","type":"paragraph","has_children":false}`},
+	}
+	children := func(_ context.Context, id string) ([]json.RawMessage, error) {
+		blocks, ok := tree[id]
+		if !ok {
+			return nil, fmt.Errorf("unexpected fetch of %s", id)
+		}
+		var out []json.RawMessage
+		for _, b := range blocks {
+			out = append(out, json.RawMessage(b))
+		}
+		return out, nil
+	}
+
+	blocks, count, err := snapshotBlocks(context.Background(), children, "page", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 blocks, got %d", count)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 top-level blocks, got %d", len(blocks))
+	}
+	nested, ok := blocks[0]["children"].([]map[string]any)
+	if !ok || len(nested) != 1 || nested[0]["id"] != "a1" {
+		t.Errorf("expected the toggle's child to be nested, got %#v", blocks[0]["children"])
+	}
+	if _, ok := blocks[1]["children"]; ok {
+		t.Error("expected the child page's content to be left out")
+	}
+	if _, ok := blocks[2]["some_future_type"]; !ok {
+		t.Error("expected unknown block fields to be kept")
+	}
+
+	loop := func(_ context.Context, id string) ([]json.RawMessage, error) {
+		return []json.RawMessage{json.RawMessage(`{"id":"x","type":"toggle","has_children":true}`)}, nil
+	}
+	if _, _, err := snapshotBlocks(context.Background(), loop, "page", 0); err == nil {
+		t.Error("expected an error for blocks nested too deep")
+	}
+}
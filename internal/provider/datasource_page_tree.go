@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// The tree is built from child_page blocks, so listing it costs one call
+// per page and per nested block rather than one more per page to read it.
+// Page URLs are built from the ID; notion.so redirects them to the page.
+
+var _ datasource.DataSource = &PageTreeDataSource{}
+
+type PageTreeDataSource struct {
+	client *notionapi.Client
+}
+
+type PageTreeDataSourceModel struct {
+	RootPageID NotionIDValue       `tfsdk:"root_page_id"`
+	Depth      types.Int64         `tfsdk:"depth"`
+	Pages      []PageTreePageModel `tfsdk:"pages"`
+}
+
+type PageTreePageModel struct {
+	ID       types.String `tfsdk:"id"`
+	Title    types.String `tfsdk:"title"`
+	ParentID types.String `tfsdk:"parent_id"`
+	URL      types.String `tfsdk:"url"`
+	Depth    types.Int64  `tfsdk:"depth"`
+}
+
+func NewPageTreeDataSource() datasource.DataSource {
+	return &PageTreeDataSource{}
+}
+
+func (d *PageTreeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_tree"
+}
+
+func (d *PageTreeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the pages under a Notion page, at every level down to a depth limit.",
+		Attributes: map[string]schema.Attribute{
+			"root_page_id": schema.StringAttribute{
+				Description: "The ID of the page whose descendant pages should be listed.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+			},
+			"depth": schema.Int64Attribute{
+				Description: fmt.Sprintf("How many levels of pages to list: 1 lists the child pages of the root, 2 also their "+
+					"child pages, and so on. Defaults to %d, the most allowed.", maxSnapshotDepth),
+				Optional: true,
+			},
+			"pages": schema.ListNestedAttribute{
+				Description: "The pages under root_page_id in document order, each followed by its own descendants.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The page ID.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The page title.",
+							Computed:    true,
+						},
+						"parent_id": schema.StringAttribute{
+							Description: "The ID of the page this page is a child of.",
+							Computed:    true,
+						},
+						"url": schema.StringAttribute{
+							Description: "A notion.so URL of the page.",
+							Computed:    true,
+						},
+						"depth": schema.Int64Attribute{
+							Description: "The level of the page: 1 for the child pages of the root, 2 for theirs, and so on.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PageTreeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	d.client = data.client
+}
+
+func (d *PageTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PageTreeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	depth := maxSnapshotDepth
+	if !config.Depth.IsNull() {
+		if d := config.Depth.ValueInt64(); d < 1 || d > maxSnapshotDepth {
+			resp.Diagnostics.AddAttributeError(path.Root("depth"), "Invalid depth",
+				fmt.Sprintf("depth must be between 1 and %d, got %d.", maxSnapshotDepth, d))
+			return
+		}
+		depth = int(config.Depth.ValueInt64())
+	}
+
+	pages, err := pageTree(ctx, func(ctx context.Context, id string) ([]notionapi.Block, error) {
+		return listChildBlocks(ctx, d.client, id)
+	}, config.RootPageID.ValueNotionID(), depth)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing pages", err.Error())
+		return
+	}
+	config.Pages = pages
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// pageTree returns the pages under rootID down to depth levels, in document
+// order, each followed by its descendants. Pages nested in other blocks,
+// such as toggles and columns, belong to the page holding those blocks.
+// Databases are not descended into, so their entries are left out.
+func pageTree(ctx context.Context, children func(ctx context.Context, id string) ([]notionapi.Block, error), rootID string, depth int) ([]PageTreePageModel, error) {
+	pages := []PageTreePageModel{}
+	// walk lists the blocks under id, which is the page pageID or one of
+	// its nested blocks, nested levels deep.
+	var walk func(id, pageID string, level, nested int) error
+	walk = func(id, pageID string, level, nested int) error {
+		if nested >= maxSnapshotDepth {
+			return fmt.Errorf("blocks nested deeper than %d levels under %s", maxSnapshotDepth, pageID)
+		}
+		blocks, err := children(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, b := range blocks {
+			blockID := normalizeID(string(b.GetID()))
+			switch v := b.(type) {
+			case *notionapi.ChildPageBlock:
+				pages = append(pages, PageTreePageModel{
+					ID:       types.StringValue(blockID),
+					Title:    types.StringValue(v.ChildPage.Title),
+					ParentID: types.StringValue(pageID),
+					URL:      types.StringValue("https://www.notion.so/" + blockID),
+					Depth:    types.Int64Value(int64(level)),
+				})
+				if b.GetHasChildren() && level < depth {
+					if err := walk(blockID, blockID, level+1, 0); err != nil {
+						return err
+					}
+				}
+			case *notionapi.ChildDatabaseBlock:
+				// Its entries are rows, not pages of the tree.
+			default:
+				if b.GetHasChildren() {
+					if err := walk(blockID, pageID, level, nested+1); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+	rootID = normalizeID(rootID)
+	if err := walk(rootID, rootID, 1, 0); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
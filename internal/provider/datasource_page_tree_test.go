@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestPageTree(t *testing.T) {
+	basic := func(id string, typ notionapi.BlockType, hasChildren bool) notionapi.BasicBlock {
+		return notionapi.BasicBlock{Object: "block", ID: notionapi.BlockID(id), Type: typ, HasChildren: hasChildren}
+	}
+	page := func(id, title string, hasChildren bool) notionapi.Block {
+		return &notionapi.ChildPageBlock{BasicBlock: basic(id, notionapi.BlockTypeChildPage, hasChildren),
+			ChildPage: struct {
+				Title string `json:"title"`
+			}{Title: title}}
+	}
+	tree := map[string][]notionapi.Block{
+		"root": {
+			&notionapi.ParagraphBlock{BasicBlock: basic("intro", notionapi.BlockTypeParagraph, false)},
+			page("handbook", "Handbook", true),
+			&notionapi.ToggleBlock{BasicBlock: basic("archive", notionapi.BlockTypeToggle, true)},
+			&notionapi.ChildDatabaseBlock{BasicBlock: basic("tasks", notionapi.BlockTypeChildDatabase, true)},
+		},
+		"handbook":   {page("onboarding", "Onboarding", true)},
+		"onboarding": {page("laptops", "Laptops", false)},
+		"archive":    {page("old", "Old", false)},
+	}
+	children := func(_ context.Context, id string) ([]notionapi.Block, error) {
+		blocks, ok := tree[id]
+		if !ok {
+			return nil, fmt.Errorf("unexpected fetch of %s", id)
+		}
+		return blocks, nil
+	}
+	flat := func(pages []PageTreePageModel) []string {
+		var out []string
+		for _, p := range pages {
+			out = append(out, fmt.Sprintf("%s<%s@%d", p.Title.ValueString(), p.ParentID.ValueString(), p.Depth.ValueInt64()))
+		}
+		return out
+	}
+
+	pages, err := pageTree(context.Background(), children, "root", maxSnapshotDepth)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []string{"Handbook<root@1", "Onboarding<handbook@2", "Laptops<onboarding@3", "Old<root@1"}
+	if got := flat(pages); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := pages[0].URL.ValueString(); got != "https://www.notion.so/handbook" {
+		t.Errorf("url = %q", got)
+	}
+
+	pages, err = pageTree(context.Background(), children, "root", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want = []string{"Handbook<root@1", "Onboarding<handbook@2", "Old<root@1"}
+	if got := flat(pages); !reflect.DeepEqual(got, want) {
+		t.Errorf("depth 2: got %v, want %v", got, want)
+	}
+}
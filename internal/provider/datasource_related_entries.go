@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// A page object lists at most 25 related pages per relation property, so
+// this data source reads the relation through the property item endpoint,
+// which pages through all of them.
+
+var _ datasource.DataSource = &RelatedEntriesDataSource{}
+
+type RelatedEntriesDataSource struct {
+	client *notionapi.Client
+}
+
+type RelatedEntriesDataSourceModel struct {
+	Entry    NotionIDValue       `tfsdk:"entry"`
+	Property types.String        `tfsdk:"property"`
+	Related  []RelatedEntryModel `tfsdk:"related"`
+}
+
+type RelatedEntryModel struct {
+	ID    types.String `tfsdk:"id"`
+	Title types.String `tfsdk:"title"`
+	URL   types.String `tfsdk:"url"`
+}
+
+func NewRelatedEntriesDataSource() datasource.DataSource {
+	return &RelatedEntriesDataSource{}
+}
+
+func (d *RelatedEntriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_related_entries"
+}
+
+func (d *RelatedEntriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the pages a database entry links to through a relation property.",
+		Attributes: map[string]schema.Attribute{
+			"entry": schema.StringAttribute{
+				Description: "The ID of the database entry.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+			},
+			"property": schema.StringAttribute{
+				Description: "The name of the relation property.",
+				Required:    true,
+			},
+			"related": schema.ListNestedAttribute{
+				Description: "The related pages, in the order Notion lists them.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the related page.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the related page.",
+							Computed:    true,
+						},
+						"url": schema.StringAttribute{
+							Description: "The URL of the related page.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RelatedEntriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	d.client = data.client
+}
+
+func (d *RelatedEntriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config RelatedEntriesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading related entries", err.Error())
+		return
+	}
+	entryID := config.Entry.ValueNotionID()
+	name := config.Property.ValueString()
+
+	body, err := fetchPageBody(ctx, token, entryID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database entry", err.Error())
+		return
+	}
+	var entry struct {
+		Properties map[string]struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		resp.Diagnostics.AddError("Error reading database entry", fmt.Sprintf("Decoding entry %s: %s", entryID, err))
+		return
+	}
+	prop, ok := entry.Properties[name]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("property"), "Property not found",
+			fmt.Sprintf("Entry %s has no property named %q.", entryID, name))
+		return
+	}
+	if prop.Type != "relation" {
+		resp.Diagnostics.AddAttributeError(path.Root("property"), "Not a relation property",
+			fmt.Sprintf("Property %q of entry %s is a %s property, not a relation.", name, entryID, prop.Type))
+		return
+	}
+
+	var ids []string
+	var cursor string
+	for {
+		page, err := fetchRelationItems(ctx, token, entryID, prop.ID, cursor)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading relation", err.Error())
+			return
+		}
+		ids = append(ids, page.ids()...)
+		if !page.HasMore || page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	config.Related = make([]RelatedEntryModel, 0, len(ids))
+	for _, id := range ids {
+		body, err := fetchPageBody(ctx, token, id)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading related page", err.Error())
+			return
+		}
+		var related rawPage
+		if err := json.Unmarshal(body, &related); err != nil {
+			resp.Diagnostics.AddError("Error reading related page", fmt.Sprintf("Decoding page %s: %s", id, err))
+			return
+		}
+		var title string
+		for _, p := range related.Properties {
+			if p.Type == "title" {
+				title = extractRichText(p.Title)
+				break
+			}
+		}
+		config.Related = append(config.Related, RelatedEntryModel{
+			ID:    types.StringValue(normalizeID(id)),
+			Title: types.StringValue(title),
+			URL:   types.StringValue(related.URL),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// relationItemsPage is one page of a relation property's items.
+type relationItemsPage struct {
+	Object  string `json:"object"`
+	Results []struct {
+		Relation struct {
+			ID string `json:"id"`
+		} `json:"relation"`
+	} `json:"results"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// ids returns the IDs of the related pages in the page of items.
+func (p *relationItemsPage) ids() []string {
+	ids := make([]string, 0, len(p.Results))
+	for _, r := range p.Results {
+		if r.Relation.ID != "" {
+			ids = append(ids, r.Relation.ID)
+		}
+	}
+	return ids
+}
+
+// fetchRelationItems retrieves one page of the items of the relation
+// property propertyID of a page.
+func fetchRelationItems(ctx context.Context, token, pageID, propertyID, cursor string) (*relationItemsPage, error) {
+	// Property IDs come back percent-encoded already (see
+	// filterPropertiesQuery), so they go into the path verbatim.
+	reqURL := fmt.Sprintf("%s/pages/%s/properties/%s", notionAPIBaseURL, pageID, propertyID)
+	if cursor != "" {
+		reqURL += "?start_cursor=" + url.QueryEscape(cursor)
+	}
+	body, err := notionAPICall(ctx, http.MethodGet, reqURL, token, notionSDKAPIVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+	var page relationItemsPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("decoding relation items: %w", err)
+	}
+	if page.Object != "list" {
+		return nil, fmt.Errorf("expected a list of relation items, got a %q object", page.Object)
+	}
+	return &page, nil
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFetchRelationItems(t *testing.T) {
+	prev := notionHTTPClient
+	t.Cleanup(func() { notionHTTPClient = prev })
+
+	var paths []string
+	notionHTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.EscapedPath())
+			body := `{"object":"list","results":[{"object":"property_item","type":"relation","relation":{"id":"r1"}},` +
+				`{"object":"property_item","type":"relation","relation":{"id":"r2"}}],"has_more":true,"next_cursor":"c2"}`
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/title"):
+				body = `{"object":"property_item","type":"title"}`
+			case req.URL.Query().Get("start_cursor") == "c2":
+				body = `{"object":"list","results":[{"object":"property_item","type":"relation","relation":{"id":"r3"}}],"has_more":false}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	ctx := context.Background()
+	first, err := fetchRelationItems(ctx, "token", "entry", "a%3Bb", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !slices.Equal(first.ids(), []string{"r1", "r2"}) || !first.HasMore || first.NextCursor != "c2" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	if paths[0] != "/v1/pages/entry/properties/a%3Bb" {
+		t.Errorf("property ID should be sent as returned by the API, got path %s", paths[0])
+	}
+
+	second, err := fetchRelationItems(ctx, "token", "entry", "a%3Bb", "c2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !slices.Equal(second.ids(), []string{"r3"}) || second.HasMore {
+		t.Fatalf("unexpected second page: %+v", second)
+	}
+
+	if _, err := fetchRelationItems(ctx, "token", "entry", "title", ""); err == nil {
+		t.Error("expected an error for a property that isn't paginated")
+	}
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// Like notion_database_schema_check, the relation check reports drift
+// rather than failing on it, so a re-pointed relation can gate a run or
+// just raise a warning.
+
+var _ datasource.DataSource = &RelationCheckDataSource{}
+
+type RelationCheckDataSource struct {
+	client *notionapi.Client
+}
+
+type RelationCheckDataSourceModel struct {
+	Database          NotionIDValue             `tfsdk:"database"`
+	ExpectedRelations map[string]types.String   `tfsdk:"expected_relations"`
+	Passed            types.Bool                `tfsdk:"passed"`
+	Differences       []RelationDifferenceModel `tfsdk:"differences"`
+}
+
+type RelationDifferenceModel struct {
+	Property         types.String `tfsdk:"property"`
+	Issue            types.String `tfsdk:"issue"`
+	ExpectedDatabase types.String `tfsdk:"expected_database"`
+	ActualDatabase   types.String `tfsdk:"actual_database"`
+}
+
+func NewRelationCheckDataSource() datasource.DataSource {
+	return &RelationCheckDataSource{}
+}
+
+func (d *RelationCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_relation_check"
+}
+
+func (d *RelationCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Check that a database's relation properties point at the databases they are expected to.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "The ID of the database whose relations to check.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+			},
+			"expected_relations": schema.MapAttribute{
+				Description: "Map of relation property names to the ID of the database each must point at.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"passed": schema.BoolAttribute{
+				Description: "Whether every relation in expected_relations points at its expected database.",
+				Computed:    true,
+			},
+			"differences": schema.ListNestedAttribute{
+				Description: "The relations that don't match expected_relations, ordered by property name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"property": schema.StringAttribute{
+							Description: "The name of the property.",
+							Computed:    true,
+						},
+						"issue": schema.StringAttribute{
+							Description: "missing, not_relation or wrong_target.",
+							Computed:    true,
+						},
+						"expected_database": schema.StringAttribute{
+							Description: "The ID of the database the relation should point at.",
+							Computed:    true,
+						},
+						"actual_database": schema.StringAttribute{
+							Description: "The ID of the database the relation points at, or null when the property is missing or not a relation.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RelationCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	d.client = data.client
+}
+
+func (d *RelationCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config RelationCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	db, err := getDatabaseSchema(ctx, d.client, config.Database.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+
+	expected := make(map[string]string, len(config.ExpectedRelations))
+	for name, id := range config.ExpectedRelations {
+		expected[name] = id.ValueString()
+	}
+
+	config.Differences = relationDifferences(expected, db.Properties)
+	config.Passed = types.BoolValue(len(config.Differences) == 0)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// relationDifferences checks that each property named in expected is a
+// relation to the database it maps to. IDs are compared in normalized form,
+// so expected may hold hyphenated or bare IDs.
+func relationDifferences(expected map[string]string, props notionapi.PropertyConfigs) []RelationDifferenceModel {
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	diffs := []RelationDifferenceModel{}
+	for _, name := range names {
+		want := normalizeID(expected[name])
+		diff := RelationDifferenceModel{
+			Property:         types.StringValue(name),
+			ExpectedDatabase: types.StringValue(want),
+			ActualDatabase:   types.StringNull(),
+		}
+		prop, ok := props[name]
+		if !ok {
+			diff.Issue = types.StringValue("missing")
+			diffs = append(diffs, diff)
+			continue
+		}
+		rel, ok := prop.(*notionapi.RelationPropertyConfig)
+		if !ok {
+			diff.Issue = types.StringValue("not_relation")
+			diffs = append(diffs, diff)
+			continue
+		}
+		got := normalizeID(string(rel.Relation.DatabaseID))
+		if got != want {
+			diff.Issue = types.StringValue("wrong_target")
+			diff.ActualDatabase = types.StringValue(got)
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestRelationDifferences(t *testing.T) {
+	props := notionapi.PropertyConfigs{
+		"Project": &notionapi.RelationPropertyConfig{
+			Type:     notionapi.PropertyConfigTypeRelation,
+			Relation: notionapi.RelationConfig{DatabaseID: "11111111-1111-1111-1111-111111111111"},
+		},
+		"Owner": &notionapi.RelationPropertyConfig{
+			Type:     notionapi.PropertyConfigTypeRelation,
+			Relation: notionapi.RelationConfig{DatabaseID: "33333333-3333-3333-3333-333333333333"},
+		},
+		"Notes": &notionapi.RichTextPropertyConfig{Type: notionapi.PropertyConfigTypeRichText},
+	}
+
+	if got := relationDifferences(map[string]string{"Project": "11111111111111111111111111111111"}, props); len(got) != 0 {
+		t.Errorf("expected no differences, got %+v", got)
+	}
+
+	got := relationDifferences(map[string]string{
+		"Project": "11111111111111111111111111111111",
+		"Owner":   "22222222-2222-2222-2222-222222222222",
+		"Notes":   "22222222222222222222222222222222",
+		"Team":    "22222222222222222222222222222222",
+	}, props)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 differences, got %+v", got)
+	}
+	want := []struct{ property, issue, actual string }{
+		{"Notes", "not_relation", ""},
+		{"Owner", "wrong_target", "33333333333333333333333333333333"},
+		{"Team", "missing", ""},
+	}
+	for i, w := range want {
+		d := got[i]
+		if d.Property.ValueString() != w.property || d.Issue.ValueString() != w.issue || d.ActualDatabase.ValueString() != w.actual {
+			t.Errorf("difference %d: expected %+v, got %+v", i, w, d)
+		}
+		if d.ExpectedDatabase.ValueString() != "22222222222222222222222222222222" {
+			t.Errorf("difference %d: expected a normalized expected_database, got %s", i, d.ExpectedDatabase.ValueString())
+		}
+	}
+}
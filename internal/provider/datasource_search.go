@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
@@ -19,17 +22,22 @@ type SearchDataSource struct {
 type SearchDataSourceModel struct {
 	Query        types.String        `tfsdk:"query"`
 	FilterObject types.String        `tfsdk:"filter_object"`
+	Sort         types.String        `tfsdk:"sort"`
+	SortDir      types.String        `tfsdk:"sort_direction"`
+	EditedAfter  types.String        `tfsdk:"last_edited_after"`
+	EditedBefore types.String        `tfsdk:"last_edited_before"`
 	Results      []SearchResultModel `tfsdk:"results"`
 }
 
 type SearchResultModel struct {
-	ID         types.String `tfsdk:"id"`
-	Object     types.String `tfsdk:"object"`
-	Title      types.String `tfsdk:"title"`
-	URL        types.String `tfsdk:"url"`
-	ParentType types.String `tfsdk:"parent_type"`
-	ParentID   types.String `tfsdk:"parent_id"`
-	Archived   types.Bool   `tfsdk:"archived"`
+	ID             types.String `tfsdk:"id"`
+	Object         types.String `tfsdk:"object"`
+	Title          types.String `tfsdk:"title"`
+	URL            types.String `tfsdk:"url"`
+	ParentType     types.String `tfsdk:"parent_type"`
+	ParentID       types.String `tfsdk:"parent_id"`
+	Archived       types.Bool   `tfsdk:"archived"`
+	LastEditedTime types.String `tfsdk:"last_edited_time"`
 }
 
 func NewSearchDataSource() datasource.DataSource {
@@ -52,6 +60,24 @@ func (d *SearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description: `Optionally restrict results to "page" or "database". Omit for both.`,
 				Optional:    true,
 			},
+			"sort": schema.StringAttribute{
+				Description: `Sort results by "last_edited_time" instead of by relevance.`,
+				Optional:    true,
+				Validators:  []validator.String{SearchSortValidator()},
+			},
+			"sort_direction": schema.StringAttribute{
+				Description: `Direction of sort: "ascending" or "descending" (default). Ignored unless sort is set.`,
+				Optional:    true,
+				Validators:  []validator.String{SortDirectionValidator()},
+			},
+			"last_edited_after": schema.StringAttribute{
+				Description: "Only return results last edited at or after this time, an RFC 3339 timestamp or a date.",
+				Optional:    true,
+			},
+			"last_edited_before": schema.StringAttribute{
+				Description: "Only return results last edited before this time, an RFC 3339 timestamp or a date.",
+				Optional:    true,
+			},
 			"results": schema.ListNestedAttribute{
 				Description: "All matching pages and databases.",
 				Computed:    true,
@@ -85,6 +111,10 @@ func (d *SearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							Description: "Whether the result is archived.",
 							Computed:    true,
 						},
+						"last_edited_time": schema.StringAttribute{
+							Description: "When the page or database was last edited, as an RFC 3339 timestamp.",
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -96,13 +126,13 @@ func (d *SearchDataSource) Configure(_ context.Context, req datasource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.client = data.client
 }
 
 func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -112,12 +142,26 @@ func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	var window searchWindow
+	var err error
+	if window.after, err = searchWindowBound(config.EditedAfter); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("last_edited_after"), "Invalid last_edited_after", err.Error())
+		return
+	}
+	if window.before, err = searchWindowBound(config.EditedBefore); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("last_edited_before"), "Invalid last_edited_before", err.Error())
+		return
+	}
+	sort := searchSort(config.Sort, config.SortDir)
+
 	var cursor notionapi.Cursor
+paging:
 	for {
 		searchReq := &notionapi.SearchRequest{
 			Query:       config.Query.ValueString(),
 			StartCursor: cursor,
 			PageSize:    100,
+			Sort:        sort,
 		}
 		if !config.FilterObject.IsNull() && config.FilterObject.ValueString() != "" {
 			searchReq.Filter = notionapi.SearchFilter{
@@ -133,7 +177,13 @@ func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		}
 
 		for _, obj := range page.Results {
-			config.Results = append(config.Results, searchResultFor(obj))
+			edited := searchResultEditedTime(obj)
+			if window.passed(edited, sort) {
+				break paging
+			}
+			if window.contains(edited) {
+				config.Results = append(config.Results, searchResultFor(obj))
+			}
 		}
 
 		if !page.HasMore {
@@ -149,34 +199,112 @@ func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
 
+// searchSort returns the search sort for a data source's sort and
+// sort_direction, or nil when sort isn't set.
+func searchSort(sort, direction types.String) *notionapi.SortObject {
+	if sort.ValueString() == "" {
+		return nil
+	}
+	dir := notionapi.SortOrderDESC
+	if direction.ValueString() != "" {
+		dir = notionapi.SortOrder(direction.ValueString())
+	}
+	return &notionapi.SortObject{
+		Timestamp: notionapi.TimestampType(sort.ValueString()),
+		Direction: dir,
+	}
+}
+
+// searchWindow bounds the last_edited_time of search results. Either bound
+// may be nil.
+type searchWindow struct {
+	after, before *time.Time
+}
+
+// contains reports whether edited is at or after w.after and before
+// w.before.
+func (w searchWindow) contains(edited time.Time) bool {
+	if w.after != nil && edited.Before(*w.after) {
+		return false
+	}
+	if w.before != nil && !edited.Before(*w.before) {
+		return false
+	}
+	return true
+}
+
+// passed reports whether, with results ordered by sort, a result edited at
+// edited means no later result can fall in w, so paging can stop. Search
+// results are in relevance order unless sorted by last_edited_time, in
+// which case they never pass the window.
+func (w searchWindow) passed(edited time.Time, sort *notionapi.SortObject) bool {
+	if sort == nil || sort.Timestamp != notionapi.TimestampLastEdited {
+		return false
+	}
+	if sort.Direction == notionapi.SortOrderASC {
+		return w.before != nil && !edited.Before(*w.before)
+	}
+	return w.after != nil && edited.Before(*w.after)
+}
+
+// searchWindowBound parses a last_edited_after or last_edited_before value,
+// returning nil when it isn't set. Notion records last_edited_time to the
+// minute, so the bound is rounded down to the minute.
+func searchWindowBound(v types.String) (*time.Time, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	t, _, err := parseNotionDate(v.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	t = t.UTC().Truncate(time.Minute)
+	return &t, nil
+}
+
+// searchResultEditedTime returns the last_edited_time of a search result.
+func searchResultEditedTime(obj notionapi.Object) time.Time {
+	switch v := obj.(type) {
+	case *notionapi.Page:
+		return v.LastEditedTime
+	case *notionapi.Database:
+		return v.LastEditedTime
+	default:
+		return time.Time{}
+	}
+}
+
 // searchResultFor converts a Notion search result (Page or Database) into the
 // flat representation we surface to Terraform.
 func searchResultFor(obj notionapi.Object) SearchResultModel {
 	switch v := obj.(type) {
 	case *notionapi.Page:
 		return SearchResultModel{
-			ID:         types.StringValue(normalizeID(string(v.ID))),
-			Object:     types.StringValue(string(v.Object)),
-			Title:      types.StringValue(pageTitle(v)),
-			URL:        types.StringValue(v.URL),
-			ParentType: types.StringValue(string(v.Parent.Type)),
-			ParentID:   types.StringValue(parentID(v.Parent)),
-			Archived:   types.BoolValue(v.Archived),
+			ID:             types.StringValue(normalizeID(string(v.ID))),
+			Object:         types.StringValue(string(v.Object)),
+			Title:          types.StringValue(pageTitle(v)),
+			URL:            types.StringValue(v.URL),
+			ParentType:     types.StringValue(string(v.Parent.Type)),
+			ParentID:       types.StringValue(parentID(v.Parent)),
+			Archived:       types.BoolValue(v.Archived),
+			LastEditedTime: types.StringValue(v.LastEditedTime.UTC().Format(time.RFC3339)),
 		}
 	case *notionapi.Database:
 		return SearchResultModel{
-			ID:         types.StringValue(normalizeID(string(v.ID))),
-			Object:     types.StringValue(string(v.Object)),
-			Title:      types.StringValue(richTextPlain(v.Title)),
-			URL:        types.StringValue(v.URL),
-			ParentType: types.StringValue(string(v.Parent.Type)),
-			ParentID:   types.StringValue(parentID(v.Parent)),
-			Archived:   types.BoolValue(v.Archived),
+			ID:             types.StringValue(normalizeID(string(v.ID))),
+			Object:         types.StringValue(string(v.Object)),
+			Title:          types.StringValue(richTextPlain(v.Title)),
+			URL:            types.StringValue(v.URL),
+			ParentType:     types.StringValue(string(v.Parent.Type)),
+			ParentID:       types.StringValue(parentID(v.Parent)),
+			Archived:       types.BoolValue(v.Archived),
+			LastEditedTime: types.StringValue(v.LastEditedTime.UTC().Format(time.RFC3339)),
 		}
 	default:
 		return SearchResultModel{
-			ID:     types.StringValue(""),
-			Object: types.StringValue("unknown"),
+			ID:             types.StringValue(""),
+			Object:         types.StringValue("unknown"),
+			LastEditedTime: types.StringNull(),
 		}
 	}
 }
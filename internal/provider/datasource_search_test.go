@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestSearchWindow(t *testing.T) {
+	after, err := searchWindowBound(types.StringValue("2026-03-01T09:15:42Z"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := time.Date(2026, 3, 1, 9, 15, 0, 0, time.UTC); !after.Equal(want) {
+		t.Errorf("expected the bound rounded down to %s, got %s", want, after)
+	}
+	before, err := searchWindowBound(types.StringValue("2026-03-02"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if unset, err := searchWindowBound(types.StringNull()); unset != nil || err != nil {
+		t.Errorf("expected no bound when unset, got %v, %v", unset, err)
+	}
+	if _, err := searchWindowBound(types.StringValue("last week")); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+
+	w := searchWindow{after: after, before: before}
+	cases := []struct {
+		edited time.Time
+		want   bool
+	}{
+		{time.Date(2026, 3, 1, 9, 14, 0, 0, time.UTC), false},
+		{time.Date(2026, 3, 1, 9, 15, 0, 0, time.UTC), true},
+		{time.Date(2026, 3, 1, 23, 59, 0, 0, time.UTC), true},
+		{time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tc := range cases {
+		if got := w.contains(tc.edited); got != tc.want {
+			t.Errorf("contains(%s): got %t, want %t", tc.edited, got, tc.want)
+		}
+	}
+
+	old := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	desc := &notionapi.SortObject{Timestamp: notionapi.TimestampLastEdited, Direction: notionapi.SortOrderDESC}
+	asc := &notionapi.SortObject{Timestamp: notionapi.TimestampLastEdited, Direction: notionapi.SortOrderASC}
+	if w.passed(old, nil) {
+		t.Error("expected results in relevance order never to pass the window")
+	}
+	if !w.passed(old, desc) || w.passed(recent, desc) {
+		t.Error("expected newest-first results to pass the window once older than last_edited_after")
+	}
+	if !w.passed(recent, asc) || w.passed(old, asc) {
+		t.Error("expected oldest-first results to pass the window once at or after last_edited_before")
+	}
+}
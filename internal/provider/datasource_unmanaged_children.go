@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var _ datasource.DataSource = &UnmanagedChildrenDataSource{}
+
+type UnmanagedChildrenDataSource struct {
+	client *notionapi.Client
+}
+
+type UnmanagedChildrenDataSourceModel struct {
+	ParentID   NotionIDValue         `tfsdk:"parent_id"`
+	ManagedIDs []NotionIDValue       `tfsdk:"managed_ids"`
+	Unmanaged  []UnmanagedChildModel `tfsdk:"unmanaged"`
+}
+
+type UnmanagedChildModel struct {
+	ID    types.String `tfsdk:"id"`
+	Type  types.String `tfsdk:"type"`
+	Title types.String `tfsdk:"title"`
+}
+
+func NewUnmanagedChildrenDataSource() datasource.DataSource {
+	return &UnmanagedChildrenDataSource{}
+}
+
+func (d *UnmanagedChildrenDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unmanaged_children"
+}
+
+func (d *UnmanagedChildrenDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the immediate children of a page that are not in a list of managed block and page IDs, to detect content added outside Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"parent_id": schema.StringAttribute{
+				Description: "The ID of the page or block whose children should be checked.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+			},
+			"managed_ids": schema.ListAttribute{
+				Description: "The IDs of the blocks, pages and databases under parent_id that Terraform manages.",
+				ElementType: NotionIDType{},
+				Required:    true,
+			},
+			"unmanaged": schema.ListNestedAttribute{
+				Description: "Immediate children of parent_id whose ID is not in managed_ids, in document order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The block ID. For child_page and child_database blocks, the ID of the page or database.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The block type (e.g. paragraph, child_page, child_database).",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of a child_page or child_database block. Empty for other blocks.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UnmanagedChildrenDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	d.client = data.client
+}
+
+func (d *UnmanagedChildrenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UnmanagedChildrenDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := make([]string, 0, len(config.ManagedIDs))
+	for _, id := range config.ManagedIDs {
+		if !id.IsNull() && !id.IsUnknown() {
+			managed = append(managed, id.ValueNotionID())
+		}
+	}
+
+	parentID := config.ParentID.ValueNotionID()
+	var children []notionapi.Block
+	var cursor notionapi.Cursor
+	for {
+		page, err := d.client.Block.GetChildren(ctx, notionapi.BlockID(parentID), &notionapi.Pagination{
+			StartCursor: cursor,
+			PageSize:    100,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing block children", err.Error())
+			return
+		}
+		children = append(children, page.Results...)
+		if !page.HasMore {
+			break
+		}
+		cursor = notionapi.Cursor(page.NextCursor)
+	}
+
+	config.Unmanaged = unmanagedChildren(children, managed)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// unmanagedChildren returns the children whose ID is not one of managed.
+// IDs are compared in normalized form, so managed may hold hyphenated or
+// bare IDs.
+func unmanagedChildren(children []notionapi.Block, managed []string) []UnmanagedChildModel {
+	known := make(map[string]bool, len(managed))
+	for _, id := range managed {
+		known[normalizeID(id)] = true
+	}
+	out := []UnmanagedChildModel{}
+	for _, b := range children {
+		model := blockDataModel(b)
+		if known[model.ID.ValueString()] {
+			continue
+		}
+		out = append(out, UnmanagedChildModel{
+			ID:    model.ID,
+			Type:  model.Type,
+			Title: model.Title,
+		})
+	}
+	return out
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestUnmanagedChildren(t *testing.T) {
+	children := []notionapi.Block{
+		&notionapi.ParagraphBlock{BasicBlock: notionapi.BasicBlock{ID: "11111111-1111-1111-1111-111111111111", Type: notionapi.BlockTypeParagraph}},
+		&notionapi.ChildPageBlock{
+			BasicBlock: notionapi.BasicBlock{ID: "22222222-2222-2222-2222-222222222222", Type: notionapi.BlockTypeChildPage},
+			ChildPage: struct {
+				Title string `json:"title"`
+			}{Title: "Scratch"},
+		},
+		&notionapi.DividerBlock{BasicBlock: notionapi.BasicBlock{ID: "33333333-3333-3333-3333-333333333333", Type: notionapi.BlockTypeDivider}},
+	}
+
+	got := unmanagedChildren(children, []string{"11111111111111111111111111111111", "33333333-3333-3333-3333-333333333333"})
+	if len(got) != 1 {
+		t.Fatalf("expected 1 unmanaged child, got %d", len(got))
+	}
+	if got[0].ID.ValueString() != "22222222222222222222222222222222" || got[0].Type.ValueString() != "child_page" || got[0].Title.ValueString() != "Scratch" {
+		t.Errorf("unexpected child: %+v", got[0])
+	}
+
+	if got := unmanagedChildren(nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty, non-nil list, got %#v", got)
+	}
+}
@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
@@ -13,14 +15,16 @@ import (
 var _ datasource.DataSource = &UserDataSource{}
 
 type UserDataSource struct {
-	client *notionapi.Client
+	provider *providerData
 }
 
 type UserDataSourceModel struct {
-	Email  types.String `tfsdk:"email"`
-	ID     types.String `tfsdk:"id"`
-	Name   types.String `tfsdk:"name"`
-	UserID types.String `tfsdk:"user_id"`
+	Email           types.String `tfsdk:"email"`
+	CaseInsensitive types.Bool   `tfsdk:"case_insensitive"`
+	FallbackName    types.String `tfsdk:"fallback_name"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	UserID          types.String `tfsdk:"user_id"`
 }
 
 func NewUserDataSource() datasource.DataSource {
@@ -33,12 +37,21 @@ func (d *UserDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 
 func (d *UserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Look up a Notion user by email address.",
+		Description: "Look up a Notion user by email address, or by name when no user has the address.",
 		Attributes: map[string]schema.Attribute{
 			"email": schema.StringAttribute{
 				Description: "The email address of the user.",
 				Required:    true,
 			},
+			"case_insensitive": schema.BoolAttribute{
+				Description: "Whether email and fallback_name are matched ignoring case. Defaults to true.",
+				Optional:    true,
+			},
+			"fallback_name": schema.StringAttribute{
+				Description: "The name of the user, matched when no user has the email address, e.g. because the user " +
+					"signs in with an SSO alias. It is an error if more than one user has the name.",
+				Optional: true,
+			},
 			"id": schema.StringAttribute{
 				Description: "The ID of the user (same as user_id).",
 				Computed:    true,
@@ -59,13 +72,13 @@ func (d *UserDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.provider = data
 }
 
 func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -75,36 +88,76 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	// List all users and filter by email
-	var cursor notionapi.Cursor
 	targetEmail := config.Email.ValueString()
+	equal := strings.EqualFold
+	if !config.CaseInsensitive.IsNull() && !config.CaseInsensitive.ValueBool() {
+		equal = func(a, b string) bool { return a == b }
+	}
+
+	user, err := findWorkspaceUser(ctx, d.provider, func(u *notionapi.User) bool {
+		return u.Person != nil && equal(u.Person.Email, targetEmail)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing users", err.Error())
+		return
+	}
 
-	for {
-		users, err := d.client.User.List(ctx, &notionapi.Pagination{
-			StartCursor: cursor,
-			PageSize:    100,
-		})
+	if user == nil && config.FallbackName.ValueString() != "" {
+		name := strings.TrimSpace(config.FallbackName.ValueString())
+		users, err := listWorkspaceUsers(ctx, d.provider)
 		if err != nil {
 			resp.Diagnostics.AddError("Error listing users", err.Error())
 			return
 		}
+		matches := usersNamed(users, name, equal)
+		switch len(matches) {
+		case 0:
+		case 1:
+			user = &matches[0]
+		default:
+			resp.Diagnostics.AddAttributeError(path.Root("fallback_name"), "Multiple users found",
+				fmt.Sprintf("No user has the email %s, and %d users are named %q: %s. Set email to the address of one of them.",
+					targetEmail, len(matches), name, describeUsers(matches)))
+			return
+		}
+	}
 
-		for _, user := range users.Results {
-			if user.Person != nil && user.Person.Email == targetEmail {
-				config.ID = types.StringValue(normalizeID(string(user.ID)))
-				config.Name = types.StringValue(user.Name)
-				config.UserID = types.StringValue(normalizeID(string(user.ID)))
-				resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
-				return
-			}
+	if user == nil {
+		detail := fmt.Sprintf("No user found with email: %s", targetEmail)
+		if !config.FallbackName.IsNull() {
+			detail += fmt.Sprintf(", or with name: %s", config.FallbackName.ValueString())
 		}
+		resp.Diagnostics.AddError("User not found", detail)
+		return
+	}
+
+	config.ID = types.StringValue(normalizeID(string(user.ID)))
+	config.Name = types.StringValue(user.Name)
+	config.UserID = types.StringValue(normalizeID(string(user.ID)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
 
-		if !users.HasMore {
-			break
+// usersNamed returns the people among users whose name equals name,
+// ignoring surrounding whitespace.
+func usersNamed(users []notionapi.User, name string, equal func(a, b string) bool) []notionapi.User {
+	var matches []notionapi.User
+	for _, u := range users {
+		if u.Type == notionapi.UserTypePerson && equal(strings.TrimSpace(u.Name), name) {
+			matches = append(matches, u)
 		}
-		cursor = notionapi.Cursor(users.NextCursor)
 	}
+	return matches
+}
 
-	resp.Diagnostics.AddError("User not found",
-		fmt.Sprintf("No user found with email: %s", targetEmail))
+// describeUsers lists users by ID and email, for diagnostics.
+func describeUsers(users []notionapi.User) string {
+	parts := make([]string, 0, len(users))
+	for _, u := range users {
+		email := ""
+		if u.Person != nil {
+			email = u.Person.Email
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", normalizeID(string(u.ID)), email))
+	}
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestUsersNamed(t *testing.T) {
+	users := []notionapi.User{
+		{ID: "u1", Type: notionapi.UserTypePerson, Name: "Ada Lovelace", Person: &notionapi.Person{Email: "ada@example.com"}},
+		{ID: "u2", Type: notionapi.UserTypePerson, Name: "ada lovelace ", Person: &notionapi.Person{Email: "ada@sso.example.com"}},
+		{ID: "u3", Type: notionapi.UserTypeBot, Name: "Ada Lovelace"},
+	}
+	exact := func(a, b string) bool { return a == b }
+
+	if got := usersNamed(users, "Ada Lovelace", exact); len(got) != 1 || got[0].ID != "u1" {
+		t.Errorf("exact: got %+v", got)
+	}
+	got := usersNamed(users, "Ada Lovelace", strings.EqualFold)
+	if len(got) != 2 {
+		t.Fatalf("case-insensitive: got %d users, want 2", len(got))
+	}
+	if want := "u1 (ada@example.com), u2 (ada@sso.example.com)"; describeUsers(got) != want {
+		t.Errorf("describeUsers: got %q, want %q", describeUsers(got), want)
+	}
+}
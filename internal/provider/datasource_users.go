@@ -7,13 +7,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/jomei/notionapi"
 )
 
 var _ datasource.DataSource = &UsersDataSource{}
 
 type UsersDataSource struct {
-	client *notionapi.Client
+	provider *providerData
 }
 
 type UsersDataSourceModel struct {
@@ -76,48 +75,37 @@ func (d *UsersDataSource) Configure(_ context.Context, req datasource.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.provider = data
 }
 
 func (d *UsersDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state UsersDataSourceModel
-	var cursor notionapi.Cursor
 
-	for {
-		page, err := d.client.User.List(ctx, &notionapi.Pagination{
-			StartCursor: cursor,
-			PageSize:    100,
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error listing users", err.Error())
-			return
-		}
+	users, err := listWorkspaceUsers(ctx, d.provider)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing users", err.Error())
+		return
+	}
 
-		for _, user := range page.Results {
-			model := UserDataModel{
-				ID:        types.StringValue(normalizeID(string(user.ID))),
-				Name:      types.StringValue(user.Name),
-				Type:      types.StringValue(string(user.Type)),
-				AvatarURL: types.StringValue(user.AvatarURL),
-			}
-			if user.Person != nil {
-				model.Email = types.StringValue(user.Person.Email)
-			} else {
-				model.Email = types.StringValue("")
-			}
-			state.Users = append(state.Users, model)
+	for _, user := range users {
+		model := UserDataModel{
+			ID:        types.StringValue(normalizeID(string(user.ID))),
+			Name:      types.StringValue(user.Name),
+			Type:      types.StringValue(string(user.Type)),
+			AvatarURL: types.StringValue(user.AvatarURL),
 		}
-
-		if !page.HasMore {
-			break
+		if user.Person != nil {
+			model.Email = types.StringValue(user.Person.Email)
+		} else {
+			model.Email = types.StringValue("")
 		}
-		cursor = notionapi.Cursor(page.NextCursor)
+		state.Users = append(state.Users, model)
 	}
 
 	if state.Users == nil {
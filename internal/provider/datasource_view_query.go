@@ -22,12 +22,12 @@ type ViewQueryDataSource struct {
 }
 
 type ViewQueryDataSourceModel struct {
-	ViewID      types.String `tfsdk:"view_id"`
-	PageSize    types.Int64  `tfsdk:"page_size"`
-	StartCursor types.String `tfsdk:"start_cursor"`
-	NextCursor  types.String `tfsdk:"next_cursor"`
-	HasMore     types.Bool   `tfsdk:"has_more"`
-	RawJSON     types.String `tfsdk:"raw_json"`
+	ViewID      NotionIDValue `tfsdk:"view_id"`
+	PageSize    types.Int64   `tfsdk:"page_size"`
+	StartCursor types.String  `tfsdk:"start_cursor"`
+	NextCursor  types.String  `tfsdk:"next_cursor"`
+	HasMore     types.Bool    `tfsdk:"has_more"`
+	RawJSON     types.String  `tfsdk:"raw_json"`
 }
 
 func NewViewQueryDataSource() datasource.DataSource {
@@ -47,6 +47,7 @@ func (d *ViewQueryDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 		Attributes: map[string]schema.Attribute{
 			"view_id": schema.StringAttribute{
 				Description: "ID of the view to query.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 			},
 			"page_size": schema.Int64Attribute{
@@ -77,13 +78,13 @@ func (d *ViewQueryDataSource) Configure(_ context.Context, req datasource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	d.client = client
+	d.client = data.client
 }
 
 func (d *ViewQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -113,7 +114,7 @@ func (d *ViewQueryDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	respBody, err := queryView(ctx, token, config.ViewID.ValueString(), bodyJSON)
+	respBody, err := queryView(ctx, token, config.ViewID.ValueNotionID(), bodyJSON)
 	if err != nil {
 		resp.Diagnostics.AddError("Error querying view", err.Error())
 		return
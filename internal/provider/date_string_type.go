@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Notion accepts dates either as a bare calendar date ("2025-01-01") or as an
+// RFC3339 datetime, and on read it hands back whichever shape it decides on —
+// formatNotionDate collapses midnight to date-only, and Notion itself may
+// shift a datetime into the workspace's time zone. Comparing those strings
+// byte-for-byte produced perpetual diffs, so date_properties uses this custom
+// element type whose semantic equality compares the instant/date instead.
+
+const notionDateOnlyLayout = "2006-01-02"
+
+var (
+	_ basetypes.StringTypable                    = DateStringType{}
+	_ basetypes.StringValuableWithSemanticEquals = DateStringValue{}
+)
+
+// DateStringType is a string type holding an ISO 8601 date or datetime.
+type DateStringType struct {
+	basetypes.StringType
+}
+
+func (t DateStringType) Equal(o attr.Type) bool {
+	other, ok := o.(DateStringType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t DateStringType) String() string {
+	return "DateStringType"
+}
+
+func (t DateStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DateStringValue{StringValue: in}, nil
+}
+
+func (t DateStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return DateStringValue{StringValue: stringValue}, nil
+}
+
+func (t DateStringType) ValueType(_ context.Context) attr.Value {
+	return DateStringValue{}
+}
+
+// DateStringValue is the value counterpart of DateStringType.
+type DateStringValue struct {
+	basetypes.StringValue
+}
+
+// NewDateStringValue returns a known DateStringValue.
+func NewDateStringValue(s string) DateStringValue {
+	return DateStringValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v DateStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(DateStringValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v DateStringValue) Type(_ context.Context) attr.Type {
+	return DateStringType{}
+}
+
+// StringSemanticEquals reports whether two date strings describe the same
+// date or instant. Unparseable values fall back to exact string comparison so
+// validation errors still surface rather than being masked.
+func (v DateStringValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(DateStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return notionDatesEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// parseNotionDate parses an ISO 8601 date ("2006-01-02") or RFC3339 datetime.
+// dateOnly reports which form matched.
+func parseNotionDate(s string) (t time.Time, dateOnly bool, err error) {
+	if t, err = time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	if t, err = time.Parse(notionDateOnlyLayout, s); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%q is not a valid ISO 8601 date (expected 2006-01-02 or RFC3339 such as 2006-01-02T15:04:05Z)", s)
+}
+
+// notionDatesEqual compares two date strings semantically:
+//   - two datetimes are equal when they denote the same instant, regardless
+//     of the offset they're written in;
+//   - a date-only value equals a datetime at midnight on that calendar date
+//     (in the datetime's own offset), which is how Notion echoes an all-day
+//     date that was submitted with an explicit time.
+func notionDatesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ta, aDateOnly, errA := parseNotionDate(a)
+	tb, bDateOnly, errB := parseNotionDate(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	switch {
+	case aDateOnly && bDateOnly:
+		return ta.Equal(tb)
+	case aDateOnly:
+		return isMidnightOn(tb, ta)
+	case bDateOnly:
+		return isMidnightOn(ta, tb)
+	default:
+		return ta.Equal(tb)
+	}
+}
+
+// isMidnightOn reports whether t is 00:00:00 on the calendar date of day.
+func isMidnightOn(t, day time.Time) bool {
+	if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+		return false
+	}
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := day.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
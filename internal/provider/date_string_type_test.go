@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNotionDatesEqual(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"2025-01-01", "2025-01-01", true},
+		{"2025-01-01", "2025-01-01T00:00:00Z", true},
+		{"2025-01-01T00:00:00Z", "2025-01-01", true},
+		{"2025-01-01", "2025-01-01T00:00:00+09:00", true},
+		{"2025-01-01T10:00:00+02:00", "2025-01-01T08:00:00Z", true},
+		{"2025-01-01", "2025-01-02", false},
+		{"2025-01-01", "2025-01-01T09:30:00Z", false},
+		{"2025-01-01T10:00:00Z", "2025-01-01T10:00:01Z", false},
+		{"not-a-date", "2025-01-01", false},
+	}
+	for _, tc := range cases {
+		if got := notionDatesEqual(tc.a, tc.b); got != tc.want {
+			t.Errorf("notionDatesEqual(%q, %q) = %t, want %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestDateStringValue_SemanticEquals(t *testing.T) {
+	prior := NewDateStringValue("2025-01-01")
+	equal, diags := prior.StringSemanticEquals(context.Background(), NewDateStringValue("2025-01-01T00:00:00Z"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !equal {
+		t.Error("expected date-only and midnight datetime to be semantically equal")
+	}
+}
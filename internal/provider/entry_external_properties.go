@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/jomei/notionapi"
+)
+
+// The typed property maps of notion_database_entry only read back the
+// properties they set, so a value someone sets in Notion on another property
+// never shows up as drift. With detect_external_properties, Read records the
+// values of the other properties in private state and warns about any that
+// differ from the ones recorded by the previous read. The warning shows in
+// the plan that refreshes the entry; the values it saw are recorded once
+// that refreshed state is saved.
+
+// externalPropertiesPrivateKey is the private state key holding the values
+// of the properties of an entry that Terraform doesn't manage, as last read.
+const externalPropertiesPrivateKey = "external_properties"
+
+// computedPropertyTypes are the property types whose values Notion computes,
+// which change without anyone editing the entry.
+var computedPropertyTypes = []string{
+	"formula", "rollup", "created_time", "created_by", "last_edited_time", "last_edited_by", "unique_id",
+}
+
+// externalPropertyValues returns the values of the properties of an entry,
+// keyed by name, that m doesn't manage: the ones that aren't its title, in
+// one of its typed property maps or sensitive_properties, or computed by
+// Notion. props are the raw
+// properties of page, which identifies the properties by ID for an m with
+// properties_by_id.
+func externalPropertyValues(page *notionapi.Page, props map[string]rawProperty, m *DatabaseEntryResourceModel) map[string]string {
+	managed := map[string]bool{}
+	for _, pm := range append(entryPropertyMaps(m), &m.SensitiveProperties) {
+		for key := range pm.Elements() {
+			managed[key] = true
+		}
+	}
+
+	values := make(map[string]string, len(props))
+	for name, prop := range props {
+		if prop.Type == "title" || containsString(computedPropertyTypes, prop.Type) || managed[name] {
+			continue
+		}
+		if p, ok := page.Properties[name]; ok && managed[string(p.GetID())] {
+			continue
+		}
+		values[name] = rawPropertyToString(prop)
+	}
+	return values
+}
+
+// externalPropertyChanges describes the properties whose value differs
+// between prior and current, sorted by name. Properties only in one of them
+// were added to or removed from the database, or started or stopped being
+// managed, and are left out.
+func externalPropertyChanges(prior, current map[string]string) []string {
+	var changes []string
+	for name, value := range current {
+		if old, ok := prior[name]; ok && old != value {
+			changes = append(changes, fmt.Sprintf("%q: %q -> %q", name, old, value))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// detectExternalProperties warns about the properties of an entry that
+// changed in Notion since the last read, then records their current values.
+func detectExternalProperties(ctx context.Context, prior privateStateGetter, next privateStateSetter, id string, current map[string]string) diag.Diagnostics {
+	raw, diags := prior.GetKey(ctx, externalPropertiesPrivateKey)
+	if diags.HasError() {
+		return diags
+	}
+	if len(raw) > 0 {
+		var recorded map[string]string
+		if err := json.Unmarshal(raw, &recorded); err != nil {
+			diags.AddError("Error reading private state", fmt.Sprintf("Decoding %s: %s", externalPropertiesPrivateKey, err))
+			return diags
+		}
+		if changes := externalPropertyChanges(recorded, current); len(changes) > 0 {
+			diags.AddWarning("Properties changed outside Terraform",
+				fmt.Sprintf("Properties of database entry %s that Terraform doesn't manage were changed in Notion:\n  %s",
+					id, strings.Join(changes, "\n  ")))
+		}
+	}
+
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		diags.AddError("Error writing private state", err.Error())
+		return diags
+	}
+	diags.Append(next.SetKey(ctx, externalPropertiesPrivateKey, encoded)...)
+	return diags
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestExternalPropertyValues(t *testing.T) {
+	checked := true
+	points := 3.0
+	page := &notionapi.Page{Properties: notionapi.Properties{
+		"Priority": &notionapi.SelectProperty{ID: "pR1o"},
+	}}
+	props := map[string]rawProperty{
+		"Name":     {Type: "title", Title: []byte(`[{"plain_text":"Row"}]`)},
+		"Status":   {Type: "select", Select: &rawOption{Name: "Done"}},
+		"Priority": {Type: "select", Select: &rawOption{Name: "High"}},
+		"Points":   {Type: "number", Number: &points},
+		"Reviewed": {Type: "checkbox", Checkbox: &checked},
+		"Created":  {Type: "created_time"},
+	}
+	m := &DatabaseEntryResourceModel{
+		SelectProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Status": types.StringValue("Done"),
+			"pR1o":   types.StringValue("High"),
+		}),
+	}
+
+	got := externalPropertyValues(page, props, m)
+	want := map[string]string{"Points": "3", "Reviewed": "true"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestExternalPropertyChanges(t *testing.T) {
+	prior := map[string]string{"Points": "3", "Reviewed": "false", "Owner": "Ada"}
+	current := map[string]string{"Points": "5", "Reviewed": "true", "Owner": "Ada", "Notes": "new"}
+
+	got := externalPropertyChanges(prior, current)
+	want := []string{`"Points": "3" -> "5"`, `"Reviewed": "false" -> "true"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDetectExternalProperties(t *testing.T) {
+	ctx := context.Background()
+	private := privateStateMap{}
+
+	diags := detectExternalProperties(ctx, private, private, "entry", map[string]string{"Points": "3"})
+	if diags.HasError() || diags.WarningsCount() != 0 {
+		t.Fatalf("expected no diagnostics on the first read, got %v", diags)
+	}
+
+	diags = detectExternalProperties(ctx, private, private, "entry", map[string]string{"Points": "3"})
+	if diags.WarningsCount() != 0 {
+		t.Errorf("expected no warning for unchanged values, got %v", diags)
+	}
+
+	diags = detectExternalProperties(ctx, private, private, "entry", map[string]string{"Points": "5"})
+	if diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), `"Points": "3" -> "5"`) {
+		t.Errorf("expected a warning about Points, got %v", diags)
+	}
+	if got := string(private[externalPropertiesPrivateKey]); got != `{"Points":"5"}` {
+		t.Errorf("expected the new values to be recorded, got %s", got)
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// The Notion API has no idempotency keys, so an apply that is interrupted
+// after Page.Create but before the state is saved leaves a row that the
+// next apply creates again. With idempotency_property set, create writes a
+// key derived from the configuration into that property, and first looks
+// for a live row that already has it: that is the row the interrupted apply
+// created, and it is adopted instead of duplicated. Only a key set in
+// idempotency_key identifies one entry; a key derived from the content is
+// shared by every entry with the same content, so a row that has one is
+// reported instead of adopted.
+
+// idempotencyKeyPrefix marks keys the provider derived itself.
+const idempotencyKeyPrefix = "tf-"
+
+// adoptIdempotent adopts the live row that already has the entry's
+// idempotency_key, if any. Returns whether a row was adopted. A row that
+// has the key derived from the entry's content is an error.
+func (r *DatabaseEntryResource) adoptIdempotent(ctx context.Context, plan *DatabaseEntryResourceModel, titlePropName string, resp *resource.CreateResponse) bool {
+	entryID, err := findEntryByKey(ctx, r.client, plan.Database.ValueNotionID(),
+		plan.IdempotencyProperty.ValueString(), entryIdempotencyKey(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error looking for database entry by idempotency key", err.Error())
+		return false
+	}
+	if entryID == "" {
+		return false
+	}
+	if plan.IdempotencyKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("idempotency_key"), "Database entry with the same content exists",
+			fmt.Sprintf("Row %s already has the idempotency key derived from this entry's content. It may be the row an "+
+				"interrupted apply created, or another entry with the same content. Import the row, or set idempotency_key "+
+				"to a value unique to this entry.", entryID))
+		return false
+	}
+	return r.adoptEntry(ctx, plan, titlePropName, entryID, resp)
+}
+
+// entryIdempotencyKey returns the idempotency_key of plan, or else a key
+// derived from its database, title and typed property values, so that
+// applying the same configuration again derives the same key.
+func entryIdempotencyKey(plan *DatabaseEntryResourceModel) string {
+	if !plan.IdempotencyKey.IsNull() && !plan.IdempotencyKey.IsUnknown() {
+		return plan.IdempotencyKey.ValueString()
+	}
+	parts := []string{plan.Database.ValueNotionID(), plan.Title.ValueString()}
+	for _, m := range []attr.Value{
+		plan.RichTextProperties, plan.NumberProperties, plan.CheckboxProperties,
+		plan.SelectProperties, plan.StatusProperties, plan.URLProperties,
+		plan.EmailProperties, plan.PhoneNumberProperties, plan.DateProperties,
+	} {
+		// Map values render with their keys sorted.
+		parts = append(parts, m.String())
+	}
+	// Added after the others, and only when set, so the keys derived for
+	// existing configurations don't change.
+	if !plan.MultiSelectProperties.IsNull() {
+		parts = append(parts, plan.MultiSelectProperties.String())
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return idempotencyKeyPrefix + hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestEntryIdempotencyKey(t *testing.T) {
+	plan := idempotentEntryPlan
+	key := entryIdempotencyKey(plan("Deploy", map[string]string{"Status": "Open", "Stage": "Prod"}))
+	if !strings.HasPrefix(key, idempotencyKeyPrefix) {
+		t.Errorf("got %q, want the %q prefix", key, idempotencyKeyPrefix)
+	}
+	if again := entryIdempotencyKey(plan("Deploy", map[string]string{"Stage": "Prod", "Status": "Open"})); again != key {
+		t.Errorf("the same content derived %q and %q", key, again)
+	}
+	if other := entryIdempotencyKey(plan("Deploy", map[string]string{"Status": "Done", "Stage": "Prod"})); other == key {
+		t.Error("different content derived the same key")
+	}
+
+	explicit := plan("Deploy", nil)
+	explicit.IdempotencyKey = types.StringValue("deploy-42")
+	if got := entryIdempotencyKey(explicit); got != "deploy-42" {
+		t.Errorf("got %q, want the configured key", got)
+	}
+}
+
+// idempotentEntryPlan returns an entry plan with idempotency_property set
+// and only a title and status values.
+func idempotentEntryPlan(title string, status map[string]string) *DatabaseEntryResourceModel {
+	nullMap := types.MapNull(types.StringType)
+	statusMap := nullMap
+	if status != nil {
+		vals := map[string]attr.Value{}
+		for k, v := range status {
+			vals[k] = types.StringValue(v)
+		}
+		statusMap = types.MapValueMust(types.StringType, vals)
+	}
+	return &DatabaseEntryResourceModel{
+		Database:              NewNotionIDValue("abcd1234abcd1234abcd1234abcd1234"),
+		Title:                 NewRichTextStringValue(title),
+		IdempotencyProperty:   types.StringValue("Terraform Key"),
+		IdempotencyKey:        types.StringNull(),
+		RichTextProperties:    types.MapNull(RichTextStringType{}),
+		NumberProperties:      types.MapNull(types.Float64Type),
+		CheckboxProperties:    types.MapNull(types.BoolType),
+		SelectProperties:      nullMap,
+		MultiSelectProperties: types.MapNull(types.SetType{ElemType: types.StringType}),
+		StatusProperties:      statusMap,
+		URLProperties:         nullMap,
+		EmailProperties:       nullMap,
+		PhoneNumberProperties: nullMap,
+		DateProperties:        types.MapNull(DateStringType{}),
+	}
+}
+
+func TestAdoptIdempotentDerivedKey(t *testing.T) {
+	plan := idempotentEntryPlan("Deploy", nil)
+	key := entryIdempotencyKey(plan)
+	var requests int
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			// Another entry with the same content already created its row.
+			body := fmt.Sprintf(`{"object":"list","has_more":false,"results":[{"object":"page","id":"11111111222233334444555555555555",`+
+				`"properties":{"Terraform Key":{"id":"k","type":"rich_text","rich_text":[{"type":"text","text":{"content":%q},"plain_text":%q}]}}}]}`,
+				key, key)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+	r := &DatabaseEntryResource{client: client}
+
+	var resp resource.CreateResponse
+	if r.adoptIdempotent(context.Background(), plan, "Name", &resp) {
+		t.Fatal("adopted a row by a key derived from the content")
+	}
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a row with the derived key")
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want only the lookup", requests)
+	}
+}
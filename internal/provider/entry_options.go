@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// Notion only rejects a status that isn't one of the property's options
+// when the entry is written, and quietly adds a select or multi-select
+// value that isn't one as a new option with a random color, so a typo
+// becomes a new option. notion_database_entry checks them against the
+// database schema at plan time instead. With create_missing_options, the
+// missing select and multi-select options are added to the schema, with
+// new_option_color, before the entry is written; the API can't change the
+// options of a status property, so unknown statuses always fail the plan.
+
+// missingOption is a value of a select, multi-select or status map of an
+// entry that isn't one of its property's options.
+type missingOption struct {
+	attr  string // select_properties, multi_select_properties or status_properties
+	key   string // key in the map, a name or, with properties_by_id, an ID
+	name  string // name of the property
+	value string
+}
+
+// entryOptionMaps returns the attribute names and values of the select,
+// multi-select and status maps of m, skipping unknown ones. Select and
+// status values are returned as one-element lists.
+func entryOptionMaps(ctx context.Context, m *DatabaseEntryResourceModel) (map[string]map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	maps := map[string]map[string][]string{}
+	for attr, v := range map[string]types.Map{
+		"select_properties": m.SelectProperties,
+		"status_properties": m.StatusProperties,
+	} {
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		values := map[string]types.String{}
+		diags.Append(v.ElementsAs(ctx, &values, false)...)
+		maps[attr] = map[string][]string{}
+		for key, value := range values {
+			if !value.IsUnknown() && !value.IsNull() {
+				maps[attr][key] = []string{value.ValueString()}
+			}
+		}
+	}
+	if v := m.MultiSelectProperties; !v.IsNull() && !v.IsUnknown() {
+		sets := map[string]types.Set{}
+		diags.Append(v.ElementsAs(ctx, &sets, false)...)
+		maps["multi_select_properties"] = map[string][]string{}
+		for key, set := range sets {
+			if set.IsUnknown() || set.IsNull() {
+				continue
+			}
+			for _, value := range set.Elements() {
+				if s, ok := value.(types.String); ok && !s.IsUnknown() && !s.IsNull() {
+					maps["multi_select_properties"][key] = append(maps["multi_select_properties"][key], s.ValueString())
+				}
+			}
+		}
+	}
+	return maps, diags
+}
+
+// entryOptionCount returns the number of values in maps.
+func entryOptionCount(maps map[string]map[string][]string) int {
+	n := 0
+	for _, values := range maps {
+		for _, v := range values {
+			n += len(v)
+		}
+	}
+	return n
+}
+
+// propertyOptions returns the options of a select, multi-select or status
+// property, and whether prop is one.
+func propertyOptions(prop notionapi.PropertyConfig) ([]notionapi.Option, bool) {
+	switch p := prop.(type) {
+	case *notionapi.SelectPropertyConfig:
+		return p.Select.Options, true
+	case *notionapi.MultiSelectPropertyConfig:
+		return p.MultiSelect.Options, true
+	case *notionapi.StatusPropertyConfig:
+		return p.Status.Options, true
+	default:
+		return nil, false
+	}
+}
+
+// missingEntryOptions returns the values in maps, as returned by
+// entryOptionMaps, that aren't options of their properties in db, sorted.
+// Keys are property IDs when byID is set. Empty values clear the property
+// and are never missing; properties that aren't in db, or aren't of the
+// map's type, are left for the API to reject.
+func missingEntryOptions(db *notionapi.Database, byID bool, maps map[string]map[string][]string) []missingOption {
+	wantType := map[string]notionapi.PropertyConfigType{
+		"select_properties":       notionapi.PropertyConfigTypeSelect,
+		"multi_select_properties": notionapi.PropertyConfigTypeMultiSelect,
+		"status_properties":       notionapi.PropertyConfigStatus,
+	}
+	var missing []missingOption
+	for name, prop := range db.Properties {
+		key := name
+		if byID {
+			key = string(prop.GetID())
+		}
+		for attr, values := range maps {
+			if prop.GetType() != wantType[attr] {
+				continue
+			}
+			options, _ := propertyOptions(prop)
+			for _, value := range values[key] {
+				if value != "" && !containsOption(options, value) {
+					missing = append(missing, missingOption{attr: attr, key: key, name: name, value: value})
+				}
+			}
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].attr != missing[j].attr {
+			return missing[i].attr < missing[j].attr
+		}
+		if missing[i].key != missing[j].key {
+			return missing[i].key < missing[j].key
+		}
+		return missing[i].value < missing[j].value
+	})
+	return missing
+}
+
+func containsOption(options []notionapi.Option, name string) bool {
+	for _, o := range options {
+		if o.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// optionNames returns the names of options, quoted, for messages.
+func optionNames(options []notionapi.Option) string {
+	names := make([]string, len(options))
+	for i, o := range options {
+		names[i] = fmt.Sprintf("%q", o.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// checkEntryOptions fails the plan for select, multi-select and status
+// values that aren't options of their properties, leaving select and
+// multi-select ones to create_missing_options when it is set. The schema is
+// only read when the entry is created or one of the maps changes.
+func checkEntryOptions(ctx context.Context, client *notionapi.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan *DatabaseEntryResourceModel) {
+	if client == nil || plan.Database.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state DatabaseEntryResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.SelectProperties.Equal(state.SelectProperties) && plan.StatusProperties.Equal(state.StatusProperties) &&
+			plan.MultiSelectProperties.Equal(state.MultiSelectProperties) {
+			return
+		}
+	}
+
+	maps, diags := entryOptionMaps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if plan.CreateMissingOptions.ValueBool() {
+		maps = map[string]map[string][]string{"status_properties": maps["status_properties"]}
+	}
+	if resp.Diagnostics.HasError() || entryOptionCount(maps) == 0 {
+		return
+	}
+	db, err := getDatabaseSchema(ctx, client, plan.Database.ValueNotionID())
+	if err != nil {
+		// The database may not exist yet; Create reports errors properly.
+		return
+	}
+
+	for _, m := range missingEntryOptions(db, plan.PropertiesByID.ValueBool(), maps) {
+		options, _ := propertyOptions(db.Properties[m.name])
+		kind, fix := "select", "add the option to the property, or set create_missing_options to add it when the entry is written"
+		switch m.attr {
+		case "multi_select_properties":
+			kind = "multi-select"
+		case "status_properties":
+			kind, fix = "status", "or add the option to the property in Notion, as the API can't change status options"
+		}
+		resp.Diagnostics.AddAttributeError(path.Root(m.attr).AtMapKey(m.key), "Unknown "+kind+" option",
+			fmt.Sprintf("%q is not an option of the %s property %q, whose options are %s. Fix the value, %s.",
+				m.value, kind, m.name, optionNames(options), fix))
+	}
+}
+
+// addMissingEntryOptions implements create_missing_options: it adds the
+// select and multi-select values of plan that aren't options of their
+// properties to the database schema, with new_option_color, so writing the
+// entry doesn't pick a random color.
+func addMissingEntryOptions(ctx context.Context, provider *providerData, plan *DatabaseEntryResourceModel) diag.Diagnostics {
+	maps, diags := entryOptionMaps(ctx, plan)
+	delete(maps, "status_properties")
+	if diags.HasError() || !plan.CreateMissingOptions.ValueBool() || entryOptionCount(maps) == 0 {
+		return diags
+	}
+	db, err := getDatabaseSchema(ctx, provider.client, plan.Database.ValueNotionID())
+	if err != nil {
+		diags.AddError("Error reading database", err.Error())
+		return diags
+	}
+	missing := missingEntryOptions(db, plan.PropertiesByID.ValueBool(), maps)
+	if len(missing) == 0 {
+		return diags
+	}
+
+	// Options are replaced as a whole, so each property is sent with its
+	// existing options, IDs included, followed by the new ones.
+	color := notionapi.ColorDefault
+	if c := plan.NewOptionColor; !c.IsNull() && !c.IsUnknown() {
+		color = notionapi.Color(c.ValueString())
+	}
+	added := map[string][]notionapi.Option{}
+	for _, m := range missing {
+		if !containsOption(added[m.name], m.value) {
+			added[m.name] = append(added[m.name], notionapi.Option{Name: m.value, Color: color})
+		}
+	}
+	configs := notionapi.PropertyConfigs{}
+	for name, options := range added {
+		prop := db.Properties[name]
+		existing, _ := propertyOptions(prop)
+		options = append(append([]notionapi.Option{}, existing...), options...)
+		key := propertyUpdateKey(types.StringValue(string(prop.GetID())), name)
+		if prop.GetType() == notionapi.PropertyConfigTypeMultiSelect {
+			configs[key] = notionapi.MultiSelectPropertyConfig{Type: notionapi.PropertyConfigTypeMultiSelect, MultiSelect: notionapi.Select{Options: options}}
+		} else {
+			configs[key] = notionapi.SelectPropertyConfig{Type: notionapi.PropertyConfigTypeSelect, Select: notionapi.Select{Options: options}}
+		}
+	}
+	if _, err := provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
+		Properties: configs,
+	}); err != nil {
+		diags.AddError("Error adding missing options", err.Error())
+	}
+	return diags
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestMissingEntryOptions(t *testing.T) {
+	db := &notionapi.Database{Properties: notionapi.PropertyConfigs{
+		"Priority": &notionapi.SelectPropertyConfig{ID: "pR1o", Type: notionapi.PropertyConfigTypeSelect,
+			Select: notionapi.Select{Options: []notionapi.Option{{Name: "High"}, {Name: "Low"}}}},
+		"Status": &notionapi.StatusPropertyConfig{ID: "sT4t", Type: notionapi.PropertyConfigStatus,
+			Status: notionapi.StatusConfig{Options: []notionapi.Option{{Name: "Not started"}, {Name: "Done"}}}},
+		"Tags": &notionapi.MultiSelectPropertyConfig{ID: "tA9s", Type: notionapi.PropertyConfigTypeMultiSelect,
+			MultiSelect: notionapi.Select{Options: []notionapi.Option{{Name: "go"}}}},
+		"Notes": &notionapi.RichTextPropertyConfig{ID: "nO7e", Type: notionapi.PropertyConfigTypeRichText},
+	}}
+
+	maps := map[string]map[string][]string{
+		"select_properties":       {"Priority": {"Hihg"}, "Notes": {"Anything"}, "Missing": {"Value"}},
+		"multi_select_properties": {"Tags": {"terraform", "go", "api"}},
+		"status_properties":       {"Status": {"Done"}},
+	}
+	got := missingEntryOptions(db, false, maps)
+	want := []missingOption{
+		{attr: "multi_select_properties", key: "Tags", name: "Tags", value: "api"},
+		{attr: "multi_select_properties", key: "Tags", name: "Tags", value: "terraform"},
+		{attr: "select_properties", key: "Priority", name: "Priority", value: "Hihg"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	maps = map[string]map[string][]string{
+		"select_properties": {"pR1o": {""}},
+		"status_properties": {"sT4t": {"Blocked"}, "Status": {"Blocked"}},
+	}
+	got = missingEntryOptions(db, true, maps)
+	want = []missingOption{{attr: "status_properties", key: "sT4t", name: "Status", value: "Blocked"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("by ID: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jomei/notionapi"
+)
+
+// Terraform can only hide whole attributes from plan output, not single
+// elements of a map, so properties whose values must not be shown, such as
+// salary bands or customer contacts, are set through sensitive_properties,
+// a map of their own marked sensitive. Its values are strings converted
+// according to the property's type in the database. They are left out of
+// all_properties and masked in the provider's logs and API errors.
+
+// sensitiveProperty converts value to a property of type typ. An empty
+// value clears the property.
+func sensitiveProperty(typ notionapi.PropertyConfigType, value string) (notionapi.Property, error) {
+	switch typ {
+	case notionapi.PropertyConfigTypeRichText:
+		rt := []notionapi.RichText{}
+		if value != "" {
+			rt = append(rt, notionapi.RichText{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: value}})
+		}
+		return notionapi.RichTextProperty{Type: notionapi.PropertyTypeRichText, RichText: rt}, nil
+	case notionapi.PropertyConfigTypeNumber:
+		var n float64
+		if value != "" {
+			var err error
+			if n, err = strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("not a number")
+			}
+		}
+		return notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: n}, nil
+	case notionapi.PropertyConfigTypeCheckbox:
+		var b bool
+		if value != "" {
+			var err error
+			if b, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("not true or false")
+			}
+		}
+		return notionapi.CheckboxProperty{Type: notionapi.PropertyTypeCheckbox, Checkbox: b}, nil
+	case notionapi.PropertyConfigTypeSelect:
+		return notionapi.SelectProperty{Type: notionapi.PropertyTypeSelect, Select: notionapi.Option{Name: value}}, nil
+	case notionapi.PropertyConfigStatus:
+		return notionapi.StatusProperty{Type: notionapi.PropertyTypeStatus, Status: notionapi.Option{Name: value}}, nil
+	case notionapi.PropertyConfigTypeURL:
+		return notionapi.URLProperty{Type: notionapi.PropertyTypeURL, URL: value}, nil
+	case notionapi.PropertyConfigTypeEmail:
+		return notionapi.EmailProperty{Type: notionapi.PropertyTypeEmail, Email: value}, nil
+	case notionapi.PropertyConfigTypePhoneNumber:
+		return notionapi.PhoneNumberProperty{Type: notionapi.PropertyTypePhoneNumber, PhoneNumber: value}, nil
+	case notionapi.PropertyConfigTypeDate:
+		if value == "" {
+			return notionapi.DateProperty{Type: notionapi.PropertyTypeDate}, nil
+		}
+		t, _, err := parseNotionDate(value)
+		if err != nil {
+			return nil, fmt.Errorf("not an ISO 8601 date")
+		}
+		d := notionapi.Date(t)
+		return notionapi.DateProperty{Type: notionapi.PropertyTypeDate, Date: &notionapi.DateObject{Start: &d}}, nil
+	default:
+		return nil, fmt.Errorf("a %s property can't be set from a string", typ)
+	}
+}
+
+// sensitivePropertyValue renders prop the way sensitive_properties sets it,
+// reporting false for a property of a type it can't set.
+func sensitivePropertyValue(prop notionapi.Property) (string, bool) {
+	switch p := prop.(type) {
+	case *notionapi.RichTextProperty:
+		return richTextPlain(p.RichText), true
+	case *notionapi.NumberProperty:
+		return strconv.FormatFloat(p.Number, 'f', -1, 64), true
+	case *notionapi.CheckboxProperty:
+		return strconv.FormatBool(p.Checkbox), true
+	case *notionapi.SelectProperty:
+		return p.Select.Name, true
+	case *notionapi.StatusProperty:
+		return p.Status.Name, true
+	case *notionapi.URLProperty:
+		return p.URL, true
+	case *notionapi.EmailProperty:
+		return p.Email, true
+	case *notionapi.PhoneNumberProperty:
+		return p.PhoneNumber, true
+	case *notionapi.DateProperty:
+		if p.Date == nil || p.Date.Start == nil {
+			return "", true
+		}
+		return formatNotionDate(p.Date.Start), true
+	default:
+		return "", false
+	}
+}
+
+// writeSensitiveProperties adds the planned sensitive properties to props,
+// looking up their types in the database. When prior is non-nil, sensitive
+// properties it managed that are absent from the plan are cleared, unless
+// on_remove is "ignore".
+func writeSensitiveProperties(ctx context.Context, client *notionapi.Client, plan, prior *DatabaseEntryResourceModel, props notionapi.Properties) diag.Diagnostics {
+	var diags diag.Diagnostics
+	values := map[string]string{}
+	if !plan.SensitiveProperties.IsNull() && !plan.SensitiveProperties.IsUnknown() {
+		diags.Append(plan.SensitiveProperties.ElementsAs(ctx, &values, false)...)
+	}
+	if prior != nil && plan.OnRemove.ValueString() != "ignore" &&
+		plan.PropertiesByID.ValueBool() == prior.PropertiesByID.ValueBool() {
+		for _, key := range removedKeys(prior.SensitiveProperties, plan.SensitiveProperties) {
+			values[key] = ""
+		}
+	}
+	if len(values) == 0 || diags.HasError() {
+		return diags
+	}
+
+	db, err := getDatabaseSchema(ctx, client, plan.Database.ValueNotionID())
+	if err != nil {
+		diags.AddError("Error reading database", err.Error())
+		return diags
+	}
+	names := map[string]string{}
+	typesByKey := map[string]notionapi.PropertyConfigType{}
+	for name, cfg := range db.Properties {
+		key := name
+		if plan.PropertiesByID.ValueBool() {
+			key = string(cfg.GetID())
+		}
+		names[key] = name
+		typesByKey[key] = cfg.GetType()
+	}
+
+	for key, value := range values {
+		typ, ok := typesByKey[key]
+		if !ok {
+			diags.AddError("Unknown sensitive property",
+				fmt.Sprintf("The database has no property %q, set in sensitive_properties.", key))
+			continue
+		}
+		prop, err := sensitiveProperty(typ, value)
+		if err != nil {
+			// The value itself is never part of the message.
+			diags.AddError("Invalid sensitive property value", fmt.Sprintf("Property %q: %s.", key, err))
+			continue
+		}
+		if plan.PropertiesByID.ValueBool() {
+			props[names[key]] = prop
+		} else {
+			props[key] = prop
+		}
+	}
+	return diags
+}
+
+// readSensitiveProperties reads the managed sensitive properties of page
+// back into state.
+func readSensitiveProperties(page *notionapi.Page, state *DatabaseEntryResourceModel, diags *diag.Diagnostics) {
+	if state.SensitiveProperties.IsNull() {
+		return
+	}
+	props := page.Properties
+	if state.PropertiesByID.ValueBool() {
+		props = propertiesByID(page.Properties)
+	}
+	vals := make(map[string]attr.Value)
+	for key := range state.SensitiveProperties.Elements() {
+		if prop, ok := props[key]; ok {
+			if value, ok := sensitivePropertyValue(prop); ok {
+				vals[key] = types.StringValue(value)
+			}
+		}
+	}
+	m, d := types.MapValue(types.StringType, vals)
+	diags.Append(d...)
+	state.SensitiveProperties = m
+}
+
+// nonSensitiveProperties returns the raw properties of page that aren't in
+// the sensitive_properties of m, for all_properties.
+func nonSensitiveProperties(page *notionapi.Page, raw map[string]rawProperty, m *DatabaseEntryResourceModel) map[string]rawProperty {
+	if m.SensitiveProperties.IsNull() || m.SensitiveProperties.IsUnknown() {
+		return raw
+	}
+	sensitive := m.SensitiveProperties.Elements()
+	out := make(map[string]rawProperty, len(raw))
+	for name, prop := range raw {
+		if _, ok := sensitive[name]; ok {
+			continue
+		}
+		if p, ok := page.Properties[name]; ok {
+			if _, ok := sensitive[string(p.GetID())]; ok {
+				continue
+			}
+		}
+		out[name] = prop
+	}
+	return out
+}
+
+// sensitiveValues returns the non-empty values of the sensitive_properties
+// of m, longest first, so that masking one doesn't leave part of another.
+func sensitiveValues(m *DatabaseEntryResourceModel) []string {
+	if m.SensitiveProperties.IsNull() || m.SensitiveProperties.IsUnknown() {
+		return nil
+	}
+	var values []string
+	for _, v := range m.SensitiveProperties.Elements() {
+		if s, ok := v.(types.String); ok && !s.IsUnknown() && s.ValueString() != "" {
+			values = append(values, s.ValueString())
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// maskSensitiveProperties returns ctx with the values of the
+// sensitive_properties of each of ms masked in the provider's logs.
+func maskSensitiveProperties(ctx context.Context, ms ...*DatabaseEntryResourceModel) context.Context {
+	for _, m := range ms {
+		if values := sensitiveValues(m); len(values) > 0 {
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, values...)
+			ctx = tflog.MaskMessageStrings(ctx, values...)
+		}
+	}
+	return ctx
+}
+
+// redactSensitiveProperties replaces the values of the sensitive_properties
+// of m in msg, such as an API error that echoes the request.
+func redactSensitiveProperties(msg string, m *DatabaseEntryResourceModel) string {
+	for _, value := range sensitiveValues(m) {
+		msg = strings.ReplaceAll(msg, value, "(sensitive value)")
+	}
+	return msg
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jomei/notionapi"
+)
+
+func TestSensitivePropertyRoundTrip(t *testing.T) {
+	cases := []struct {
+		typ   notionapi.PropertyConfigType
+		value string
+	}{
+		{notionapi.PropertyConfigTypeRichText, "Band **C**"},
+		{notionapi.PropertyConfigTypeNumber, "125000.5"},
+		{notionapi.PropertyConfigTypeCheckbox, "true"},
+		{notionapi.PropertyConfigTypeSelect, "Band C"},
+		{notionapi.PropertyConfigStatus, "Approved"},
+		{notionapi.PropertyConfigTypeURL, "https://example.com/contract"},
+		{notionapi.PropertyConfigTypeEmail, "ada@example.com"},
+		{notionapi.PropertyConfigTypePhoneNumber, "+44 20 7946 0000"},
+		{notionapi.PropertyConfigTypeDate, "2026-03-01"},
+		{notionapi.PropertyConfigTypeDate, "2026-03-01T09:30:00Z"},
+	}
+	for _, tc := range cases {
+		prop, err := sensitiveProperty(tc.typ, tc.value)
+		if err != nil {
+			t.Fatalf("%s %q: %v", tc.typ, tc.value, err)
+		}
+		// The API returns pointers to properties; rebuild one the way a
+		// response would be decoded.
+		var read notionapi.Property
+		switch p := prop.(type) {
+		case notionapi.RichTextProperty:
+			p.RichText[0].PlainText = p.RichText[0].Text.Content
+			read = &p
+		case notionapi.NumberProperty:
+			read = &p
+		case notionapi.CheckboxProperty:
+			read = &p
+		case notionapi.SelectProperty:
+			read = &p
+		case notionapi.StatusProperty:
+			read = &p
+		case notionapi.URLProperty:
+			read = &p
+		case notionapi.EmailProperty:
+			read = &p
+		case notionapi.PhoneNumberProperty:
+			read = &p
+		case notionapi.DateProperty:
+			read = &p
+		}
+		if got, ok := sensitivePropertyValue(read); !ok || got != tc.value {
+			t.Errorf("%s: wrote %q, read back %q (%v)", tc.typ, tc.value, got, ok)
+		}
+	}
+}
+
+func TestSensitivePropertyInvalid(t *testing.T) {
+	for typ, value := range map[notionapi.PropertyConfigType]string{
+		notionapi.PropertyConfigTypeNumber:   "lots",
+		notionapi.PropertyConfigTypeCheckbox: "maybe",
+		notionapi.PropertyConfigTypeDate:     "next week",
+		notionapi.PropertyConfigTypePeople:   "Ada",
+	} {
+		if _, err := sensitiveProperty(typ, value); err == nil {
+			t.Errorf("%s: expected %q to be rejected", typ, value)
+		}
+	}
+	if _, err := sensitiveProperty(notionapi.PropertyConfigTypeNumber, "lots"); err.Error() != "not a number" {
+		t.Errorf("expected the error not to include the value, got %q", err)
+	}
+}
+
+func TestNonSensitiveProperties(t *testing.T) {
+	page := &notionapi.Page{Properties: notionapi.Properties{
+		"Salary":  &notionapi.NumberProperty{ID: "sAl1"},
+		"Contact": &notionapi.EmailProperty{ID: "cOn1"},
+		"Team":    &notionapi.SelectProperty{ID: "tEa1"},
+	}}
+	raw := map[string]rawProperty{
+		"Salary":  {Type: "number"},
+		"Contact": {Type: "email"},
+		"Team":    {Type: "select"},
+	}
+	m := &DatabaseEntryResourceModel{
+		SensitiveProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Salary": types.StringValue("125000"),
+			"cOn1":   types.StringValue("ada@example.com"),
+		}),
+	}
+
+	got := nonSensitiveProperties(page, raw, m)
+	want := map[string]rawProperty{"Team": {Type: "select"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRedactSensitiveProperties(t *testing.T) {
+	m := &DatabaseEntryResourceModel{
+		SensitiveProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Contact": types.StringValue("ada@example.com"),
+			"Alias":   types.StringValue("ada"),
+			"Empty":   types.StringValue(""),
+		}),
+	}
+	got := redactSensitiveProperties(`body.properties.Contact.email: "ada@example.com" is invalid`, m)
+	want := `body.properties.Contact.email: "(sensitive value)" is invalid`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSensitivePropertiesCreateWithMarkdown(t *testing.T) {
+	prev := notionHTTPClient
+	t.Cleanup(func() { notionHTTPClient = prev })
+
+	var created map[string]json.RawMessage
+	notionHTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var body string
+			switch {
+			case strings.Contains(req.URL.Path, "/databases/"):
+				body = `{"object":"database","id":"db","properties":{` +
+					`"Name":{"id":"title","name":"Name","type":"title","title":{}},` +
+					`"Salary":{"id":"s%3Aa","name":"Salary","type":"number","number":{}}}}`
+			case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/pages"):
+				var reqBody struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+					t.Fatalf("decoding create request: %v", err)
+				}
+				created = reqBody.Properties
+				body = `{"object":"page","id":"p1","url":"https://www.notion.so/p1"}`
+			default:
+				body = `{"object":"page","id":"p1","parent":{"type":"database_id","database_id":"db"},"properties":{}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	ctx := context.Background()
+	client := notionapi.NewClient("test-token")
+	registerClientToken(client, "test-token")
+	r := &DatabaseEntryResource{client: client, mdClient: newMarkdownClient(client), provider: newProviderData(client)}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	// Start from a plan with every attribute null.
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attrs := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(typ, nil)
+	}
+	var plan DatabaseEntryResourceModel
+	if diags := (tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, attrs)}).Get(ctx, &plan); diags.HasError() {
+		t.Fatalf("reading plan: %v", diags)
+	}
+	plan.Database = NewNotionIDValue("db")
+	plan.Title = NewRichTextStringValue("Row")
+	plan.Markdown = types.StringValue("# Notes")
+	plan.SensitiveProperties = types.MapValueMust(types.StringType, map[string]attr.Value{"Salary": types.StringValue("120000")})
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.createWithMarkdown(ctx, &plan, "Name", &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if _, ok := created["Name"]; !ok {
+		t.Errorf("created properties %v have no title", created)
+	}
+	if got := string(created["Salary"]); !strings.Contains(got, `"number":120000`) {
+		t.Errorf("created Salary = %s, want the sensitive value 120000", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
 
@@ -14,76 +15,329 @@ func normalizeID(id string) string {
 	return strings.ReplaceAll(id, "-", "")
 }
 
-// mdLinkRe matches markdown links: [display text](url)
-var mdLinkRe = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+// Rich text attributes hold plain text with a small subset of inline
+// markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and
+// `code`. plainToRichText turns it into annotated rich text and
+// richTextToPlain renders rich text back into it. As in CommonMark, an
+// emphasis marker only opens before and closes after a non-space character,
+// so "2 * 3 * 4" stays plain text, and a backslash makes the character after
+// it literal, so "\*\*kwargs" is sent as "**kwargs". Code blocks take their
+// text literally, without any of this (see resolveRichText).
 
-// richTextToPlain extracts plain text from a slice of RichText objects,
-// reconstructing markdown link syntax for RichText elements that have a link.
+// mdLinkRe matches a markdown link at the start of a string: [display text](url)
+var mdLinkRe = regexp.MustCompile(`^\[([^\]]+)\]\(([^)]+)\)`)
+
+// mdEscapable lists the characters a backslash makes literal, and
+// mdSpecial those of them that can start markdown, which
+// escapeInlineMarkdown escapes.
+const (
+	mdEscapable = "\\`*~[]()"
+	mdSpecial   = "\\`*~["
+)
+
+// mdEmphasis lists the emphasis markers, longest first so "**" is not read
+// as two "*".
+var mdEmphasis = []struct {
+	marker string
+	set    func(*notionapi.Annotations)
+}{
+	{"**", func(a *notionapi.Annotations) { a.Bold = true }},
+	{"~~", func(a *notionapi.Annotations) { a.Strikethrough = true }},
+	{"*", func(a *notionapi.Annotations) { a.Italic = true }},
+}
+
+// richTextToPlain renders a slice of RichText objects as plain text,
+// reconstructing the inline markdown plainToRichText understands for links
+// and for bold, italic, strikethrough and code annotations. Adjacent
+// elements with the same link and formatting are rendered as one, so text
+// Notion split into several runs doesn't gain extra markers. Text that
+// would read back as markdown it isn't, such as a literal "**", is
+// rendered with its markdown characters escaped.
 func richTextToPlain(rt []notionapi.RichText) string {
+	s := renderRichText(rt, false)
+	if !sameMarkdownRuns(plainToRichText(s), rt) {
+		s = renderRichText(rt, true)
+	}
+	return s
+}
+
+// renderRichText renders rt as inline markdown, escaping every markdown
+// character in its text when escape is set.
+func renderRichText(rt []notionapi.RichText, escape bool) string {
+	var sb strings.Builder
+	var content strings.Builder
+	var style, prev markdownStyle
+	for i, r := range rt {
+		style = markdownStyleOf(r)
+		if i > 0 && style != prev {
+			sb.WriteString(renderInlineMarkdown(content.String(), &prev.ann, prev.url, escape))
+			content.Reset()
+		}
+		content.WriteString(r.PlainText)
+		prev = style
+	}
+	if len(rt) > 0 {
+		sb.WriteString(renderInlineMarkdown(content.String(), &prev.ann, prev.url, escape))
+	}
+	return sb.String()
+}
+
+// sameMarkdownRuns reports whether parsed, the result of plainToRichText,
+// has the same text, links and markdown formatting as rt, however the two
+// are split into runs.
+func sameMarkdownRuns(parsed, rt []notionapi.RichText) bool {
+	type run struct {
+		content string
+		style   markdownStyle
+	}
+	merge := func(rt []notionapi.RichText, content func(notionapi.RichText) string) []run {
+		var runs []run
+		for _, r := range rt {
+			c := content(r)
+			if c == "" {
+				continue
+			}
+			style := markdownStyleOf(r)
+			if n := len(runs); n > 0 && runs[n-1].style == style {
+				runs[n-1].content += c
+				continue
+			}
+			runs = append(runs, run{content: c, style: style})
+		}
+		return runs
+	}
+	a := merge(parsed, func(r notionapi.RichText) string { return r.Text.Content })
+	b := merge(rt, func(r notionapi.RichText) string { return r.PlainText })
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// markdownStyle is the part of a RichText element's formatting that inline
+// markdown can express.
+type markdownStyle struct {
+	ann notionapi.Annotations
+	url string
+}
+
+func markdownStyleOf(r notionapi.RichText) markdownStyle {
+	var style markdownStyle
+	if r.Text != nil && r.Text.Link != nil {
+		style.url = r.Text.Link.Url
+	}
+	if a := r.Annotations; a != nil {
+		style.ann = notionapi.Annotations{Bold: a.Bold, Italic: a.Italic, Strikethrough: a.Strikethrough, Code: a.Code}
+	}
+	return style
+}
+
+// renderInlineMarkdown wraps content in the markers for its annotations and
+// link, escaping content's markdown characters first when escape is set
+// (code spans are always literal). Whitespace at either end is kept outside
+// the emphasis markers, which would otherwise not parse back.
+func renderInlineMarkdown(content string, ann *notionapi.Annotations, url string, escape bool) string {
+	s := content
+	if escape && (ann == nil || !ann.Code) {
+		s = escapeInlineMarkdown(s)
+	}
+	if ann != nil {
+		core := strings.TrimSpace(s)
+		if core != "" {
+			lead := s[:strings.Index(s, core)]
+			trail := s[len(lead)+len(core):]
+			if ann.Code {
+				core = "`" + core + "`"
+			}
+			if ann.Strikethrough {
+				core = "~~" + core + "~~"
+			}
+			if ann.Italic {
+				core = "*" + core + "*"
+			}
+			if ann.Bold {
+				core = "**" + core + "**"
+			}
+			s = lead + core + trail
+		}
+	}
+	if url != "" {
+		s = "[" + s + "](" + url + ")"
+	}
+	return s
+}
+
+// escapeInlineMarkdown backslash-escapes every character of s that could
+// start markdown.
+func escapeInlineMarkdown(s string) string {
 	var sb strings.Builder
-	for _, r := range rt {
-		if r.Text != nil && r.Text.Link != nil && r.Text.Link.Url != "" {
-			sb.WriteString("[")
-			sb.WriteString(r.PlainText)
-			sb.WriteString("](")
-			sb.WriteString(r.Text.Link.Url)
-			sb.WriteString(")")
-		} else {
-			sb.WriteString(r.PlainText)
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(mdSpecial, s[i]) >= 0 {
+			sb.WriteByte('\\')
 		}
+		sb.WriteByte(s[i])
 	}
 	return sb.String()
 }
 
-// plainToRichText parses a string for markdown links [text](url) and creates
-// a RichText slice with appropriate link annotations. Plain text without links
+// plainToRichText parses a string for inline markdown and creates a RichText
+// slice with the matching links and annotations. Plain text without markdown
 // produces a single RichText element (backward compatible).
 func plainToRichText(text string) []notionapi.RichText {
-	matches := mdLinkRe.FindAllStringSubmatchIndex(text, -1)
-	if len(matches) == 0 {
-		return []notionapi.RichText{
-			{
-				Type: notionapi.ObjectTypeText,
-				Text: &notionapi.Text{Content: text},
-			},
-		}
+	result := parseInlineMarkdown(text, notionapi.Annotations{}, nil)
+	if len(result) == 0 {
+		return []notionapi.RichText{textRun(text, notionapi.Annotations{}, nil)}
 	}
+	return result
+}
+
+// literalRichText returns text as a single unformatted RichText element,
+// with no markdown parsing.
+func literalRichText(text string) []notionapi.RichText {
+	return []notionapi.RichText{textRun(text, notionapi.Annotations{}, nil)}
+}
 
+// markdownPlainText returns text with its inline markdown removed: the
+// characters a reader sees, which is what Notion's text filters match on.
+func markdownPlainText(text string) string {
+	var sb strings.Builder
+	for _, r := range plainToRichText(text) {
+		sb.WriteString(r.Text.Content)
+	}
+	return sb.String()
+}
+
+// parseInlineMarkdown converts text to rich text runs carrying ann and link
+// in addition to whatever markdown text itself contains.
+func parseInlineMarkdown(text string, ann notionapi.Annotations, link *notionapi.Link) []notionapi.RichText {
 	var result []notionapi.RichText
-	cursor := 0
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			result = append(result, textRun(plain.String(), ann, link))
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		if rest[0] == '\\' && len(rest) > 1 && strings.IndexByte(mdEscapable, rest[1]) >= 0 {
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+		}
 
-	for _, m := range matches {
-		// m[0]:m[1] = full match, m[2]:m[3] = display text, m[4]:m[5] = url
-		if m[0] > cursor {
-			plain := text[cursor:m[0]]
-			result = append(result, notionapi.RichText{
-				Type: notionapi.ObjectTypeText,
-				Text: &notionapi.Text{Content: plain},
-			})
+		if rest[0] == '`' {
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				flush()
+				code := ann
+				code.Code = true
+				result = append(result, textRun(rest[1:1+end], code, link))
+				i += end + 2
+				continue
+			}
 		}
 
-		display := text[m[2]:m[3]]
-		url := text[m[4]:m[5]]
-		result = append(result, notionapi.RichText{
-			Type: notionapi.ObjectTypeText,
-			Text: &notionapi.Text{
-				Content: display,
-				Link:    &notionapi.Link{Url: url},
-			},
-		})
+		if rest[0] == '[' && link == nil {
+			if m := mdLinkRe.FindStringSubmatchIndex(rest); m != nil && !escapedAt(rest, m[3]) {
+				// m[0]:m[1] = full match, m[2]:m[3] = display text, m[4]:m[5] = url
+				flush()
+				result = append(result, parseInlineMarkdown(rest[m[2]:m[3]], ann, &notionapi.Link{Url: rest[m[4]:m[5]]})...)
+				i += m[1]
+				continue
+			}
+		}
 
-		cursor = m[1]
+		matched := false
+		for _, e := range mdEmphasis {
+			if !strings.HasPrefix(rest, e.marker) {
+				continue
+			}
+			end := closingEmphasis(rest, e.marker)
+			if end < 0 {
+				continue
+			}
+			flush()
+			inner := ann
+			e.set(&inner)
+			result = append(result, parseInlineMarkdown(rest[len(e.marker):end], inner, link)...)
+			i += end + len(e.marker)
+			matched = true
+			break
+		}
+		if matched {
+			continue
+		}
+
+		plain.WriteByte(rest[0])
+		i++
 	}
+	flush()
+	return result
+}
 
-	if cursor < len(text) {
-		plain := text[cursor:]
-		result = append(result, notionapi.RichText{
-			Type: notionapi.ObjectTypeText,
-			Text: &notionapi.Text{Content: plain},
-		})
+// closingEmphasis returns the index in s of the marker closing the one s
+// starts with, or -1. The enclosed text must not start or end with a space,
+// and an escaped marker doesn't close. Of a run of asterisks the last ones
+// close, so "***x***" nests italic in bold.
+func closingEmphasis(s, marker string) int {
+	start := len(marker)
+	if start >= len(s) || isMarkdownSpace(s[start]) {
+		return -1
 	}
+	for from := start + 1; from < len(s); {
+		idx := strings.Index(s[from:], marker)
+		if idx < 0 {
+			return -1
+		}
+		end := from + idx
+		if escapedAt(s, end) {
+			from = end + 1
+			continue
+		}
+		for end+len(marker) < len(s) && s[end+len(marker)] == marker[0] {
+			end++
+		}
+		if !isMarkdownSpace(s[end-1]) {
+			return end
+		}
+		from = end + len(marker)
+	}
+	return -1
+}
 
-	return result
+// escapedAt reports whether the character at s[i] is escaped, that is
+// preceded by an odd number of backslashes.
+func escapedAt(s string, i int) bool {
+	n := 0
+	for i-n > 0 && s[i-n-1] == '\\' {
+		n++
+	}
+	return n%2 == 1
+}
+
+func isMarkdownSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// textRun returns a text RichText element. Annotations are only attached
+// when one is set, so unformatted text is sent exactly as before.
+func textRun(content string, ann notionapi.Annotations, link *notionapi.Link) notionapi.RichText {
+	rt := notionapi.RichText{
+		Type: notionapi.ObjectTypeText,
+		Text: &notionapi.Text{Content: content, Link: link},
+	}
+	if ann != (notionapi.Annotations{}) {
+		a := ann
+		rt.Annotations = &a
+	}
+	return rt
 }
 
 // jsonToRichText parses a JSON-encoded array of Notion RichText objects.
@@ -103,3 +357,42 @@ func richTextToJSON(rt []notionapi.RichText) (string, error) {
 	}
 	return string(b), nil
 }
+
+// iconFromConfig builds the API icon for an icon attribute value: an http(s)
+// URL becomes an external image icon, anything else an emoji. An empty value
+// yields nil (no icon change).
+func iconFromConfig(s string) *notionapi.Icon {
+	if s == "" {
+		return nil
+	}
+	if checkHTTPURL(s) == nil {
+		return &notionapi.Icon{
+			Type:     notionapi.FileTypeExternal,
+			External: &notionapi.FileObject{URL: s},
+		}
+	}
+	return &notionapi.Icon{
+		Type:  "emoji",
+		Emoji: emojiPtr(s),
+	}
+}
+
+// iconToState returns the icon attribute value for an API icon. Emoji and
+// external image icons round-trip. Notion-hosted file icons (whose signed
+// URLs expire) and workspace custom emoji can't be written back through the
+// attribute, so for those prior is kept rather than blanking the field, which
+// would plan the removal of an icon someone set in the Notion UI.
+func iconToState(icon *notionapi.Icon, prior types.String) types.String {
+	switch {
+	case icon == nil:
+		return types.StringValue("")
+	case icon.Emoji != nil:
+		return types.StringValue(string(*icon.Emoji))
+	case icon.External != nil && icon.External.URL != "":
+		return types.StringValue(icon.External.URL)
+	case prior.IsNull() || prior.IsUnknown():
+		return types.StringValue("")
+	default:
+		return prior
+	}
+}
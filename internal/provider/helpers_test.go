@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestIconFromConfig(t *testing.T) {
+	if icon := iconFromConfig(""); icon != nil {
+		t.Errorf("expected nil icon for empty value, got %#v", icon)
+	}
+
+	icon := iconFromConfig("🚀")
+	if icon == nil || icon.Emoji == nil || string(*icon.Emoji) != "🚀" {
+		t.Errorf("expected emoji icon, got %#v", icon)
+	}
+
+	icon = iconFromConfig("https://example.com/icon.png")
+	if icon == nil || icon.Type != notionapi.FileTypeExternal || icon.External == nil || icon.External.URL != "https://example.com/icon.png" {
+		t.Errorf("expected external icon, got %#v", icon)
+	}
+}
+
+func TestIconToState(t *testing.T) {
+	prior := types.StringValue("🚀")
+
+	tests := []struct {
+		name  string
+		icon  *notionapi.Icon
+		prior types.String
+		want  string
+	}{
+		{"no icon", nil, prior, ""},
+		{"emoji", &notionapi.Icon{Type: "emoji", Emoji: emojiPtr("📘")}, prior, "📘"},
+		{"external", &notionapi.Icon{Type: notionapi.FileTypeExternal, External: &notionapi.FileObject{URL: "https://example.com/i.png"}}, prior, "https://example.com/i.png"},
+		{"hosted file keeps prior", &notionapi.Icon{Type: notionapi.FileTypeFile, File: &notionapi.FileObject{URL: "https://s3.example.com/signed"}}, prior, "🚀"},
+		{"custom emoji keeps prior", &notionapi.Icon{Type: "custom_emoji"}, prior, "🚀"},
+		{"custom emoji without prior", &notionapi.Icon{Type: "custom_emoji"}, types.StringNull(), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := iconToState(tt.icon, tt.prior).ValueString(); got != tt.want {
+				t.Errorf("iconToState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainToRichText_Markdown(t *testing.T) {
+	type run struct {
+		content string
+		ann     notionapi.Annotations
+		url     string
+	}
+	bold := notionapi.Annotations{Bold: true}
+	cases := []struct {
+		in   string
+		want []run
+	}{
+		{"plain", []run{{content: "plain"}}},
+		{"a **b** c", []run{{content: "a "}, {content: "b", ann: bold}, {content: " c"}}},
+		{"*i* ~~s~~ `c`", []run{
+			{content: "i", ann: notionapi.Annotations{Italic: true}},
+			{content: " "},
+			{content: "s", ann: notionapi.Annotations{Strikethrough: true}},
+			{content: " "},
+			{content: "c", ann: notionapi.Annotations{Code: true}},
+		}},
+		{"***both***", []run{{content: "both", ann: notionapi.Annotations{Bold: true, Italic: true}}}},
+		{"**[docs](https://x.io)**", []run{{content: "docs", ann: bold, url: "https://x.io"}}},
+		{"[**docs**](https://x.io)", []run{{content: "docs", ann: bold, url: "https://x.io"}}},
+		{"`**not bold**`", []run{{content: "**not bold**", ann: notionapi.Annotations{Code: true}}}},
+		{"2 * 3 * 4", []run{{content: "2 * 3 * 4"}}},
+		{"snake_case and a ** b", []run{{content: "snake_case and a ** b"}}},
+		{"unclosed **bold", []run{{content: "unclosed **bold"}}},
+		{`def f(\*args, \*\*kwargs):`, []run{{content: "def f(*args, **kwargs):"}}},
+		{"echo \\`date\\`", []run{{content: "echo `date`"}}},
+		{`*a\*`, []run{{content: "*a*"}}},
+		{`\[not](a link)`, []run{{content: "[not](a link)"}}},
+		{`C:\path\to`, []run{{content: `C:\path\to`}}},
+		{`\\**bold**`, []run{{content: `\`}, {content: "bold", ann: bold}}},
+	}
+	for _, tc := range cases {
+		rt := plainToRichText(tc.in)
+		var got []run
+		for _, r := range rt {
+			g := run{content: r.Text.Content}
+			if r.Annotations != nil {
+				g.ann = *r.Annotations
+			}
+			if r.Text.Link != nil {
+				g.url = r.Text.Link.Url
+			}
+			got = append(got, g)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("plainToRichText(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestRichTextToPlain_Markdown(t *testing.T) {
+	bold := &notionapi.Annotations{Bold: true, Color: "default"}
+	cases := []struct {
+		name string
+		in   []notionapi.RichText
+		want string
+	}{
+		{"split runs merge", []notionapi.RichText{
+			{PlainText: "bo", Annotations: bold},
+			{PlainText: "ld", Annotations: bold},
+			{PlainText: " text", Annotations: &notionapi.Annotations{Color: "default"}},
+		}, "**bold** text"},
+		{"spaces outside markers", []notionapi.RichText{
+			{PlainText: "bold ", Annotations: bold},
+			{PlainText: "text"},
+		}, "**bold** text"},
+		{"link and formatting", []notionapi.RichText{
+			{PlainText: "docs", Text: &notionapi.Text{Content: "docs", Link: &notionapi.Link{Url: "https://x.io"}}, Annotations: &notionapi.Annotations{Italic: true, Code: true}},
+		}, "[*`docs`*](https://x.io)"},
+		{"underline and color are not markdown", []notionapi.RichText{
+			{PlainText: "red", Annotations: &notionapi.Annotations{Underline: true, Color: "red"}},
+		}, "red"},
+		{"literal markers are escaped", []notionapi.RichText{
+			{PlainText: "def f(*args, **kwargs):"},
+		}, `def f(\*args, \*\*kwargs):`},
+		{"literal backticks are escaped", []notionapi.RichText{
+			{PlainText: "echo `date` "},
+			{PlainText: "now", Annotations: bold},
+		}, "echo \\`date\\` **now**"},
+		{"text that can't be read as markdown is left alone", []notionapi.RichText{
+			{PlainText: "2 * 3 * 4 in C:\\tmp"},
+		}, "2 * 3 * 4 in C:\\tmp"},
+	}
+	for _, tc := range cases {
+		got := richTextToPlain(tc.in)
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+		// Whatever is rendered parses back to the same formatting.
+		if again := richTextToPlain(withPlainText(plainToRichText(got))); again != got {
+			t.Errorf("%s: %q does not round-trip, got %q", tc.name, got, again)
+		}
+	}
+}
+
+func withPlainText(rt []notionapi.RichText) []notionapi.RichText {
+	for i := range rt {
+		rt[i].PlainText = rt[i].Text.Content
+	}
+	return rt
+}
+
+func TestResolveRichText_CodeIsLiteral(t *testing.T) {
+	source := "def f(*args, **kwargs):\n    return `date` # [x](y)"
+	for _, blockType := range []string{"code", "paragraph"} {
+		rt, err := resolveRichText(BlockResourceModel{
+			Type:         types.StringValue(blockType),
+			RichText:     NewRichTextStringValue(source),
+			RichTextJSON: types.StringNull(),
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", blockType, err)
+		}
+		literal := len(rt) == 1 && rt[0].Text.Content == source && rt[0].Annotations == nil && rt[0].Text.Link == nil
+		if literal != (blockType == "code") {
+			t.Errorf("%s: got %+v, literal %v", blockType, rt, literal)
+		}
+	}
+
+	// Reading a code block back gives its text as is.
+	state := BlockResourceModel{RichTextJSON: types.StringNull()}
+	readBlockIntoState(&notionapi.CodeBlock{
+		BasicBlock: notionapi.BasicBlock{Type: notionapi.BlockTypeCode},
+		Code: notionapi.Code{
+			RichText: []notionapi.RichText{{PlainText: source, Text: &notionapi.Text{Content: source}}},
+			Language: "python",
+		},
+	}, &state)
+	if got := state.RichText.ValueString(); got != source {
+		t.Errorf("read back %q, want %q", got, source)
+	}
+}
@@ -1,17 +1,15 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/jomei/notionapi"
 )
 
-const markdownAPIVersion = "2026-03-11"
+const markdownAPIVersion = notionAPIVersion
 
 type PageMarkdownResponse struct {
 	Object          string   `json:"object"`
@@ -22,48 +20,31 @@ type PageMarkdownResponse struct {
 }
 
 type markdownClient struct {
-	token string
+	client *notionapi.Client
 }
 
 func newMarkdownClient(client *notionapi.Client) *markdownClient {
-	return &markdownClient{token: client.Token.String()}
+	return &markdownClient{client: client}
 }
 
 func (mc *markdownClient) doRequest(ctx context.Context, method, url string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+	var reqBody []byte
 	if body != nil {
 		b, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewReader(b)
+		reqBody = b
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	token, err := tokenForClient(mc.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", mc.token))
-	req.Header.Set("Notion-Version", markdownAPIVersion)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		return nil, err
 	}
-
-	resp, err := http.DefaultClient.Do(req)
+	respBody, err := notionAPICall(ctx, method, url, token, markdownAPIVersion, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("Notion API error (status %d): %s", resp.StatusCode, string(respBody))
-	}
 
 	return respBody, nil
 }
@@ -75,7 +56,7 @@ func (mc *markdownClient) CreatePageWithMarkdown(ctx context.Context, parentPage
 		"markdown": markdown,
 	}
 
-	respBody, err := mc.doRequest(ctx, http.MethodPost, "https://api.notion.com/v1/pages", body)
+	respBody, err := mc.doRequest(ctx, http.MethodPost, notionAPIBaseURL+"/pages", body)
 	if err != nil {
 		return nil, err
 	}
@@ -98,63 +79,55 @@ func (mc *markdownClient) CreatePageWithMarkdown(ctx context.Context, parentPage
 }
 
 // CreatePageWithMarkdownAndTitle creates a page with both a title property and markdown content.
-func (mc *markdownClient) CreatePageWithMarkdownAndTitle(ctx context.Context, parentPageID, title, markdown string) (string, string, error) {
+func (mc *markdownClient) CreatePageWithMarkdownAndTitle(ctx context.Context, parentPageID, title, markdown string) (createPageResp, error) {
 	body := map[string]interface{}{
 		"parent":   map[string]string{"page_id": parentPageID},
 		"markdown": markdown,
 		"properties": map[string]interface{}{
 			"title": map[string]interface{}{
-				"type": "title",
-				"title": []map[string]interface{}{
-					{"type": "text", "text": map[string]string{"content": title}},
-				},
+				"type":  "title",
+				"title": plainToRichText(title),
 			},
 		},
 	}
 
-	respBody, err := mc.doRequest(ctx, http.MethodPost, "https://api.notion.com/v1/pages", body)
+	var page createPageResp
+	respBody, err := mc.doRequest(ctx, http.MethodPost, notionAPIBaseURL+"/pages", body)
 	if err != nil {
-		return "", "", err
+		return page, err
 	}
 
-	var page struct {
-		ID  string `json:"id"`
-		URL string `json:"url"`
-	}
 	if err := json.Unmarshal(respBody, &page); err != nil {
-		return "", "", fmt.Errorf("failed to parse page response: %w", err)
+		return page, fmt.Errorf("failed to parse page response: %w", err)
 	}
 
-	return page.ID, page.URL, nil
+	return page, nil
 }
 
 // CreateDatabaseEntryWithMarkdown creates a database entry with markdown content and properties.
-func (mc *markdownClient) CreateDatabaseEntryWithMarkdown(ctx context.Context, databaseID, markdown string, properties map[string]interface{}) (string, string, error) {
+func (mc *markdownClient) CreateDatabaseEntryWithMarkdown(ctx context.Context, databaseID, markdown string, properties map[string]interface{}) (createPageResp, error) {
 	body := map[string]interface{}{
 		"parent":     map[string]string{"database_id": databaseID},
 		"markdown":   markdown,
 		"properties": properties,
 	}
 
-	respBody, err := mc.doRequest(ctx, http.MethodPost, "https://api.notion.com/v1/pages", body)
+	var page createPageResp
+	respBody, err := mc.doRequest(ctx, http.MethodPost, notionAPIBaseURL+"/pages", body)
 	if err != nil {
-		return "", "", err
+		return page, err
 	}
 
-	var page struct {
-		ID  string `json:"id"`
-		URL string `json:"url"`
-	}
 	if err := json.Unmarshal(respBody, &page); err != nil {
-		return "", "", fmt.Errorf("failed to parse page response: %w", err)
+		return page, fmt.Errorf("failed to parse page response: %w", err)
 	}
 
-	return page.ID, page.URL, nil
+	return page, nil
 }
 
 // GetPageMarkdown retrieves a page's content as markdown.
 func (mc *markdownClient) GetPageMarkdown(ctx context.Context, pageID string) (*PageMarkdownResponse, error) {
-	url := fmt.Sprintf("https://api.notion.com/v1/pages/%s/markdown", pageID)
+	url := fmt.Sprintf("%s/pages/%s/markdown", notionAPIBaseURL, pageID)
 
 	respBody, err := mc.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -175,7 +148,7 @@ func (mc *markdownClient) GetPageMarkdown(ctx context.Context, pageID string) (*
 
 // ReplacePageMarkdown replaces all content in a page with new markdown.
 func (mc *markdownClient) ReplacePageMarkdown(ctx context.Context, pageID, markdown string) (*PageMarkdownResponse, error) {
-	url := fmt.Sprintf("https://api.notion.com/v1/pages/%s/markdown", pageID)
+	url := fmt.Sprintf("%s/pages/%s/markdown", notionAPIBaseURL, pageID)
 
 	body := map[string]interface{}{
 		"type": "replace_content",
@@ -210,7 +183,7 @@ func (mc *markdownClient) InsertPageMarkdown(ctx context.Context, pageID, markdo
 		return nil, fmt.Errorf("InsertPageMarkdown: position must be \"start\" or \"end\", got %q", position)
 	}
 
-	url := fmt.Sprintf("https://api.notion.com/v1/pages/%s/markdown", pageID)
+	url := fmt.Sprintf("%s/pages/%s/markdown", notionAPIBaseURL, pageID)
 	body := map[string]interface{}{
 		"type": "insert_content",
 		"insert_content": map[string]interface{}{
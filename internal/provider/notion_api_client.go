@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Every request the provider sends to Notion, whether through the SDK or
+// as a raw call for endpoints and shapes the SDK doesn't cover, goes
+// through notionHTTPClient. One client means one connection pool, one
+// timeout policy and one retry policy: retryTransport absorbs 5xx and edge
+// HTML errors, and doNotionRequestWithVersion adds the 429 handling the SDK
+// does for its own calls.
+
+const (
+	notionAPIBaseURL = "https://api.notion.com/v1"
+	// notionAPIVersion is the Notion-Version sent by raw calls by default.
+	notionAPIVersion = "2026-03-11"
+	// notionSDKAPIVersion is the Notion-Version the SDK is pinned to. Raw
+	// calls whose responses are decoded into SDK types must send it so the
+	// response shape matches.
+	notionSDKAPIVersion = "2022-06-28"
+	// Mirrors the SDK's default (notionapi.Client.maxRetries = 3) so raw
+	// calls' rate-limit behavior matches the rest of the provider.
+	notionMaxRateLimitRetries = 3
+	// notionMaxIdleConnsPerHost keeps enough idle connections to
+	// api.notion.com for concurrent reads (see database_query_partition.go)
+	// to reuse them rather than each opening a new TLS connection.
+	// http.DefaultTransport keeps only two.
+	notionMaxIdleConnsPerHost = 16
+)
+
+// notionTransport is the pooled transport underneath notionHTTPClient.
+var notionTransport http.RoundTripper = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   notionMaxIdleConnsPerHost,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ResponseHeaderTimeout: 60 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// notionHTTPClient is the single http.Client shared by the SDK (wired in
+// the provider's Configure) and every raw call. Error bodies are sanitized
+// outside the retries, so retryTransport still sees the original ones.
+var notionHTTPClient = newSanitizingHTTPClient(newRetryHTTPClient())
+
+// doNotionRequest performs an HTTP request against the Notion API with
+// 429-retry semantics matching the upstream SDK: retry up to
+// notionMaxRateLimitRetries times, honoring the Retry-After header. The
+// caller owns closing the returned response body.
+//
+// reqBody is passed by value (not as a Reader) so each retry attempt can
+// construct a fresh body without having to rewind a stream.
+func doNotionRequest(ctx context.Context, method, url, token string, reqBody []byte) (*http.Response, error) {
+	return doNotionRequestWithVersion(ctx, method, url, token, notionAPIVersion, reqBody)
+}
+
+// doNotionRequestWithVersion is doNotionRequest with an explicit
+// Notion-Version header, for endpoints whose response shape depends on it.
+func doNotionRequestWithVersion(ctx context.Context, method, url, token, version string, reqBody []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if reqBody != nil {
+			body = bytes.NewReader(reqBody)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Notion-Version", version)
+		if reqBody != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := notionHTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
+		// Drain and close the 429 body before retrying.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if attempt+1 >= notionMaxRateLimitRetries {
+			return nil, fmt.Errorf("notion API rate-limited %s %s after %d attempts", method, url, notionMaxRateLimitRetries)
+		}
+
+		retryAfter := 1
+		if hdr := resp.Header.Get("Retry-After"); hdr != "" {
+			if n, parseErr := strconv.Atoi(hdr); parseErr == nil && n > 0 {
+				retryAfter = n
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(retryAfter) * time.Second):
+		}
+	}
+}
+
+// notionAPICall is doNotionRequestWithVersion for callers that only want
+// the body of a successful response. Non-2xx responses become errors
+// carrying the status and the API's error body.
+func notionAPICall(ctx context.Context, method, url, token, version string, reqBody []byte) ([]byte, error) {
+	resp, err := doNotionRequestWithVersion(ctx, method, url, token, version, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Notion API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Notion shows the same object ID in several shapes: the hyphenated UUID the
+// API returns, the bare 32-character hex string this provider stores, and the
+// share URL users copy from the browser ("https://www.notion.so/ws/Title-<id>").
+// Plain string attributes compared those byte-for-byte, so pasting a URL or a
+// hyphenated ID produced API errors or a perpetual diff. NotionIDType accepts
+// any of these shapes, compares them by the ID they contain, and
+// ValueNotionID hands the canonical form to API calls.
+
+var (
+	_ basetypes.StringTypable                    = NotionIDType{}
+	_ basetypes.StringValuableWithSemanticEquals = NotionIDValue{}
+)
+
+// notionIDSuffixRe matches a Notion ID, hyphenated or not, at the end of a
+// string (a bare ID or the last path segment of a share URL).
+var notionIDSuffixRe = regexp.MustCompile(`(?i)([0-9a-f]{32}|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// NotionIDType is a string type holding a Notion object ID, optionally
+// hyphenated or embedded in a notion.so URL.
+type NotionIDType struct {
+	basetypes.StringType
+}
+
+func (t NotionIDType) Equal(o attr.Type) bool {
+	other, ok := o.(NotionIDType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t NotionIDType) String() string {
+	return "NotionIDType"
+}
+
+func (t NotionIDType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return NotionIDValue{StringValue: in}, nil
+}
+
+func (t NotionIDType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return NotionIDValue{StringValue: stringValue}, nil
+}
+
+func (t NotionIDType) ValueType(_ context.Context) attr.Value {
+	return NotionIDValue{}
+}
+
+// NotionIDValue is the value counterpart of NotionIDType.
+type NotionIDValue struct {
+	basetypes.StringValue
+}
+
+// NewNotionIDValue returns a known NotionIDValue.
+func NewNotionIDValue(s string) NotionIDValue {
+	return NotionIDValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+// NewNotionIDNull returns a null NotionIDValue.
+func NewNotionIDNull() NotionIDValue {
+	return NotionIDValue{StringValue: basetypes.NewStringNull()}
+}
+
+func (v NotionIDValue) Equal(o attr.Value) bool {
+	other, ok := o.(NotionIDValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v NotionIDValue) Type(_ context.Context) attr.Type {
+	return NotionIDType{}
+}
+
+// ValueNotionID returns the canonical (unhyphenated, lowercase) ID contained
+// in the value, for use in API calls. Null and unknown values yield "".
+func (v NotionIDValue) ValueNotionID() string {
+	return canonicalNotionID(v.ValueString())
+}
+
+// StringSemanticEquals reports whether both values refer to the same ID.
+func (v NotionIDValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(NotionIDValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return v.ValueNotionID() == newValue.ValueNotionID(), diags
+}
+
+// canonicalNotionID extracts the ID from a bare ID, hyphenated UUID or
+// notion.so URL and returns it unhyphenated and lowercase. Query strings and
+// fragments are ignored, so a database URL's "?v=<view id>" doesn't win over
+// the database ID in the path. Strings with no recognisable ID are returned
+// unchanged so the API can report what's wrong with them.
+func canonicalNotionID(s string) string {
+	candidate := strings.TrimSpace(s)
+	if strings.Contains(candidate, "://") || strings.HasPrefix(candidate, "notion.so/") || strings.HasPrefix(candidate, "www.notion.so/") {
+		if !strings.Contains(candidate, "://") {
+			candidate = "https://" + candidate
+		}
+		if u, err := url.Parse(candidate); err == nil {
+			candidate = strings.TrimRight(u.Path, "/")
+		}
+	}
+	m := notionIDSuffixRe.FindString(candidate)
+	if m == "" {
+		return s
+	}
+	return strings.ToLower(normalizeID(m))
+}
+
+// RequiresReplaceIfNotionIDChanged is stringplanmodifier.RequiresReplace for
+// NotionIDType attributes: the resource is only replaced when the configured
+// value refers to a different object, not when the same ID is merely written
+// in another shape (e.g. a state recorded before NotionIDType existed holding
+// the bare ID while the configuration uses the hyphenated form or a URL).
+func RequiresReplaceIfNotionIDChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = canonicalNotionID(req.PlanValue.ValueString()) != canonicalNotionID(req.StateValue.ValueString())
+		},
+		"Changing this to a different Notion object forces a new resource.",
+		"Changing this to a different Notion object forces a new resource.",
+	)
+}
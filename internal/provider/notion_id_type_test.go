@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCanonicalNotionID(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef"
+	cases := []struct {
+		in, want string
+	}{
+		{id, id},
+		{"01234567-89ab-cdef-0123-456789abcdef", id},
+		{"0123456789ABCDEF0123456789ABCDEF", id},
+		{"  " + id + " ", id},
+		{"https://www.notion.so/acme/Roadmap-" + id, id},
+		{"https://www.notion.so/acme/Roadmap-" + id + "/", id},
+		{"https://www.notion.so/" + id + "?v=fedcba9876543210fedcba9876543210", id},
+		{"https://www.notion.so/acme/Deadbeef-" + id + "#heading", id},
+		{"notion.so/acme/" + id, id},
+		{"title", "title"},
+		{"not-an-id", "not-an-id"},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		if got := canonicalNotionID(tc.in); got != tc.want {
+			t.Errorf("canonicalNotionID(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestNotionIDValue_SemanticEquals(t *testing.T) {
+	prior := NewNotionIDValue("01234567-89ab-cdef-0123-456789abcdef")
+
+	equal, diags := prior.StringSemanticEquals(context.Background(), NewNotionIDValue("https://www.notion.so/acme/Page-0123456789abcdef0123456789abcdef"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !equal {
+		t.Error("expected hyphenated ID and page URL to be semantically equal")
+	}
+
+	equal, _ = prior.StringSemanticEquals(context.Background(), NewNotionIDValue("fedcba9876543210fedcba9876543210"))
+	if equal {
+		t.Error("expected different IDs to differ")
+	}
+}
@@ -9,23 +9,25 @@ import (
 )
 
 // The jomei/notionapi SDK doesn't know about the 2026-01-15 template parameter
-// on Create page or the move page endpoint. This file shims both via direct
-// HTTP using the shared doNotionRequest helper (notion_trash.go), keeping the
-// rest of resource_page.go on the SDK path for the common case.
+// on Create page, the move page endpoint, or moving databases. This file shims
+// them via direct HTTP using the shared doNotionRequest helper
+// (notion_api_client.go), keeping the rest of resource_page.go and
+// resource_database.go on the SDK path for the common case.
 
 // createPageResp is the slim subset of the create-page response we need.
 type createPageResp struct {
-	ID  string `json:"id"`
-	URL string `json:"url"`
+	ID        string `json:"id"`
+	URL       string `json:"url"`
+	PublicURL string `json:"public_url"`
 }
 
 // createPageWithTemplate POSTs /v1/pages with a `template` field, returning
-// (id, url). templateID may be empty to mean "default template" (the API
+// the new page's id and URLs. templateID may be empty to mean "default template" (the API
 // distinguishes that from "no template" via the `type` field). The `children`
 // param is intentionally not supported here — per Notion docs, children are
 // disallowed when applying a template, and the page is returned blank initially
 // with the template applied asynchronously.
-func createPageWithTemplate(ctx context.Context, token, parentPageID, title, templateID, timezone string) (string, string, error) {
+func createPageWithTemplate(ctx context.Context, token, parentPageID, title, templateID, timezone string) (createPageResp, error) {
 	tpl := map[string]interface{}{}
 	if templateID == "" {
 		tpl["type"] = "default"
@@ -42,37 +44,35 @@ func createPageWithTemplate(ctx context.Context, token, parentPageID, title, tem
 		"template": tpl,
 		"properties": map[string]interface{}{
 			"title": map[string]interface{}{
-				"type": "title",
-				"title": []map[string]interface{}{
-					{"type": "text", "text": map[string]string{"content": title}},
-				},
+				"type":  "title",
+				"title": plainToRichText(title),
 			},
 		},
 	}
+	var page createPageResp
 	bodyJSON, err := json.Marshal(body)
 	if err != nil {
-		return "", "", err
+		return page, err
 	}
 
 	resp, err := doNotionRequest(ctx, http.MethodPost, notionAPIBaseURL+"/pages", token, bodyJSON)
 	if err != nil {
-		return "", "", err
+		return page, err
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", "", err
+		return page, err
 	}
 	if resp.StatusCode >= 400 {
-		return "", "", fmt.Errorf("notion API %d creating page with template: %s", resp.StatusCode, string(respBody))
+		return page, fmt.Errorf("notion API %d creating page with template: %s", resp.StatusCode, string(respBody))
 	}
 
-	var page createPageResp
 	if err := json.Unmarshal(respBody, &page); err != nil {
-		return "", "", fmt.Errorf("failed to parse create-page response: %w", err)
+		return page, fmt.Errorf("failed to parse create-page response: %w", err)
 	}
-	return page.ID, page.URL, nil
+	return page, nil
 }
 
 // movePage POSTs /v1/pages/{id}/move with the new page_id parent. Backs the
@@ -103,3 +103,32 @@ func movePage(ctx context.Context, token, pageID, newParentPageID string) error
 	}
 	return nil
 }
+
+// moveDatabase PATCHes /v1/databases/{id} with a new page_id parent. The
+// database keeps its ID and rows; used by resource_database Update when
+// parent changes.
+func moveDatabase(ctx context.Context, token, databaseID, newParentPageID string) error {
+	body := map[string]interface{}{
+		"parent": map[string]string{
+			"type":    "page_id",
+			"page_id": newParentPageID,
+		},
+	}
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/databases/%s", notionAPIBaseURL, databaseID)
+	resp, err := doNotionRequest(ctx, http.MethodPatch, url, token, bodyJSON)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("notion API %d moving database %s: %s", resp.StatusCode, databaseID, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMoveDatabase(t *testing.T) {
+	prev := notionHTTPClient
+	t.Cleanup(func() { notionHTTPClient = prev })
+
+	var request, body string
+	status, respBody := http.StatusOK, `{"object":"database","id":"db1"}`
+	notionHTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			request = req.Method + " " + req.URL.Path
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(respBody)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	ctx := context.Background()
+	if err := moveDatabase(ctx, "token", "db1", "page2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if request != "PATCH /v1/databases/db1" {
+		t.Errorf("got request %s", request)
+	}
+	if want := `{"parent":{"page_id":"page2","type":"page_id"}}`; body != want {
+		t.Errorf("got body %s, want %s", body, want)
+	}
+
+	status, respBody = http.StatusBadRequest, `{"object":"error","status":400,"code":"validation_error","message":"Can't move database"}`
+	err := moveDatabase(ctx, "token", "db1", "page2")
+	if err == nil || !strings.Contains(err.Error(), "Can't move database") {
+		t.Errorf("got error %v, want the API's message", err)
+	}
+}
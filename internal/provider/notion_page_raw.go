@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jomei/notionapi"
+)
+
+// The SDK decodes every property of a page and fails the whole request when
+// one has a type it doesn't model (e.g. "place"), so a single such column in
+// a database broke refreshes of every entry in it, including entries that
+// don't manage that column. getPageWithRaw makes a raw request
+// that decodes properties one at a time and skips the ones the SDK can't
+// represent, the same way datasource_database_entries.go sidesteps the SDK
+// for queries.
+
+// rawPageEnvelope is a page response with its properties left undecoded. The
+// outer Properties field shadows the embedded notionapi.Page one.
+type rawPageEnvelope struct {
+	notionapi.Page
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// getPageWithRaw retrieves a page directly, keeping only the properties the
+// SDK can decode, and also returns the page as a rawPage: every property
+// undecoded, including the ones the SDK can't represent, for rendering with
+// rawPropertyToString, and the in_trash flag notionapi.Page lacks. It uses the SDK's Notion-Version so the response has
+// the shape notionapi.Page expects.
+func getPageWithRaw(ctx context.Context, token, pageID string) (*notionapi.Page, *rawPage, error) {
+	body, err := fetchPageBody(ctx, token, pageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := decodePageLenient(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var raw rawPage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, nil, fmt.Errorf("decoding page properties: %w", err)
+	}
+	return page, &raw, nil
+}
+
+// fetchPageBody returns the body of a page retrieval.
+func fetchPageBody(ctx context.Context, token, pageID string) ([]byte, error) {
+	reqURL := fmt.Sprintf("%s/pages/%s", notionAPIBaseURL, pageID)
+	resp, err := doNotionRequestWithVersion(ctx, http.MethodGet, reqURL, token, notionSDKAPIVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("notion API %d fetching pages/%s: %s", resp.StatusCode, pageID, string(body))
+	}
+	return body, nil
+}
+
+// decodePageLenient decodes a page response, dropping properties whose type
+// the SDK doesn't support instead of failing.
+func decodePageLenient(body []byte) (*notionapi.Page, error) {
+	var envelope rawPageEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("decoding page: %w", err)
+	}
+
+	page := envelope.Page
+	page.Properties = notionapi.Properties{}
+	for name, raw := range envelope.Properties {
+		single, err := json.Marshal(map[string]json.RawMessage{name: raw})
+		if err != nil {
+			return nil, err
+		}
+		var decoded notionapi.Properties
+		if err := json.Unmarshal(single, &decoded); err != nil {
+			continue
+		}
+		for k, v := range decoded {
+			page.Properties[k] = v
+		}
+	}
+	return &page, nil
+}
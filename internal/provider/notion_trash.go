@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
+	"strings"
 	"sync"
-	"time"
 
 	"github.com/jomei/notionapi"
 )
@@ -22,92 +21,34 @@ import (
 // calls that use `in_trash` and a recent Notion-Version header, so deletes
 // behave correctly until the upstream SDK catches up.
 
-const (
-	notionAPIBaseURL      = "https://api.notion.com/v1"
-	notionTrashAPIVersion = "2026-03-11"
-	// Mirrors the SDK's default (notionapi.Client.maxRetries = 3) so the
-	// shim's rate-limit behavior matches the rest of the provider.
-	notionTrashMaxRetries = 3
-)
-
-// trashHTTPClient is a package-level http.Client whose transport retries
-// on 5xx and edge HTML errors (see retry_transport.go). doNotionRequest
-// uses this so the trash shim gets the same treatment as the rest of the
-// provider, on top of its own existing 429 retry loop below.
-var trashHTTPClient = newRetryHTTPClient()
+// notionTrashAPIVersion is the Notion-Version that understands in_trash.
+const notionTrashAPIVersion = notionAPIVersion
 
-// clientTokens maps API client pointers to their bearer tokens. The
-// provider's Configure stores the token here; the trash shim looks it up.
-// This avoids changing every resource's Configure signature to plumb the
-// token alongside the existing *notionapi.Client.
+// clientTokens maps API client pointers to the source of their bearer
+// tokens. The provider's Configure stores the source here; the trash shim
+// looks it up. This avoids changing every resource's Configure signature to
+// plumb the token alongside the existing *notionapi.Client.
 var clientTokens sync.Map
 
 // registerClientToken records the token used to construct a client.
 func registerClientToken(client *notionapi.Client, token string) {
-	clientTokens.Store(client, token)
+	registerClientTokenSource(client, staticTokenSource(token))
 }
 
-// tokenForClient returns the token for a given client, if registered.
+// registerClientTokenSource records where a client's token comes from; see
+// token_source.go.
+func registerClientTokenSource(client *notionapi.Client, source *tokenSource) {
+	clientTokens.Store(client, source)
+}
+
+// tokenForClient returns the current token for a given client, if
+// registered.
 func tokenForClient(client *notionapi.Client) (string, error) {
 	v, ok := clientTokens.Load(client)
 	if !ok {
 		return "", fmt.Errorf("no Notion API token registered for client (provider Configure may not have run)")
 	}
-	return v.(string), nil
-}
-
-// doNotionRequest performs an HTTP request against the Notion API with
-// 429-retry semantics matching the upstream SDK: retry up to
-// notionTrashMaxRetries times, honoring the Retry-After header. The caller
-// owns closing the returned response body.
-//
-// reqBody is passed by value (not as a Reader) so each retry attempt can
-// construct a fresh body without having to rewind a stream.
-func doNotionRequest(ctx context.Context, method, url, token string, reqBody []byte) (*http.Response, error) {
-	for attempt := 0; ; attempt++ {
-		var body io.Reader
-		if reqBody != nil {
-			body = bytes.NewReader(reqBody)
-		}
-		req, err := http.NewRequestWithContext(ctx, method, url, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Notion-Version", notionTrashAPIVersion)
-		if reqBody != nil {
-			req.Header.Set("Content-Type", "application/json")
-		}
-
-		resp, err := trashHTTPClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode != http.StatusTooManyRequests {
-			return resp, nil
-		}
-
-		// Drain and close the 429 body before retrying.
-		_, _ = io.Copy(io.Discard, resp.Body)
-		_ = resp.Body.Close()
-
-		if attempt+1 >= notionTrashMaxRetries {
-			return nil, fmt.Errorf("notion API rate-limited %s %s after %d attempts", method, url, notionTrashMaxRetries)
-		}
-
-		retryAfter := 1
-		if hdr := resp.Header.Get("Retry-After"); hdr != "" {
-			if n, parseErr := strconv.Atoi(hdr); parseErr == nil && n > 0 {
-				retryAfter = n
-			}
-		}
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(time.Duration(retryAfter) * time.Second):
-		}
-	}
+	return v.(*tokenSource).Token()
 }
 
 // trashObject moves a Notion page or database to trash via the modern
@@ -127,11 +68,46 @@ func trashObject(ctx context.Context, token, objectKind, id string) error {
 
 	if resp.StatusCode >= 400 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("notion API %d trashing %s/%s: %s", resp.StatusCode, objectKind, id, string(respBody))
+		return &trashError{status: resp.StatusCode, object: objectKind + "/" + id, body: respBody}
 	}
 	return nil
 }
 
+// trashError is a failed trashObject request. It unwraps to the
+// notionapi.Error in the response body, so callers can check it the same
+// way as SDK errors.
+type trashError struct {
+	status int
+	object string
+	body   []byte
+}
+
+func (e *trashError) Error() string {
+	return fmt.Sprintf("notion API %d trashing %s: %s", e.status, e.object, string(e.body))
+}
+
+func (e *trashError) Unwrap() error {
+	apiErr := &notionapi.Error{Status: e.status}
+	_ = json.Unmarshal(e.body, apiErr)
+	return apiErr
+}
+
+// isNotFoundError reports whether err is Notion's object_not_found.
+func isNotFoundError(err error) bool {
+	var apiErr *notionapi.Error
+	return errors.As(err, &apiErr) && (apiErr.Status == http.StatusNotFound || apiErr.Code == "object_not_found")
+}
+
+// isGoneError reports whether err says the object was already deleted or
+// archived, which a delete can treat as done.
+func isGoneError(err error) bool {
+	if isNotFoundError(err) {
+		return true
+	}
+	var apiErr *notionapi.Error
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusBadRequest && strings.Contains(apiErr.Message, "archived")
+}
+
 // isObjectTrashed returns whether the given page or database has been moved
 // to trash. Used by acceptance tests' CheckDestroy to verify the delete
 // actually took effect (not just that the API returned success).
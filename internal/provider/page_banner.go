@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/jomei/notionapi"
+)
+
+// A managed banner is a callout notion_page keeps as the first block of the
+// page, telling editors that the page is managed by Terraform. Its block ID
+// is tracked in private state so Read can tell whether it is still the
+// first block, and Update can remove it before pinning a new one.
+
+const (
+	managedBannerPrivateKey = "managed_banner_block_id"
+	managedBannerIcon       = "🔒"
+)
+
+// bannerTextEscaper escapes the characters enhanced markdown would read as
+// tags or entities, and joins lines, since the callout is one paragraph.
+var bannerTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\n", " ")
+
+// managedBannerMarkdown is the enhanced-markdown callout for text.
+func managedBannerMarkdown(text string) string {
+	return fmt.Sprintf(`<callout icon="%s">%s</callout>`, html.EscapeString(managedBannerIcon), bannerTextEscaper.Replace(text))
+}
+
+// trackedManagedBanner returns the banner block ID recorded in private
+// state, or "" when none is.
+func trackedManagedBanner(ctx context.Context, private privateStateGetter) (string, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, managedBannerPrivateKey)
+	if diags.HasError() || len(raw) == 0 {
+		return "", diags
+	}
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		diags.AddError("Error reading private state", fmt.Sprintf("Decoding %s: %s", managedBannerPrivateKey, err))
+	}
+	return id, diags
+}
+
+// trackManagedBanner records the banner block ID in private state; "" clears
+// it.
+func trackManagedBanner(ctx context.Context, private privateStateSetter, id string) diag.Diagnostics {
+	if id == "" {
+		return private.SetKey(ctx, managedBannerPrivateKey, nil)
+	}
+	raw, err := json.Marshal(id)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error writing private state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, managedBannerPrivateKey, raw)
+}
+
+// pinnedManagedBanner returns the text of the banner if the block with
+// bannerID is still the first block of the page, and false otherwise.
+func pinnedManagedBanner(ctx context.Context, client *notionapi.Client, pageID, bannerID string) (string, bool, error) {
+	first, err := firstChildBlock(ctx, client, pageID)
+	if err != nil || first == nil {
+		return "", false, err
+	}
+	callout, ok := first.(*notionapi.CalloutBlock)
+	if !ok || bannerID == "" || normalizeID(string(callout.ID)) != bannerID {
+		return "", false, nil
+	}
+	return richTextToPlain(callout.Callout.RichText), true, nil
+}
+
+// firstChildBlock returns the first block of a page, or nil if it is empty.
+func firstChildBlock(ctx context.Context, client *notionapi.Client, pageID string) (notionapi.Block, error) {
+	children, err := client.Block.GetChildren(ctx, notionapi.BlockID(pageID), &notionapi.Pagination{PageSize: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(children.Results) == 0 {
+		return nil, nil
+	}
+	return children.Results[0], nil
+}
+
+// pinManagedBanner removes the banner block priorID, if it still exists,
+// and puts a banner with text at the top of the page, recording its ID in
+// private state. A callout with the same text already at the top, such as
+// one copied from a template, is adopted rather than duplicated. An empty
+// text only removes the old banner.
+func (r *PageResource) pinManagedBanner(ctx context.Context, pageID, text, priorID string, private privateStateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if priorID != "" {
+		prior, err := r.client.Block.Get(ctx, notionapi.BlockID(priorID))
+		if err == nil && !prior.GetArchived() {
+			if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(priorID)); err != nil {
+				diags.AddError("Error removing managed banner", err.Error())
+				return diags
+			}
+		}
+	}
+
+	var bannerID string
+	if text != "" {
+		first, err := firstChildBlock(ctx, r.client, pageID)
+		if err != nil {
+			diags.AddError("Error reading page content", err.Error())
+			return diags
+		}
+		if callout, ok := first.(*notionapi.CalloutBlock); ok &&
+			normalizeRichTextPlain(richTextToPlain(callout.Callout.RichText)) == normalizeRichTextPlain(text) {
+			bannerID = normalizeID(string(callout.ID))
+		} else {
+			if _, err := r.mdClient.InsertPageMarkdown(ctx, pageID, managedBannerMarkdown(text), "start"); err != nil {
+				diags.AddError("Error adding managed banner", err.Error())
+				return diags
+			}
+			first, err := firstChildBlock(ctx, r.client, pageID)
+			if err != nil || first == nil {
+				diags.AddError("Error adding managed banner", fmt.Sprintf("Reading the inserted banner back: %v", err))
+				return diags
+			}
+			bannerID = normalizeID(string(first.GetID()))
+		}
+	}
+
+	diags.Append(trackManagedBanner(ctx, private, bannerID)...)
+	return diags
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestPinnedManagedBanner(t *testing.T) {
+	const first = `{"object":"list","has_more":false,"results":[{"object":"block","id":"0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0","type":"callout",
+		"callout":{"rich_text":[{"type":"text","text":{"content":"Managed by Terraform"},"plain_text":"Managed by Terraform"}],"icon":{"type":"emoji","emoji":"🔒"}}}]}`
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(first)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+
+	text, pinned, err := pinnedManagedBanner(context.Background(), client, "page", "0f1e2d3c4b5a69788796a5b4c3d2e1f0")
+	if err != nil {
+		t.Fatalf("pinnedManagedBanner() error = %v", err)
+	}
+	if !pinned || text != "Managed by Terraform" {
+		t.Errorf("pinnedManagedBanner() = %q, %t; want the banner text, pinned", text, pinned)
+	}
+
+	// A callout at the top that isn't the tracked banner doesn't count.
+	if _, pinned, _ := pinnedManagedBanner(context.Background(), client, "page", "ffffffffffffffffffffffffffffffff"); pinned {
+		t.Error("pinnedManagedBanner() reported another callout as the banner")
+	}
+}
+
+func TestManagedBannerMarkdown(t *testing.T) {
+	got := managedBannerMarkdown("This page is managed by Terraform — edit <repo>\nQ&A: see ops")
+	want := `<callout icon="🔒">This page is managed by Terraform — edit &lt;repo&gt; Q&amp;A: see ops</callout>`
+	if got != want {
+		t.Errorf("managedBannerMarkdown() = %s, want %s", got, want)
+	}
+}
@@ -2,7 +2,13 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -19,7 +25,13 @@ type NotionProvider struct {
 }
 
 type NotionProviderModel struct {
-	Token types.String `tfsdk:"token"`
+	Token                 types.String  `tfsdk:"token"`
+	TokenFile             types.String  `tfsdk:"token_file"`
+	EstimateAPICalls      types.Bool    `tfsdk:"estimate_api_calls"`
+	ExpectedWorkspaceName types.String  `tfsdk:"expected_workspace_name"`
+	ExpectedBotID         NotionIDValue `tfsdk:"expected_bot_id"`
+	VerifyToken           types.Bool    `tfsdk:"verify_token"`
+	SanitizeAPIErrors     types.Bool    `tfsdk:"sanitize_api_errors"`
 }
 
 func New(version string) func() provider.Provider {
@@ -44,6 +56,42 @@ func (p *NotionProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token_file": schema.StringAttribute{
+				Description: "Path of a file holding the Notion API token. The file is read again whenever it changes, so a token " +
+					"rotated during a long apply is picked up by the following API calls. Can also be set via the " +
+					"NOTION_TOKEN_FILE environment variable. Conflicts with token.",
+				Optional: true,
+			},
+			"estimate_api_calls": schema.BoolAttribute{
+				Description: "Log, during plan, an estimate of the Notion API calls each planned change makes and a running total, " +
+					"to help split large applies before they run into rate limits. The estimates are logged at the INFO level " +
+					"(TF_LOG=INFO). Can also be set via the NOTION_ESTIMATE_API_CALLS environment variable.",
+				Optional: true,
+			},
+			"expected_workspace_name": schema.StringAttribute{
+				Description: "The name of the workspace the token must belong to. When set, Configure looks up the token's bot user " +
+					"and fails if it belongs to another workspace, e.g. because a production token was supplied to a development pipeline.",
+				Optional: true,
+			},
+			"expected_bot_id": schema.StringAttribute{
+				Description: "The ID of the bot user the token must belong to. When set, Configure looks up the token's bot user " +
+					"and fails if it is another integration's.",
+				CustomType: NotionIDType{},
+				Optional:   true,
+			},
+			"verify_token": schema.BoolAttribute{
+				Description: "Check when the provider is configured that Notion accepts the token, so an invalid or revoked token " +
+					"fails with a clear error instead of with errors from each resource. Costs one API call per run. " +
+					"Can also be set via the NOTION_VERIFY_TOKEN environment variable.",
+				Optional: true,
+			},
+			"sanitize_api_errors": schema.BoolAttribute{
+				Description: "Keep page content out of error messages, for environments where diagnostics and CI logs must not " +
+					"include it. Quoted text in Notion's error messages is replaced with \"…\" and the messages are cut to 200 " +
+					"characters; other error bodies, such as HTML error pages, are truncated. Applies to every configuration of " +
+					"the provider once any enables it. Can also be set via the NOTION_SANITIZE_API_ERRORS environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -55,40 +103,124 @@ func (p *NotionProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	token := os.Getenv("NOTION_TOKEN")
-	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
+	if !config.Token.IsNull() && !config.TokenFile.IsNull() {
+		resp.Diagnostics.AddError("Conflicting Notion API Token Settings",
+			"Only one of token and token_file can be set in the provider configuration.")
+		return
+	}
+
+	// The configuration takes precedence over the environment, and a token
+	// over a token file.
+	tokenFile := os.Getenv("NOTION_TOKEN_FILE")
+	if !config.TokenFile.IsNull() {
+		tokenFile = config.TokenFile.ValueString()
+	}
+	var source *tokenSource
+	switch {
+	case !config.Token.IsNull():
+		source = staticTokenSource(config.Token.ValueString())
+	case !config.TokenFile.IsNull(), os.Getenv("NOTION_TOKEN") == "" && tokenFile != "":
+		var err error
+		source, err = fileTokenSource(tokenFile)
+		if err != nil {
+			resp.Diagnostics.AddError("Unreadable Notion API Token File", err.Error())
+			return
+		}
+	default:
+		source = staticTokenSource(os.Getenv("NOTION_TOKEN"))
 	}
+	token, _ := source.Token()
 
 	if token == "" {
 		resp.Diagnostics.AddError(
 			"Missing Notion API Token",
 			"The provider cannot create the Notion API client as there is a missing or empty value for the Notion API token. "+
-				"Set the token value in the configuration or use the NOTION_TOKEN environment variable.",
+				"Set the token or token_file value in the configuration or use the NOTION_TOKEN or NOTION_TOKEN_FILE environment variable.",
 		)
 		return
 	}
 
-	// Wire the SDK with a retry-capable http.Client so transient 5xx /
-	// HTML-from-edge responses don't bubble up as the cryptic
+	if err := checkTokenFormat(token); err != nil {
+		resp.Diagnostics.AddError("Malformed Notion API Token", err.Error())
+		return
+	}
+	if !hasKnownTokenPrefix(token) {
+		resp.Diagnostics.AddWarning("Unrecognized Notion API Token",
+			"The Notion API token doesn't start with \"ntn_\" or \"secret_\" like Notion's integration and OAuth tokens do. "+
+				"Check that the token value is an API token and not, for example, an integration or page ID.")
+	}
+
+	// Wire the SDK with the shared retry-capable http.Client so transient
+	// 5xx / HTML-from-edge responses don't bubble up as the cryptic
 	// "invalid character '<' looking for beginning of value" decode
-	// error. See retry_transport.go for the full policy.
+	// error, and so SDK and raw calls share one connection pool. See
+	// retry_transport.go and notion_api_client.go.
+	httpClient := notionHTTPClient
+	if source.rotates() {
+		httpClient = tokenSourceHTTPClient(notionHTTPClient, source)
+	}
 	client := notionapi.NewClient(
 		notionapi.Token(token),
-		notionapi.WithHTTPClient(newRetryHTTPClient()),
+		notionapi.WithHTTPClient(httpClient),
 	)
-	registerClientToken(client, token)
+	registerClientTokenSource(client, source)
+
+	estimate, _ := strconv.ParseBool(os.Getenv("NOTION_ESTIMATE_API_CALLS"))
+	if !config.EstimateAPICalls.IsNull() {
+		estimate = config.EstimateAPICalls.ValueBool()
+	}
+	registerEstimateAPICalls(client, estimate)
+
+	sanitize, _ := strconv.ParseBool(os.Getenv("NOTION_SANITIZE_API_ERRORS"))
+	if !config.SanitizeAPIErrors.IsNull() {
+		sanitize = config.SanitizeAPIErrors.ValueBool()
+	}
+	if sanitize {
+		sanitizeAPIErrors.Store(true)
+	}
 
-	resp.ResourceData = client
-	resp.DataSourceData = client
+	verify, _ := strconv.ParseBool(os.Getenv("NOTION_VERIFY_TOKEN"))
+	if !config.VerifyToken.IsNull() {
+		verify = config.VerifyToken.ValueBool()
+	}
+	checkOwner := !config.ExpectedWorkspaceName.IsNull() || !config.ExpectedBotID.IsNull()
+
+	if verify || checkOwner {
+		me, err := client.User.Me(ctx)
+		if err != nil {
+			summary, detail := describeTokenError(err)
+			resp.Diagnostics.AddError(summary, detail)
+			return
+		}
+		if checkOwner {
+			if err := checkTokenOwner(me, config.ExpectedWorkspaceName.ValueString(), config.ExpectedBotID.ValueNotionID()); err != nil {
+				resp.Diagnostics.AddError("Unexpected Notion Workspace", err.Error())
+				return
+			}
+		}
+	}
+
+	data := newProviderData(client)
+	resp.ResourceData = data
+	resp.DataSourceData = data
 }
 
 func (p *NotionProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewPageResource,
 		NewBlockResource,
+		NewBlocksResource,
+		NewSyncedContentResource,
+		NewColumnsResource,
+		NewPageSectionsResource,
+		NewPageHierarchyResource,
+		NewPageIndexResource,
+		NewDatabaseEntriesBulkResource,
+		NewDatabaseImportResource,
+		NewDatabasePropertiesResource,
 		NewDatabaseResource,
 		NewDatabaseEntryResource,
+		NewChangelogEntryResource,
 		NewDatabasePropertySelectResource,
 		NewDatabasePropertyMultiSelectResource,
 		NewDatabasePropertyStatusResource,
@@ -121,5 +253,58 @@ func (p *NotionProvider) DataSources(_ context.Context) []func() datasource.Data
 		NewBlocksDataSource,
 		NewMeetingNotesDataSource,
 		NewViewQueryDataSource,
+		NewRelatedEntriesDataSource,
+		NewUnmanagedChildrenDataSource,
+		NewPageSnapshotDataSource,
+		NewPageTreeDataSource,
+		NewDatabaseSchemaCheckDataSource,
+		NewRelationCheckDataSource,
+	}
+}
+
+// checkTokenOwner checks the bot user a token belongs to against the
+// expected workspace name and bot ID; an empty expectation is not checked.
+func checkTokenOwner(me *notionapi.User, workspaceName, botID string) error {
+	if me.Type != notionapi.UserTypeBot || me.Bot == nil {
+		return fmt.Errorf("the Notion API token belongs to a %s user, not an integration's bot user", me.Type)
+	}
+	if workspaceName != "" && me.Bot.WorkspaceName != workspaceName {
+		return fmt.Errorf("the Notion API token belongs to workspace %q, but expected_workspace_name is %q", me.Bot.WorkspaceName, workspaceName)
+	}
+	if botID != "" && normalizeID(string(me.ID)) != normalizeID(botID) {
+		return fmt.Errorf("the Notion API token belongs to bot %s (%s), but expected_bot_id is %s", normalizeID(string(me.ID)), me.Name, normalizeID(botID))
+	}
+	return nil
+}
+
+// checkTokenFormat rejects tokens that can't be valid whatever Notion
+// says, such as ones pasted with a trailing newline.
+func checkTokenFormat(token string) error {
+	if strings.TrimSpace(token) != token {
+		return fmt.Errorf("the Notion API token has leading or trailing whitespace; remove it, e.g. the newline left by reading the token from a file")
+	}
+	if strings.ContainsFunc(token, unicode.IsSpace) {
+		return fmt.Errorf("the Notion API token contains whitespace; check that only the token was copied")
+	}
+	return nil
+}
+
+// hasKnownTokenPrefix reports whether token starts like Notion's internal
+// integration and OAuth tokens do.
+func hasKnownTokenPrefix(token string) bool {
+	return strings.HasPrefix(token, "ntn_") || strings.HasPrefix(token, "secret_")
+}
+
+// describeTokenError returns the diagnostic summary and detail for an error
+// looking up the token's bot user.
+func describeTokenError(err error) (string, string) {
+	var apiErr *notionapi.Error
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized {
+		return "Invalid Notion API Token",
+			"Notion rejected the API token (" + apiErr.Message + "). The token is invalid, or the integration it belongs to " +
+				"was deleted, removed from the workspace or had its token refreshed. Check the token value, or create a new " +
+				"token at https://www.notion.so/my-integrations."
 	}
+	return "Error Verifying Notion API Token",
+		"The provider could not look up the bot user of the Notion API token: " + err.Error()
 }
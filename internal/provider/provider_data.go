@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"sync"
+
+	"github.com/jomei/notionapi"
+)
+
+// providerData is what Configure hands to resources and data sources: the
+// API client, and the caches shared by everything the provider instance
+// manages. A provider instance lives for a single Terraform command, and so
+// do its caches.
+type providerData struct {
+	client *notionapi.Client
+
+	// titlePropertyNames maps canonical database IDs to the name of their
+	// title property.
+	titlePropertyNames sync.Map
+
+	// propertyBatches holds the property updates waiting to be sent, by
+	// canonical database ID.
+	propertyBatchesMu sync.Mutex
+	propertyBatches   map[string]*propertyBatch
+
+	// users is the workspace's user list, fetched as far as lookups need.
+	users userDirectory
+}
+
+func newProviderData(client *notionapi.Client) *providerData {
+	return &providerData{
+		client:          client,
+		propertyBatches: map[string]*propertyBatch{},
+	}
+}
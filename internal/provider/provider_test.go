@@ -1,10 +1,110 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/jomei/notionapi"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"notion": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+func TestCheckTokenOwner(t *testing.T) {
+	me := &notionapi.User{
+		ID:   "11111111-2222-3333-4444-555555555555",
+		Type: notionapi.UserTypeBot,
+		Name: "Terraform",
+		Bot:  &notionapi.Bot{WorkspaceName: "Acme Dev"},
+	}
+
+	cases := []struct {
+		name          string
+		workspaceName string
+		botID         string
+		wantErr       bool
+	}{
+		{"no expectations", "", "", false},
+		{"matching workspace", "Acme Dev", "", false},
+		{"other workspace", "Acme", "", true},
+		{"matching bot", "", "11111111222233334444555555555555", false},
+		{"other bot", "", "99999999222233334444555555555555", true},
+	}
+	for _, tc := range cases {
+		err := checkTokenOwner(me, tc.workspaceName, tc.botID)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tc.name, err, tc.wantErr)
+		}
+	}
+
+	person := &notionapi.User{ID: "u", Type: notionapi.UserTypePerson}
+	if err := checkTokenOwner(person, "Acme Dev", ""); err == nil {
+		t.Error("expected an error for a person user")
+	}
+}
+
+func TestCheckTokenFormat(t *testing.T) {
+	if err := checkTokenFormat("ntn_abc123"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	for _, token := range []string{"ntn_abc123\n", " secret_abc", "secret_ab c"} {
+		if err := checkTokenFormat(token); err == nil {
+			t.Errorf("%q: expected an error", token)
+		}
+	}
+	if !hasKnownTokenPrefix("secret_abc") || !hasKnownTokenPrefix("ntn_abc") || hasKnownTokenPrefix("abcd1234abcd1234abcd1234abcd1234") {
+		t.Error("unexpected hasKnownTokenPrefix result")
+	}
+}
+
+func TestDescribeTokenError(t *testing.T) {
+	summary, _ := describeTokenError(&notionapi.Error{Status: 401, Code: "unauthorized", Message: "API token is invalid."})
+	if summary != "Invalid Notion API Token" {
+		t.Errorf("401: got summary %q", summary)
+	}
+	summary, _ = describeTokenError(errors.New("connection refused"))
+	if summary != "Error Verifying Notion API Token" {
+		t.Errorf("other error: got summary %q", summary)
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		var meta resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "notion"}, &meta)
+		t.Run(meta.TypeName, func(t *testing.T) {
+			var resp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected schema diagnostics: %v", resp.Diagnostics)
+			}
+			if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("invalid schema: %v", diags)
+			}
+		})
+	}
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		var meta datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "notion"}, &meta)
+		t.Run("data."+meta.TypeName, func(t *testing.T) {
+			var resp datasource.SchemaResponse
+			d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected schema diagnostics: %v", resp.Diagnostics)
+			}
+			if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("invalid schema: %v", diags)
+			}
+		})
+	}
+}
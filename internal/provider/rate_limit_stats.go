@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Notion allows an integration an average of three requests per second and
+// answers bursts above that with 429 and a Retry-After header. The SDK and
+// doNotionRequestWithVersion both wait and retry, so throttling makes an
+// apply slow rather than failing it. Every 429 is logged as a warning with
+// running totals for the provider process (the current plan or apply), so
+// an operator can see how much of a slow run was spent backing off and
+// lower -parallelism accordingly.
+
+// rateLimitStats counts 429 responses and the backoff they imposed.
+type rateLimitStats struct {
+	throttled atomic.Int64
+	backoff   atomic.Int64 // nanoseconds
+}
+
+// notionRateLimits holds the totals for this provider process.
+var notionRateLimits rateLimitStats
+
+// record adds one 429 whose caller will wait retryAfter, and returns the
+// updated totals.
+func (s *rateLimitStats) record(retryAfter time.Duration) (int64, time.Duration) {
+	throttled := s.throttled.Add(1)
+	backoff := time.Duration(s.backoff.Add(int64(retryAfter)))
+	return throttled, backoff
+}
+
+// rateLimitRetryAfter returns how long a caller waits before retrying a 429
+// response: its Retry-After header in seconds, or one second (the
+// doNotionRequestWithVersion default) when it is missing or malformed.
+func rateLimitRetryAfter(resp *http.Response) time.Duration {
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return time.Second
+}
+
+// recordRateLimited counts a 429 response to req and logs it with the
+// running totals.
+func recordRateLimited(ctx context.Context, req *http.Request, resp *http.Response) {
+	retryAfter := rateLimitRetryAfter(resp)
+	throttled, backoff := notionRateLimits.record(retryAfter)
+	tflog.Warn(ctx, "Notion API rate limit hit; backing off before retrying", map[string]interface{}{
+		"method":          req.Method,
+		"path":            req.URL.Path,
+		"retry_after":     retryAfter.String(),
+		"throttled_total": throttled,
+		"backoff_total":   backoff.String(),
+	})
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport_Records429(t *testing.T) {
+	client := &http.Client{Transport: newTestTransport()}
+
+	tests := []struct {
+		retryAfter  string
+		wantBackoff time.Duration
+	}{
+		{"2", 2 * time.Second},
+		{"", time.Second},
+		{"soon", time.Second},
+	}
+	for _, tt := range tests {
+		retryAfter := tt.retryAfter
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			http.Error(w, `{"code":"rate_limited"}`, http.StatusTooManyRequests)
+		}))
+		t.Cleanup(srv.Close)
+		throttled, backoff := notionRateLimits.throttled.Load(), notionRateLimits.backoff.Load()
+
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+
+		if got := notionRateLimits.throttled.Load() - throttled; got != 1 {
+			t.Errorf("Retry-After %q: throttled increased by %d, want 1", tt.retryAfter, got)
+		}
+		if got := time.Duration(notionRateLimits.backoff.Load() - backoff); got != tt.wantBackoff {
+			t.Errorf("Retry-After %q: backoff increased by %s, want %s", tt.retryAfter, got, tt.wantBackoff)
+		}
+	}
+}
@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,8 +21,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &BlockResource{}
-	_ resource.ResourceWithImportState = &BlockResource{}
+	_ resource.Resource                     = &BlockResource{}
+	_ resource.ResourceWithImportState      = &BlockResource{}
+	_ resource.ResourceWithConfigValidators = &BlockResource{}
+	_ resource.ResourceWithModifyPlan       = &BlockResource{}
 )
 
 type BlockResource struct {
@@ -27,22 +32,24 @@ type BlockResource struct {
 }
 
 type BlockResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	ParentID     types.String `tfsdk:"parent_id"`
-	Type         types.String `tfsdk:"type"`
-	After        types.String `tfsdk:"after"`
-	HasChildren  types.Bool   `tfsdk:"has_children"`
-	RichText     types.String `tfsdk:"rich_text"`
-	RichTextJSON types.String `tfsdk:"rich_text_json"`
-	Color        types.String `tfsdk:"color"`
-	IsToggleable types.Bool   `tfsdk:"is_toggleable"`
-	Checked      types.Bool   `tfsdk:"checked"`
-	Icon         types.String `tfsdk:"icon"`
-	Language     types.String `tfsdk:"language"`
-	Caption      types.String `tfsdk:"caption"`
-	URL          types.String `tfsdk:"url"`
-	Expression   types.String `tfsdk:"expression"`
-	SyncedFrom   types.String `tfsdk:"synced_from"`
+	ID           types.String        `tfsdk:"id"`
+	ParentID     NotionIDValue       `tfsdk:"parent_id"`
+	ParentType   types.String        `tfsdk:"parent_type"`
+	Type         types.String        `tfsdk:"type"`
+	After        types.String        `tfsdk:"after"`
+	HasChildren  types.Bool          `tfsdk:"has_children"`
+	RichText     RichTextStringValue `tfsdk:"rich_text"`
+	RichTextJSON types.String        `tfsdk:"rich_text_json"`
+	Color        types.String        `tfsdk:"color"`
+	IsToggleable types.Bool          `tfsdk:"is_toggleable"`
+	Checked      types.Bool          `tfsdk:"checked"`
+	Icon         types.String        `tfsdk:"icon"`
+	Language     types.String        `tfsdk:"language"`
+	Caption      RichTextStringValue `tfsdk:"caption"`
+	URL          types.String        `tfsdk:"url"`
+	Expression   types.String        `tfsdk:"expression"`
+	SyncedFrom   NotionIDValue       `tfsdk:"synced_from"`
+	Vars         types.Map           `tfsdk:"vars"`
 }
 
 func NewBlockResource() resource.Resource {
@@ -66,9 +73,17 @@ func (r *BlockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"parent_id": schema.StringAttribute{
 				Description: "The ID of the parent page or block.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"parent_type": schema.StringAttribute{
+				Description: "The kind of parent Notion reports for the block: page_id, block_id, database_id or workspace.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"type": schema.StringAttribute{
@@ -96,17 +111,20 @@ func (r *BlockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"rich_text": schema.StringAttribute{
-				Description: "Text content of the block. Supports markdown links: [text](url).",
-				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString(""),
+				Description: "Text content of the block. Supports inline markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and `code`; " +
+					"escape a literal marker with a backslash. Taken literally for code blocks. " +
+					"Compared semantically, so Notion's re-serialization of runs and whitespace does not produce a diff.",
+				CustomType: RichTextStringType{},
+				Optional:   true,
+				Computed:   true,
+				Default:    stringdefault.StaticString(""),
 			},
 			"rich_text_json": schema.StringAttribute{
 				Description: "JSON-encoded array of Notion rich text objects. When set, takes precedence over rich_text.",
 				Optional:    true,
 			},
 			"color": schema.StringAttribute{
-				Description: "Block color (e.g. default, red, blue_background).",
+				Description: "Block color: default, one of the text colors (e.g. red), or a background color (e.g. blue_background). Unknown colors fail at plan time.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
@@ -127,7 +145,7 @@ func (r *BlockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Default:     booldefault.StaticBool(false),
 			},
 			"icon": schema.StringAttribute{
-				Description: "Emoji icon for callout blocks.",
+				Description: "Icon for callout blocks: an emoji, or an http(s) URL of an image.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
@@ -139,16 +157,20 @@ func (r *BlockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Default:     stringdefault.StaticString(""),
 			},
 			"caption": schema.StringAttribute{
-				Description: "Caption text for code, bookmark, and image blocks.",
+				Description: "Caption text for code, bookmark, and image blocks. Supports the same inline markdown as rich_text.",
+				CustomType:  RichTextStringType{},
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
 			},
 			"url": schema.StringAttribute{
-				Description: "URL for bookmark, embed, and image blocks.",
+				Description: "URL for bookmark, embed, and image blocks. Must be an absolute http(s) URL.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
+				Validators: []validator.String{
+					HTTPURLValidator(),
+				},
 			},
 			"expression": schema.StringAttribute{
 				Description: "LaTeX expression for equation blocks.",
@@ -158,26 +180,70 @@ func (r *BlockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"synced_from": schema.StringAttribute{
 				Description: "Source block ID for synced block copies.",
+				CustomType:  NotionIDType{},
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
+			"vars": schema.MapAttribute{
+				Description: "Values for ${key} placeholders in rich_text and caption, substituted when the block is written. " +
+					"Write the placeholders as $${key} in HCL so Terraform doesn't interpolate them. A placeholder without a " +
+					"value is an error.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
 
+func (r *BlockResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		BlockTypeAttributesValidator(),
+	}
+}
+
+// ModifyPlan replaces blocks whose type can't be updated in place when any of
+// their attributes change, so the plan shows the replacement up front instead
+// of the apply failing with "does not support updates".
+func (r *BlockResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer logAPICallEstimate(ctx, r.client, "notion_block", req, resp, singleObjectCallEstimate)
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state BlockResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !containsString(blockTypesWithoutUpdates, state.Type.ValueString()) {
+		return
+	}
+
+	for _, name := range blockTypeSpecificAttributes {
+		if !blockModelAttribute(&plan, name).Equal(blockModelAttribute(&state, name)) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root(name))
+		}
+	}
+	if !plan.Vars.Equal(state.Vars) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("vars"))
+	}
+}
+
 func (r *BlockResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
 
 func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -187,13 +253,18 @@ func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	block, err := buildBlockForCreate(plan)
+	rendered, diags := renderBlockVars(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	block, err := buildBlockForCreate(rendered)
 	if err != nil {
 		resp.Diagnostics.AddError("Error building block", err.Error())
 		return
 	}
 
-	parentID := notionapi.BlockID(plan.ParentID.ValueString())
+	parentID := notionapi.BlockID(plan.ParentID.ValueNotionID())
 	appendReq := &notionapi.AppendBlockChildrenRequest{
 		Children: []notionapi.Block{block},
 	}
@@ -213,7 +284,10 @@ func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	created := result.Results[0]
+	template := plan
 	readBlockIntoState(created, &plan)
+	resp.Diagnostics.Append(keepBlockTemplates(ctx, &template, &plan)...)
+	resp.Diagnostics.Append(trackBlockContentHash(ctx, resp.Private, created)...)
 
 	// Preserve the after value from the plan (it's not returned by the API)
 	if !plan.After.IsNull() && !plan.After.IsUnknown() {
@@ -236,16 +310,41 @@ func (r *BlockResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	if _, ok := block.(*notionapi.UnsupportedBlock); ok {
+		r.readUnsupportedBlock(ctx, &state, resp)
+		return
+	}
+
 	if block.GetArchived() {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	// The hash stays the one of the content Terraform last wrote, so every
+	// refresh reports a change made in Notion until it is applied over. A
+	// block without one, e.g. an imported one, starts from what it has now.
+	tracked, diags := trackedBlockContentHash(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if tracked == "" {
+		resp.Diagnostics.Append(trackBlockContentHash(ctx, resp.Private, block)...)
+	} else {
+		changed, diags := blockChangedExternally(ctx, req.Private, block)
+		resp.Diagnostics.Append(diags...)
+		if changed {
+			resp.Diagnostics.AddWarning("Block changed outside Terraform",
+				fmt.Sprintf("Block %s was edited in Notion since Terraform last wrote it. The next apply that updates the block overwrites the edit.",
+					normalizeID(string(block.GetID()))))
+		}
+	}
+
 	// Preserve after from state since the API doesn't return it
 	after := state.After
 	syncedFrom := state.SyncedFrom
 
+	template := state
 	readBlockIntoState(block, &state)
+	resp.Diagnostics.Append(keepBlockTemplates(ctx, &template, &state)...)
+	warnUnmanageableBlock(&resp.Diagnostics, state.ID.ValueString(), state.Type.ValueString())
 
 	state.After = after
 	// Preserve synced_from if it wasn't set by readBlockIntoState
@@ -256,6 +355,51 @@ func (r *BlockResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+// readUnsupportedBlock reads a block the SDK can't decode, such as one
+// converted in the Notion UI to a type the SDK doesn't know. The SDK returns
+// such a block empty, without even its ID, so only its type and trash
+// status are read, raw. The rest of the state is kept as it was: the new type
+// differs from the configured one, so the next plan replaces the block.
+func (r *BlockResource) readUnsupportedBlock(ctx context.Context, state *BlockResourceModel, resp *resource.ReadResponse) {
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading block", err.Error())
+		return
+	}
+	body, err := notionAPICall(ctx, http.MethodGet, notionAPIBaseURL+"/blocks/"+state.ID.ValueString(), token, notionSDKAPIVersion, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading block", err.Error())
+		return
+	}
+	var raw struct {
+		Type     string `json:"type"`
+		Archived bool   `json:"archived"`
+		InTrash  bool   `json:"in_trash"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		resp.Diagnostics.AddError("Error reading block", fmt.Sprintf("Decoding block %s: %s", state.ID.ValueString(), err))
+		return
+	}
+	if raw.Archived || raw.InTrash {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Type = types.StringValue(raw.Type)
+	warnUnmanageableBlock(&resp.Diagnostics, state.ID.ValueString(), raw.Type)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// warnUnmanageableBlock warns when a block read back has become a type
+// notion_block can't manage, since the next apply would replace it.
+func warnUnmanageableBlock(diags *diag.Diagnostics, id, blockType string) {
+	if err := checkBlockImportType(id, blockType); err != nil {
+		diags.AddWarning("Block is no longer manageable",
+			fmt.Sprintf("The block was changed outside Terraform, and applying would replace it, deleting its content. "+
+				"Remove it from state with terraform state rm first; %s.", err))
+	}
+}
+
 func (r *BlockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan BlockResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -263,7 +407,12 @@ func (r *BlockResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	updateReq, err := buildBlockUpdateRequest(plan)
+	rendered, diags := renderBlockVars(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateReq, err := buildBlockUpdateRequest(rendered)
 	if err != nil {
 		resp.Diagnostics.AddError("Error building block update", err.Error())
 		return
@@ -279,7 +428,10 @@ func (r *BlockResource) Update(ctx context.Context, req resource.UpdateRequest,
 	after := plan.After
 	syncedFrom := plan.SyncedFrom
 
+	template := plan
 	readBlockIntoState(updated, &plan)
+	resp.Diagnostics.Append(keepBlockTemplates(ctx, &template, &plan)...)
+	resp.Diagnostics.Append(trackBlockContentHash(ctx, resp.Private, updated)...)
 
 	plan.After = after
 	if plan.SyncedFrom.IsNull() || plan.SyncedFrom.IsUnknown() {
@@ -304,5 +456,28 @@ func (r *BlockResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *BlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	block, err := r.client.Block.Get(ctx, notionapi.BlockID(normalizeID(req.ID)))
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing block", err.Error())
+		return
+	}
+	if err := checkBlockImportType(normalizeID(req.ID), string(block.GetType())); err != nil {
+		resp.Diagnostics.AddError("Unsupported block type", err.Error())
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// checkBlockImportType returns an error for a block type notion_block can't
+// manage, pointing child pages and databases at the resources that can.
+func checkBlockImportType(id, blockType string) error {
+	switch {
+	case blockType == string(notionapi.BlockTypeChildPage):
+		return fmt.Errorf("block %s is a child page. Import it as a notion_page instead: terraform import notion_page.<name> %s", id, id)
+	case blockType == string(notionapi.BlockTypeChildDatabase):
+		return fmt.Errorf("block %s is a child database. Import it as a notion_database instead: terraform import notion_database.<name> %s", id, id)
+	case !containsString(validBlockTypes, blockType):
+		return fmt.Errorf("block %s has type %q, which notion_block doesn't manage", id, blockType)
+	}
+	return nil
+}
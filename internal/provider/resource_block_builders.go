@@ -12,11 +12,15 @@ func emojiPtr(s string) *notionapi.Emoji {
 	return &e
 }
 
-// resolveRichText returns RichText from rich_text_json if set, otherwise from rich_text with markdown link parsing.
+// resolveRichText returns RichText from rich_text_json if set, otherwise from rich_text with inline markdown parsing.
+// Code blocks take rich_text literally, since source code is full of markdown characters.
 func resolveRichText(plan BlockResourceModel) ([]notionapi.RichText, error) {
 	if !plan.RichTextJSON.IsNull() && !plan.RichTextJSON.IsUnknown() {
 		return jsonToRichText(plan.RichTextJSON.ValueString())
 	}
+	if plan.Type.ValueString() == "code" {
+		return literalRichText(plan.RichText.ValueString()), nil
+	}
 	return plainToRichText(plan.RichText.ValueString()), nil
 }
 
@@ -123,11 +127,8 @@ func buildBlockForCreate(plan BlockResourceModel) (notionapi.Block, error) {
 				Color:    plan.Color.ValueString(),
 			},
 		}
-		if !plan.Icon.IsNull() && !plan.Icon.IsUnknown() && plan.Icon.ValueString() != "" {
-			block.Callout.Icon = &notionapi.Icon{
-				Type:  "emoji",
-				Emoji: emojiPtr(plan.Icon.ValueString()),
-			}
+		if !plan.Icon.IsNull() && !plan.Icon.IsUnknown() {
+			block.Callout.Icon = iconFromConfig(plan.Icon.ValueString())
 		}
 		return block, nil
 
@@ -201,9 +202,9 @@ func buildBlockForCreate(plan BlockResourceModel) (notionapi.Block, error) {
 
 	case "synced_block":
 		synced := notionapi.Synced{}
-		if !plan.SyncedFrom.IsNull() && !plan.SyncedFrom.IsUnknown() && plan.SyncedFrom.ValueString() != "" {
+		if !plan.SyncedFrom.IsNull() && !plan.SyncedFrom.IsUnknown() && plan.SyncedFrom.ValueNotionID() != "" {
 			synced.SyncedFrom = &notionapi.SyncedFrom{
-				BlockID: notionapi.BlockID(plan.SyncedFrom.ValueString()),
+				BlockID: notionapi.BlockID(plan.SyncedFrom.ValueNotionID()),
 			}
 		}
 		return &notionapi.SyncedBlock{
@@ -333,11 +334,8 @@ func buildBlockUpdateRequest(plan BlockResourceModel) (*notionapi.BlockUpdateReq
 			RichText: rt,
 			Color:    plan.Color.ValueString(),
 		}
-		if !plan.Icon.IsNull() && !plan.Icon.IsUnknown() && plan.Icon.ValueString() != "" {
-			callout.Icon = &notionapi.Icon{
-				Type:  "emoji",
-				Emoji: emojiPtr(plan.Icon.ValueString()),
-			}
+		if !plan.Icon.IsNull() && !plan.Icon.IsUnknown() {
+			callout.Icon = iconFromConfig(plan.Icon.ValueString())
 		}
 		return &notionapi.BlockUpdateRequest{Callout: callout}, nil
 
@@ -384,19 +382,24 @@ func buildBlockUpdateRequest(plan BlockResourceModel) (*notionapi.BlockUpdateReq
 		}
 		return &notionapi.BlockUpdateRequest{Image: img}, nil
 
-	case "divider", "table_of_contents", "synced_block", "column_list", "column":
-		return nil, fmt.Errorf("block type %q does not support updates", blockType)
-
 	default:
+		if containsString(blockTypesWithoutUpdates, blockType) {
+			return nil, fmt.Errorf("block type %q does not support updates", blockType)
+		}
 		return nil, fmt.Errorf("unsupported block type: %s", blockType)
 	}
 }
 
+// blockTypesWithoutUpdates are the block types buildBlockUpdateRequest can't
+// update in place. BlockResource.ModifyPlan plans a replacement instead when
+// one of their attributes changes.
+var blockTypesWithoutUpdates = []string{"divider", "table_of_contents", "synced_block", "column_list", "column"}
+
 // setRichTextState sets both RichText and RichTextJSON on the state.
 // If the user originally used rich_text_json (non-null in state), serialize to JSON.
 // Otherwise, use richTextToPlain for the markdown-aware round-trip.
 func setRichTextState(rt []notionapi.RichText, state *BlockResourceModel) {
-	state.RichText = types.StringValue(richTextToPlain(rt))
+	state.RichText = NewRichTextStringValue(richTextToPlain(rt))
 	if !state.RichTextJSON.IsNull() {
 		if j, err := richTextToJSON(rt); err == nil {
 			state.RichTextJSON = types.StringValue(j)
@@ -412,14 +415,15 @@ func readBlockIntoState(block notionapi.Block, state *BlockResourceModel) {
 	blockType := string(block.GetType())
 	state.Type = types.StringValue(blockType)
 
-	// Set parent_id from block's parent
+	// Set parent_type and parent_id from block's parent. A workspace parent
+	// has no ID, so parent_id keeps the value it has.
 	if parent := block.GetParent(); parent != nil {
-		switch parent.Type {
-		case notionapi.ParentTypePageID:
-			state.ParentID = types.StringValue(normalizeID(string(parent.PageID)))
-		case notionapi.ParentTypeBlockID:
-			state.ParentID = types.StringValue(normalizeID(string(parent.BlockID)))
+		state.ParentType = types.StringValue(string(parent.Type))
+		if id := parentID(*parent); id != "" {
+			state.ParentID = NewNotionIDValue(id)
 		}
+	} else if state.ParentType.IsUnknown() {
+		state.ParentType = types.StringNull()
 	}
 
 	switch b := block.(type) {
@@ -466,14 +470,15 @@ func readBlockIntoState(block notionapi.Block, state *BlockResourceModel) {
 	case *notionapi.CalloutBlock:
 		setRichTextState(b.Callout.RichText, state)
 		state.Color = types.StringValue(b.Callout.Color)
-		if b.Callout.Icon != nil && b.Callout.Icon.Emoji != nil {
-			state.Icon = types.StringValue(string(*b.Callout.Icon.Emoji))
+		if b.Callout.Icon != nil {
+			state.Icon = iconToState(b.Callout.Icon, state.Icon)
 		}
 
 	case *notionapi.CodeBlock:
 		setRichTextState(b.Code.RichText, state)
+		state.RichText = NewRichTextStringValue(richTextPlain(b.Code.RichText))
 		state.Language = types.StringValue(b.Code.Language)
-		state.Caption = types.StringValue(richTextToPlain(b.Code.Caption))
+		state.Caption = NewRichTextStringValue(richTextToPlain(b.Code.Caption))
 
 	case *notionapi.EquationBlock:
 		state.Expression = types.StringValue(b.Equation.Expression)
@@ -486,18 +491,18 @@ func readBlockIntoState(block notionapi.Block, state *BlockResourceModel) {
 
 	case *notionapi.BookmarkBlock:
 		state.URL = types.StringValue(b.Bookmark.URL)
-		state.Caption = types.StringValue(richTextToPlain(b.Bookmark.Caption))
+		state.Caption = NewRichTextStringValue(richTextToPlain(b.Bookmark.Caption))
 
 	case *notionapi.EmbedBlock:
 		state.URL = types.StringValue(b.Embed.URL)
 
 	case *notionapi.ImageBlock:
 		state.URL = types.StringValue(b.Image.GetURL())
-		state.Caption = types.StringValue(richTextToPlain(b.Image.Caption))
+		state.Caption = NewRichTextStringValue(richTextToPlain(b.Image.Caption))
 
 	case *notionapi.SyncedBlock:
 		if b.SyncedBlock.SyncedFrom != nil {
-			state.SyncedFrom = types.StringValue(normalizeID(string(b.SyncedBlock.SyncedFrom.BlockID)))
+			state.SyncedFrom = NewNotionIDValue(normalizeID(string(b.SyncedBlock.SyncedFrom.BlockID)))
 		}
 
 	case *notionapi.ColumnListBlock:
@@ -505,5 +510,38 @@ func readBlockIntoState(block notionapi.Block, state *BlockResourceModel) {
 
 	case *notionapi.ColumnBlock:
 		// No additional fields
+
+	case *notionapi.ChildPageBlock:
+		// Not manageable as a block; the title is kept so the state still
+		// says what the block became.
+		state.RichText = NewRichTextStringValue(b.ChildPage.Title)
+
+	case *notionapi.ChildDatabaseBlock:
+		state.RichText = NewRichTextStringValue(b.ChildDatabase.Title)
+	}
+
+	fillBlockDefaults(state)
+}
+
+// fillBlockDefaults sets the type-specific attributes readBlockIntoState left
+// null to their schema defaults. An imported block has nothing in state for
+// the attributes its type doesn't use, and leaving them null would plan an
+// update to the defaults, and write them into generated configuration as
+// null, right after the import.
+func fillBlockDefaults(state *BlockResourceModel) {
+	for _, s := range []*types.String{&state.Color, &state.Icon, &state.Language, &state.URL, &state.Expression} {
+		if s.IsNull() {
+			*s = types.StringValue("")
+		}
+	}
+	for _, s := range []*RichTextStringValue{&state.RichText, &state.Caption} {
+		if s.IsNull() {
+			*s = NewRichTextStringValue("")
+		}
+	}
+	for _, b := range []*types.Bool{&state.IsToggleable, &state.Checked} {
+		if b.IsNull() {
+			*b = types.BoolValue(false)
+		}
 	}
 }
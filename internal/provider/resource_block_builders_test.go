@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestBlockCaptionMarkdownRoundTrip(t *testing.T) {
+	const caption = "See [the docs](https://example.com/docs) for **details**"
+
+	for _, blockType := range []string{"code", "bookmark", "image"} {
+		t.Run(blockType, func(t *testing.T) {
+			plan := BlockListItemModel{
+				Type:     types.StringValue(blockType),
+				RichText: NewRichTextStringValue("fmt.Println()"),
+				Language: types.StringValue("go"),
+				URL:      types.StringValue("https://example.com/diagram.png"),
+				Caption:  NewRichTextStringValue(caption),
+			}.toBlockModel(NewNotionIDNull())
+
+			block, err := buildBlockForCreate(plan)
+			if err != nil {
+				t.Fatalf("buildBlockForCreate() error = %v", err)
+			}
+
+			// Notion echoes each run back with plain_text filled in.
+			var rt []notionapi.RichText
+			switch b := block.(type) {
+			case *notionapi.CodeBlock:
+				rt = b.Code.Caption
+			case *notionapi.BookmarkBlock:
+				rt = b.Bookmark.Caption
+			case *notionapi.ImageBlock:
+				rt = b.Image.Caption
+			default:
+				t.Fatalf("unexpected block %T", block)
+			}
+			if len(rt) != 4 {
+				t.Fatalf("caption has %d runs, want 4: %+v", len(rt), rt)
+			}
+			for i := range rt {
+				rt[i].PlainText = rt[i].Text.Content
+			}
+
+			var state BlockResourceModel
+			readBlockIntoState(block, &state)
+			if got := state.Caption.ValueString(); got != caption {
+				t.Errorf("caption = %q, want %q", got, caption)
+			}
+		})
+	}
+}
+
+func TestReadBlockIntoStateFillsDefaults(t *testing.T) {
+	// An imported block starts with nothing but its ID in state.
+	var state BlockResourceModel
+	readBlockIntoState(&notionapi.DividerBlock{BasicBlock: notionapi.BasicBlock{ID: "b1", Type: notionapi.BlockTypeDivider}}, &state)
+
+	if state.Type.ValueString() != "divider" {
+		t.Fatalf("type = %s, want divider", state.Type)
+	}
+	for name, v := range map[string]interface{ IsNull() bool }{
+		"rich_text": state.RichText, "color": state.Color, "is_toggleable": state.IsToggleable,
+		"checked": state.Checked, "icon": state.Icon, "language": state.Language,
+		"caption": state.Caption, "url": state.URL, "expression": state.Expression,
+	} {
+		if v.IsNull() {
+			t.Errorf("%s is null, want the schema default", name)
+		}
+	}
+	if !state.Color.Equal(types.StringValue("")) || !state.Checked.Equal(types.BoolValue(false)) {
+		t.Errorf("unexpected defaults: color %s, checked %s", state.Color, state.Checked)
+	}
+}
+
+func TestCheckBlockImportType(t *testing.T) {
+	if err := checkBlockImportType("b1", "paragraph"); err != nil {
+		t.Errorf("paragraph: unexpected error: %s", err)
+	}
+	for blockType, want := range map[string]string{
+		"child_page":     "notion_page",
+		"child_database": "notion_database",
+		"link_preview":   "doesn't manage",
+	} {
+		err := checkBlockImportType("b1", blockType)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got %v, want an error mentioning %q", blockType, err, want)
+		}
+	}
+}
+
+func TestChildPageAndDatabaseBlocksReadOnly(t *testing.T) {
+	page := &notionapi.ChildPageBlock{BasicBlock: notionapi.BasicBlock{ID: "p1", Type: notionapi.BlockTypeChildPage}}
+	page.ChildPage.Title = "Runbook"
+	db := &notionapi.ChildDatabaseBlock{BasicBlock: notionapi.BasicBlock{ID: "d1", Type: notionapi.BlockTypeChildDatabase}}
+	db.ChildDatabase.Title = "Tasks"
+
+	for _, tc := range []struct {
+		block notionapi.Block
+		title string
+	}{{page, "Runbook"}, {db, "Tasks"}} {
+		var state BlockResourceModel
+		readBlockIntoState(tc.block, &state)
+		if state.RichText.ValueString() != tc.title {
+			t.Errorf("%s: rich_text = %q, want %q", tc.block.GetType(), state.RichText.ValueString(), tc.title)
+		}
+
+		model := blockDataModel(tc.block)
+		if model.Title.ValueString() != tc.title || model.PlainText.ValueString() != tc.title {
+			t.Errorf("%s: data source title = %q, plain_text = %q, want %q", tc.block.GetType(), model.Title.ValueString(), model.PlainText.ValueString(), tc.title)
+		}
+	}
+
+	para := &notionapi.ParagraphBlock{BasicBlock: notionapi.BasicBlock{ID: "b1", Type: notionapi.BlockTypeParagraph}}
+	if model := blockDataModel(para); model.Title.IsNull() || model.Title.ValueString() != "" {
+		t.Errorf("paragraph: title = %s, want empty", model.Title)
+	}
+}
+
+func TestReadBlockIntoStateParent(t *testing.T) {
+	const configured = "99999999999999999999999999999999"
+	cases := []struct {
+		name     string
+		parent   notionapi.Parent
+		wantType string
+		wantID   string
+	}{
+		{"page", notionapi.Parent{Type: notionapi.ParentTypePageID, PageID: "11111111-1111-1111-1111-111111111111"}, "page_id", "11111111111111111111111111111111"},
+		{"block", notionapi.Parent{Type: notionapi.ParentTypeBlockID, BlockID: "22222222-2222-2222-2222-222222222222"}, "block_id", "22222222222222222222222222222222"},
+		{"database", notionapi.Parent{Type: notionapi.ParentTypeDatabaseID, DatabaseID: "33333333-3333-3333-3333-333333333333"}, "database_id", "33333333333333333333333333333333"},
+		{"workspace", notionapi.Parent{Type: notionapi.ParentTypeWorkspace, Workspace: true}, "workspace", configured},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := BlockResourceModel{ParentID: NewNotionIDValue(configured), ParentType: types.StringUnknown()}
+			readBlockIntoState(&notionapi.DividerBlock{BasicBlock: notionapi.BasicBlock{
+				ID: "b1", Type: notionapi.BlockTypeDivider, Parent: &tc.parent,
+			}}, &state)
+			if got := state.ParentType.ValueString(); got != tc.wantType {
+				t.Errorf("parent_type = %q, want %q", got, tc.wantType)
+			}
+			if got := state.ParentID.ValueNotionID(); got != tc.wantID {
+				t.Errorf("parent_id = %q, want %q", got, tc.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,611 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                     = &BlocksResource{}
+	_ resource.ResourceWithConfigValidators = &BlocksResource{}
+	_ resource.ResourceWithModifyPlan       = &BlocksResource{}
+)
+
+// maxAppendChildren is the most children Notion accepts in one append call.
+const maxAppendChildren = 100
+
+// BlocksResource manages an ordered run of sibling blocks under one parent.
+// Declaring many notion_block resources on a page costs one API call each,
+// and since Terraform creates them concurrently their order depends on which
+// call lands first unless every block chains "after" to its predecessor.
+// notion_blocks creates the whole run with a single append call (per 100
+// blocks), so the declared order is the order on the page.
+type BlocksResource struct {
+	client *notionapi.Client
+}
+
+type BlocksResourceModel struct {
+	ID       types.String         `tfsdk:"id"`
+	ParentID NotionIDValue        `tfsdk:"parent_id"`
+	After    types.String         `tfsdk:"after"`
+	Blocks   []BlockListItemModel `tfsdk:"blocks"`
+	BlockIDs types.List           `tfsdk:"block_ids"`
+}
+
+// BlockListItemModel is one element of notion_blocks.blocks. Its attributes
+// mean the same as the notion_block attributes of the same name.
+type BlockListItemModel struct {
+	Type         types.String        `tfsdk:"type"`
+	RichText     RichTextStringValue `tfsdk:"rich_text"`
+	RichTextJSON types.String        `tfsdk:"rich_text_json"`
+	Color        types.String        `tfsdk:"color"`
+	IsToggleable types.Bool          `tfsdk:"is_toggleable"`
+	Checked      types.Bool          `tfsdk:"checked"`
+	Icon         types.String        `tfsdk:"icon"`
+	Language     types.String        `tfsdk:"language"`
+	Caption      RichTextStringValue `tfsdk:"caption"`
+	URL          types.String        `tfsdk:"url"`
+	Expression   types.String        `tfsdk:"expression"`
+	SyncedFrom   NotionIDValue       `tfsdk:"synced_from"`
+}
+
+// toBlockModel returns the item as a notion_block model under parentID, so
+// the notion_block builders and readers can be reused.
+func (m BlockListItemModel) toBlockModel(parentID NotionIDValue) BlockResourceModel {
+	return BlockResourceModel{
+		ID:           types.StringNull(),
+		ParentID:     parentID,
+		ParentType:   types.StringNull(),
+		Type:         m.Type,
+		After:        types.StringNull(),
+		HasChildren:  types.BoolNull(),
+		RichText:     m.RichText,
+		RichTextJSON: m.RichTextJSON,
+		Color:        m.Color,
+		IsToggleable: m.IsToggleable,
+		Checked:      m.Checked,
+		Icon:         m.Icon,
+		Language:     m.Language,
+		Caption:      m.Caption,
+		URL:          m.URL,
+		Expression:   m.Expression,
+		SyncedFrom:   m.SyncedFrom,
+	}
+}
+
+// blockListItemFromModel is the inverse of toBlockModel.
+func blockListItemFromModel(b BlockResourceModel) BlockListItemModel {
+	return BlockListItemModel{
+		Type:         b.Type,
+		RichText:     b.RichText,
+		RichTextJSON: b.RichTextJSON,
+		Color:        b.Color,
+		IsToggleable: b.IsToggleable,
+		Checked:      b.Checked,
+		Icon:         b.Icon,
+		Language:     b.Language,
+		Caption:      b.Caption,
+		URL:          b.URL,
+		Expression:   b.Expression,
+		SyncedFrom:   b.SyncedFrom,
+	}
+}
+
+func NewBlocksResource() resource.Resource {
+	return &BlocksResource{}
+}
+
+func (r *BlocksResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blocks"
+}
+
+func (r *BlocksResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an ordered list of sibling content blocks, created together in declared order.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the first block in the list.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "The ID of the parent page or block.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"after": schema.StringAttribute{
+				Description: "Insert the blocks after the specified block ID. If omitted, appends to the end.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"blocks": schema.ListNestedAttribute{
+				Description: "The blocks, in page order. Adding, removing or reordering blocks, or changing a block's type, " +
+					"replaces the whole list; other changes update the affected blocks in place.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: blockListItemAttributes(),
+				},
+			},
+			"block_ids": schema.ListAttribute{
+				Description: "The IDs of the created blocks, in the same order as blocks.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// blockListItemAttributes mirrors the type-specific notion_block attributes.
+func blockListItemAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			Description: "The block type (e.g. paragraph, heading_1, code, etc.).",
+			Required:    true,
+			Validators: []validator.String{
+				BlockTypeValidator(),
+			},
+		},
+		"rich_text": schema.StringAttribute{
+			Description: "Text content of the block. Supports inline markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and `code`; " +
+				"escape a literal marker with a backslash. Taken literally for code blocks.",
+			CustomType: RichTextStringType{},
+			Optional:   true,
+			Computed:   true,
+			Default:    stringdefault.StaticString(""),
+		},
+		"rich_text_json": schema.StringAttribute{
+			Description: "JSON-encoded array of Notion rich text objects. When set, takes precedence over rich_text.",
+			Optional:    true,
+		},
+		"color": schema.StringAttribute{
+			Description: "Block color: default, one of the text colors (e.g. red), or a background color (e.g. blue_background). Unknown colors fail at plan time.",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+			Validators: []validator.String{
+				BlockColorValidator(),
+			},
+		},
+		"is_toggleable": schema.BoolAttribute{
+			Description: "Whether a heading block is toggleable.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"checked": schema.BoolAttribute{
+			Description: "Whether a to-do block is checked.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"icon": schema.StringAttribute{
+			Description: "Icon for callout blocks: an emoji, or an http(s) URL of an image.",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+		},
+		"language": schema.StringAttribute{
+			Description: "Programming language for code blocks.",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+		},
+		"caption": schema.StringAttribute{
+			Description: "Caption text for code, bookmark, and image blocks. Supports the same inline markdown as rich_text.",
+			CustomType:  RichTextStringType{},
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+		},
+		"url": schema.StringAttribute{
+			Description: "URL for bookmark, embed, and image blocks. Must be an absolute http(s) URL.",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+			Validators: []validator.String{
+				HTTPURLValidator(),
+			},
+		},
+		"expression": schema.StringAttribute{
+			Description: "LaTeX expression for equation blocks.",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+		},
+		"synced_from": schema.StringAttribute{
+			Description: "Source block ID for synced block copies.",
+			CustomType:  NotionIDType{},
+			Optional:    true,
+		},
+	}
+}
+
+func (r *BlocksResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		BlockListAttributesValidator(),
+	}
+}
+
+func (r *BlocksResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+// ModifyPlan replaces the list when its shape changes (blocks added, removed
+// or reordered, or a type changed) or when a block whose type can't be
+// updated in place changes, since block_ids pair up with blocks by position.
+func (r *BlocksResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state BlocksResourceModel
+	// A block list that isn't known until apply can't be read into Go
+	// values or compared, so it isn't checked for replacement.
+	var blocks types.List
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("blocks"), &blocks)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !req.Plan.Raw.IsNull() && !req.State.Raw.IsNull() && listElementsKnown(blocks) {
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if blockListNeedsReplace(state.Blocks, plan.Blocks) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("blocks"))
+		}
+	}
+
+	logAPICallEstimate(ctx, r.client, "notion_blocks", req, resp, func(action string) int {
+		// A planned block list that isn't known yet can't be read, and
+		// counts as empty.
+		if !req.Plan.Raw.IsNull() {
+			req.Plan.Get(ctx, &plan)
+		}
+		if !req.State.Raw.IsNull() {
+			req.State.Get(ctx, &state)
+		}
+		return blockListCallEstimate(action, state.Blocks, plan.Blocks)
+	})
+}
+
+// listElementsKnown reports whether list and each of its elements are
+// known, so it can be read into a Go slice.
+func listElementsKnown(list types.List) bool {
+	if list.IsUnknown() {
+		return false
+	}
+	for _, elem := range list.Elements() {
+		if elem.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// blockListNeedsReplace reports whether going from prior to next can't be
+// done by updating the existing blocks one by one.
+func blockListNeedsReplace(prior, next []BlockListItemModel) bool {
+	if len(prior) != len(next) {
+		return true
+	}
+	for i := range next {
+		if !next[i].Type.Equal(prior[i].Type) {
+			return true
+		}
+		if containsString(blockTypesWithoutUpdates, prior[i].Type.ValueString()) && blockListItemChanged(prior[i], next[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockListItemChanged reports whether any type-specific attribute differs.
+func blockListItemChanged(prior, next BlockListItemModel) bool {
+	priorModel := prior.toBlockModel(NewNotionIDNull())
+	nextModel := next.toBlockModel(NewNotionIDNull())
+	for _, name := range blockTypeSpecificAttributes {
+		if !blockModelAttribute(&nextModel, name).Equal(blockModelAttribute(&priorModel, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *BlocksResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BlocksResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	children, diags := buildBlockList(plan.Blocks, plan.ParentID, path.Root("blocks"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var after notionapi.BlockID
+	if !plan.After.IsNull() && !plan.After.IsUnknown() {
+		after = notionapi.BlockID(plan.After.ValueString())
+	}
+
+	created, err := appendBlocks(ctx, r.client, notionapi.BlockID(plan.ParentID.ValueNotionID()), after, children)
+	if err != nil {
+		// Blocks from earlier chunks already exist; record them so a
+		// retry replaces them instead of leaving duplicates behind.
+		if len(created) > 0 {
+			resp.Diagnostics.Append(r.setCreatedState(ctx, &plan, created, &resp.State)...)
+		}
+		resp.Diagnostics.AddError("Error creating blocks", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setCreatedState(ctx, &plan, created, &resp.State)...)
+}
+
+// stateSetter is the subset of tfsdk.State used by setCreatedState.
+type stateSetter interface {
+	Set(ctx context.Context, val interface{}) diag.Diagnostics
+}
+
+// setCreatedState records the created blocks, which pair up with the first
+// len(created) planned blocks, and writes the model to state.
+func (r *BlocksResource) setCreatedState(ctx context.Context, plan *BlocksResourceModel, created []notionapi.Block, state stateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ids := make([]string, len(created))
+	blocks := make([]BlockListItemModel, len(created))
+	for i, block := range created {
+		ids[i], blocks[i] = readBlockListItem(block, plan.Blocks[i], plan.ParentID)
+	}
+
+	plan.Blocks = blocks
+	plan.ID = types.StringValue(ids[0])
+	idList, d := types.ListValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	plan.BlockIDs = idList
+
+	diags.Append(state.Set(ctx, plan)...)
+	return diags
+}
+
+func (r *BlocksResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BlocksResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// One paginated listing of the parent's children refreshes every block,
+	// instead of a Get per block. Trashed blocks aren't listed.
+	children, err := listChildBlocks(ctx, r.client, state.ParentID.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading blocks", err.Error())
+		return
+	}
+
+	// Blocks deleted outside Terraform drop out of the list, which changes
+	// its length and so plans a replacement of the rest.
+	keptIDs, kept := refreshBlockList(children, ids, state.Blocks, state.ParentID)
+
+	if len(kept) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Blocks = kept
+	idList, diags := types.ListValueFrom(ctx, types.StringType, keptIDs)
+	resp.Diagnostics.Append(diags...)
+	state.BlockIDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BlocksResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state BlocksResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(ids) != len(plan.Blocks) {
+		// ModifyPlan replaces the resource in this case.
+		resp.Diagnostics.AddError("Error updating blocks",
+			fmt.Sprintf("State tracks %d blocks but the plan has %d. Please report this to the provider developers.", len(ids), len(plan.Blocks)))
+		return
+	}
+
+	resp.Diagnostics.Append(updateBlockList(ctx, r.client, ids, state.Blocks, plan.Blocks, plan.ParentID, path.Root("blocks"))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.BlockIDs = state.BlockIDs
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BlocksResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state BlocksResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, id := range ids {
+		// A block already deleted outside Terraform is as good as deleted.
+		if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(id)); err != nil && !isGoneError(err) {
+			resp.Diagnostics.AddError("Error deleting block", fmt.Sprintf("Block %s: %s", id, err))
+		}
+	}
+}
+
+// appendBlocks appends children under parentID after the given block (or at
+// the end when after is empty), in chunks of maxAppendChildren so the
+// declared order is kept. On error it returns the blocks created so far.
+func appendBlocks(ctx context.Context, client *notionapi.Client, parentID, after notionapi.BlockID, children []notionapi.Block) ([]notionapi.Block, error) {
+	var created []notionapi.Block
+	for start := 0; start < len(children); start += maxAppendChildren {
+		end := min(start+maxAppendChildren, len(children))
+		result, err := client.Block.AppendChildren(ctx, parentID, &notionapi.AppendBlockChildrenRequest{
+			After:    after,
+			Children: children[start:end],
+		})
+		if err != nil {
+			return created, err
+		}
+		if len(result.Results) != end-start {
+			return created, fmt.Errorf("Notion API returned %d blocks for %d appended", len(result.Results), end-start)
+		}
+		created = append(created, result.Results...)
+		after = created[len(created)-1].GetID()
+	}
+	return created, nil
+}
+
+// buildBlockList builds the API blocks for items under parentID. Errors are
+// reported against the item's index in the list at at.
+func buildBlockList(items []BlockListItemModel, parentID NotionIDValue, at path.Path) ([]notionapi.Block, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	blocks := make([]notionapi.Block, 0, len(items))
+	for i, item := range items {
+		block, err := buildBlockForCreate(item.toBlockModel(parentID))
+		if err != nil {
+			diags.AddAttributeError(at.AtListIndex(i), "Error building block", err.Error())
+			return nil, diags
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, diags
+}
+
+// readBlockListItem reads block back into item and returns its ID. Like
+// notion_block, synced_from is kept from item when the API doesn't report
+// it.
+func readBlockListItem(block notionapi.Block, item BlockListItemModel, parentID NotionIDValue) (string, BlockListItemModel) {
+	model := item.toBlockModel(parentID)
+	syncedFrom := model.SyncedFrom
+	readBlockIntoState(block, &model)
+	if model.SyncedFrom.IsNull() || model.SyncedFrom.IsUnknown() {
+		model.SyncedFrom = syncedFrom
+	}
+	return model.ID.ValueString(), blockListItemFromModel(model)
+}
+
+// listChildBlocks returns the children of a page or block, in order.
+func listChildBlocks(ctx context.Context, client *notionapi.Client, parentID string) ([]notionapi.Block, error) {
+	var children []notionapi.Block
+	var cursor notionapi.Cursor
+	for {
+		page, err := client.Block.GetChildren(ctx, notionapi.BlockID(parentID), &notionapi.Pagination{
+			StartCursor: cursor,
+			PageSize:    100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, page.Results...)
+		if !page.HasMore {
+			return children, nil
+		}
+		cursor = notionapi.Cursor(page.NextCursor)
+	}
+}
+
+// refreshBlockList reads the blocks tracked by ids back from children,
+// dropping the ones that no longer exist or were archived.
+func refreshBlockList(children []notionapi.Block, ids []string, items []BlockListItemModel, parentID NotionIDValue) ([]string, []BlockListItemModel) {
+	byID := make(map[string]notionapi.Block, len(children))
+	for _, b := range children {
+		byID[normalizeID(string(b.GetID()))] = b
+	}
+
+	var keptIDs []string
+	var kept []BlockListItemModel
+	for i, id := range ids {
+		block, ok := byID[id]
+		if !ok || block.GetArchived() || i >= len(items) {
+			continue
+		}
+		_, item := readBlockListItem(block, items[i], parentID)
+		keptIDs = append(keptIDs, id)
+		kept = append(kept, item)
+	}
+	return keptIDs, kept
+}
+
+// updateBlockList updates in place the blocks of next that differ from
+// prior, and reads each updated block back into next. ids pairs up with
+// both lists by position; errors are reported against the index at at.
+func updateBlockList(ctx context.Context, client *notionapi.Client, ids []string, prior, next []BlockListItemModel, parentID NotionIDValue, at path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i, item := range next {
+		if i < len(prior) && !blockListItemChanged(prior[i], item) {
+			continue
+		}
+
+		updateReq, err := buildBlockUpdateRequest(item.toBlockModel(parentID))
+		if err != nil {
+			diags.AddAttributeError(at.AtListIndex(i), "Error building block update", err.Error())
+			return diags
+		}
+		updated, err := client.Block.Update(ctx, notionapi.BlockID(ids[i]), updateReq)
+		if err != nil {
+			diags.AddAttributeError(at.AtListIndex(i), "Error updating block", err.Error())
+			return diags
+		}
+		_, next[i] = readBlockListItem(updated, item, parentID)
+	}
+	return diags
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccBlocksResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlocksConfig(parentPageID, "Intro"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("notion_blocks.test", "id"),
+					resource.TestCheckResourceAttr("notion_blocks.test", "block_ids.#", "3"),
+					resource.TestCheckResourceAttrPair("notion_blocks.test", "id", "notion_blocks.test", "block_ids.0"),
+					resource.TestCheckResourceAttr("notion_blocks.test", "blocks.1.rich_text", "Intro"),
+				),
+			},
+			{
+				Config: testAccBlocksConfig(parentPageID, "Intro, revised"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_blocks.test", "block_ids.#", "3"),
+					resource.TestCheckResourceAttr("notion_blocks.test", "blocks.1.rich_text", "Intro, revised"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBlocksConfig(parentPageID, intro string) string {
+	return fmt.Sprintf(`
+resource "notion_page" "test" {
+  parent_page_id = %q
+  title          = "TF Acc Blocks"
+}
+
+resource "notion_blocks" "test" {
+  parent_id = notion_page.test.id
+  blocks = [
+    { type = "heading_1", rich_text = "Welcome" },
+    { type = "paragraph", rich_text = %q },
+    { type = "divider" },
+  ]
+}
+`, parentPageID, intro)
+}
+
+func TestBlockListNeedsReplace(t *testing.T) {
+	item := func(blockType, text string) BlockListItemModel {
+		return BlockListItemModel{
+			Type:         types.StringValue(blockType),
+			RichText:     NewRichTextStringValue(text),
+			RichTextJSON: types.StringNull(),
+			Color:        types.StringValue(""),
+			IsToggleable: types.BoolValue(false),
+			Checked:      types.BoolValue(false),
+			Icon:         types.StringValue(""),
+			Language:     types.StringValue(""),
+			Caption:      NewRichTextStringValue(""),
+			URL:          types.StringValue(""),
+			Expression:   types.StringValue(""),
+			SyncedFrom:   NewNotionIDNull(),
+		}
+	}
+	prior := []BlockListItemModel{item("heading_1", "Title"), item("paragraph", "Body"), item("divider", "")}
+
+	tests := []struct {
+		name string
+		next []BlockListItemModel
+		want bool
+	}{
+		{"unchanged", []BlockListItemModel{item("heading_1", "Title"), item("paragraph", "Body"), item("divider", "")}, false},
+		{"text edit", []BlockListItemModel{item("heading_1", "Title"), item("paragraph", "New body"), item("divider", "")}, false},
+		{"block added", []BlockListItemModel{item("heading_1", "Title"), item("paragraph", "Body"), item("divider", ""), item("paragraph", "More")}, true},
+		{"block removed", []BlockListItemModel{item("heading_1", "Title"), item("divider", "")}, true},
+		{"type changed", []BlockListItemModel{item("heading_2", "Title"), item("paragraph", "Body"), item("divider", "")}, true},
+		{"reordered", []BlockListItemModel{item("paragraph", "Body"), item("heading_1", "Title"), item("divider", "")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockListNeedsReplace(prior, tt.next); got != tt.want {
+				t.Errorf("blockListNeedsReplace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlocksResourceModifyPlanUnknownBlocks(t *testing.T) {
+	ctx := context.Background()
+	r := &BlocksResource{}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	blockIDs, _ := types.ListValueFrom(ctx, types.StringType, []string{"b1"})
+	diags := state.Set(ctx, &BlocksResourceModel{
+		ID:       types.StringValue("page"),
+		ParentID: NewNotionIDValue("page"),
+		After:    types.StringNull(),
+		Blocks: []BlockListItemModel{{
+			Type:         types.StringValue("divider"),
+			RichText:     NewRichTextStringValue(""),
+			RichTextJSON: types.StringNull(),
+			Color:        types.StringNull(),
+			IsToggleable: types.BoolNull(),
+			Checked:      types.BoolNull(),
+			Icon:         types.StringNull(),
+			Language:     types.StringNull(),
+			Caption:      NewRichTextStringValue(""),
+			URL:          types.StringNull(),
+			Expression:   types.StringNull(),
+			SyncedFrom:   NewNotionIDNull(),
+		}},
+		BlockIDs: blockIDs,
+	})
+	if diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	// The planned list comes from another resource's attribute, so it
+	// isn't known until apply.
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["parent_id"] = tftypes.NewValue(tftypes.String, "page")
+	values["blocks"] = tftypes.NewValue(objType.AttributeTypes["blocks"], tftypes.UnknownValue)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, values)}
+
+	req := fwresource.ModifyPlanRequest{State: state, Plan: plan}
+	resp := fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(resp.RequiresReplace) != 0 {
+		t.Errorf("got RequiresReplace %v, want none for an unknown list", resp.RequiresReplace)
+	}
+}
+
+func TestBlocksResourceDeleteIgnoresGoneBlocks(t *testing.T) {
+	ctx := context.Background()
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		// Deleted outside Terraform.
+		"b1": {http.StatusNotFound, `{"object":"error","status":404,"code":"object_not_found","message":"Could not find block"}`},
+		// Archived outside Terraform.
+		"b2": {http.StatusBadRequest, `{"object":"error","status":400,"code":"validation_error","message":"Can't edit block that is archived. You must unarchive the block before editing."}`},
+		"b3": {http.StatusOK, `{"object":"block","id":"b3","type":"divider","divider":{},"archived":true}`},
+	}
+	var deleted []string
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			id := path.Base(req.URL.Path)
+			deleted = append(deleted, id)
+			status, body := responses[id].status, responses[id].body
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+	r := &BlocksResource{client: client}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	blockIDs, _ := types.ListValueFrom(ctx, types.StringType, []string{"b1", "b2", "b3"})
+	diags := state.Set(ctx, &BlocksResourceModel{
+		ID:       types.StringValue("page"),
+		ParentID: NewNotionIDValue("page"),
+		After:    types.StringNull(),
+		BlockIDs: blockIDs,
+	})
+	if diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	var resp fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if strings.Join(deleted, ",") != "b1,b2,b3" {
+		t.Errorf("deleted %v, want b1, b2 and b3", deleted)
+	}
+}
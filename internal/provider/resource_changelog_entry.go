@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                   = &ChangelogEntryResource{}
+	_ resource.ResourceWithImportState    = &ChangelogEntryResource{}
+	_ resource.ResourceWithValidateConfig = &ChangelogEntryResource{}
+)
+
+// ChangelogEntryResource publishes a release note as a page in a changelog
+// database: its title, release date, tags and markdown body in one
+// resource. notion_database_entry has no multi-select tags, so the same
+// page otherwise takes an entry, a separate write for the tags and the
+// markdown on top.
+type ChangelogEntryResource struct {
+	client   *notionapi.Client
+	mdClient *markdownClient
+	provider *providerData
+}
+
+type ChangelogEntryResourceModel struct {
+	ID           types.String        `tfsdk:"id"`
+	Database     NotionIDValue       `tfsdk:"database"`
+	Title        RichTextStringValue `tfsdk:"title"`
+	Date         DateStringValue     `tfsdk:"date"`
+	Tags         types.Set           `tfsdk:"tags"`
+	Markdown     types.String        `tfsdk:"markdown"`
+	DateProperty types.String        `tfsdk:"date_property"`
+	TagsProperty types.String        `tfsdk:"tags_property"`
+	URL          types.String        `tfsdk:"url"`
+}
+
+func NewChangelogEntryResource() resource.Resource {
+	return &ChangelogEntryResource{}
+}
+
+func (r *ChangelogEntryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_changelog_entry"
+}
+
+func (r *ChangelogEntryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Publishes a release note to a changelog database: a page with a title, a date, tags and a markdown body.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the page.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "The ID of the changelog database.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "The title of the entry, e.g. the version released. Supports the same inline markdown as " +
+					"notion_database_entry's title.",
+				CustomType: RichTextStringType{},
+				Required:   true,
+			},
+			"date": schema.StringAttribute{
+				Description: "The release date, as an ISO 8601 date (2006-01-02) or RFC3339 datetime, written to date_property.",
+				CustomType:  DateStringType{},
+				Optional:    true,
+			},
+			"tags": schema.SetAttribute{
+				Description: "Option names written to the multi-select tags_property. Options the property doesn't have yet are added to it.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"markdown": schema.StringAttribute{
+				Description: "The body of the entry, as markdown. Written on create and whenever it changes; not read back, " +
+					"since Notion normalizes it.",
+				Optional: true,
+			},
+			"date_property": schema.StringAttribute{
+				Description: `The name of the date property that holds date. Defaults to "Date".`,
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Date"),
+			},
+			"tags_property": schema.StringAttribute{
+				Description: `The name of the multi-select property that holds tags. Defaults to "Tags".`,
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Tags"),
+			},
+			"url": schema.StringAttribute{
+				Description: "The URL of the page.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig rejects a date Notion wouldn't accept, so it fails at plan
+// time rather than after the page is half written.
+func (r *ChangelogEntryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ChangelogEntryResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Date.IsNull() || config.Date.IsUnknown() {
+		return
+	}
+	if _, _, err := parseNotionDate(config.Date.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("date"), "Invalid Date Value", err.Error()+".")
+	}
+}
+
+func (r *ChangelogEntryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.provider = data
+	r.mdClient = newMarkdownClient(data.client)
+}
+
+func (r *ChangelogEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ChangelogEntryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	titlePropName, err := r.provider.titlePropertyName(ctx, plan.Database.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+	properties := changelogEntryProperties(ctx, &plan, nil, titlePropName, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// With a body, the page and its content are created in one request.
+	if !plan.Markdown.IsNull() {
+		props := make(map[string]interface{}, len(properties))
+		for name, prop := range properties {
+			props[name] = prop
+		}
+		created, err := r.mdClient.CreateDatabaseEntryWithMarkdown(ctx, plan.Database.ValueNotionID(), plan.Markdown.ValueString(), props)
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating changelog entry", err.Error())
+			return
+		}
+		plan.ID = types.StringValue(normalizeID(created.ID))
+		plan.URL = types.StringValue(created.URL)
+	} else {
+		page, err := r.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+			Parent: notionapi.Parent{
+				Type:       notionapi.ParentTypeDatabaseID,
+				DatabaseID: notionapi.DatabaseID(plan.Database.ValueNotionID()),
+			},
+			Properties: properties,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating changelog entry", err.Error())
+			return
+		}
+		plan.ID = types.StringValue(normalizeID(string(page.ID)))
+		plan.URL = types.StringValue(page.URL)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ChangelogEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ChangelogEntryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading changelog entry", err.Error())
+		return
+	}
+	page, raw, err := getPageWithRaw(ctx, token, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading changelog entry", err.Error())
+		return
+	}
+	if page.Archived {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(normalizeID(string(page.ID)))
+	state.URL = types.StringValue(page.URL)
+	if page.Parent.Type == notionapi.ParentTypeDatabaseID {
+		state.Database = NewNotionIDValue(normalizeID(string(page.Parent.DatabaseID)))
+	}
+	for _, prop := range page.Properties {
+		if tp, ok := prop.(*notionapi.TitleProperty); ok {
+			state.Title = NewRichTextStringValue(richTextToPlain(tp.Title))
+			break
+		}
+	}
+	// Imported entries have no value yet; match the schema defaults.
+	if state.DateProperty.IsNull() {
+		state.DateProperty = types.StringValue("Date")
+	}
+	if state.TagsProperty.IsNull() {
+		state.TagsProperty = types.StringValue("Tags")
+	}
+	readChangelogEntry(raw.Properties, &state, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ChangelogEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ChangelogEntryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	titlePropName, err := r.provider.titlePropertyName(ctx, plan.Database.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+	properties := changelogEntryProperties(ctx, &plan, &state, titlePropName, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	page, err := r.client.Page.Update(ctx, notionapi.PageID(plan.ID.ValueString()), &notionapi.PageUpdateRequest{
+		Properties: properties,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating changelog entry", err.Error())
+		return
+	}
+	plan.URL = types.StringValue(page.URL)
+
+	if !plan.Markdown.IsNull() && !plan.Markdown.Equal(state.Markdown) {
+		if _, err := r.mdClient.ReplacePageMarkdown(ctx, plan.ID.ValueString(), plan.Markdown.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error updating changelog entry markdown", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ChangelogEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ChangelogEntryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error trashing changelog entry", err.Error())
+		return
+	}
+	if err := trashObject(ctx, token, "pages", state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error trashing changelog entry", err.Error())
+		return
+	}
+}
+
+func (r *ChangelogEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// changelogEntryProperties builds the title, date and tags of m as page
+// properties. The date and tags properties are only sent when m or prior
+// sets them, so a changelog database doesn't need both; what prior wrote and
+// m no longer sets is cleared.
+func changelogEntryProperties(ctx context.Context, m, prior *ChangelogEntryResourceModel, titlePropName string, diags *diag.Diagnostics) notionapi.Properties {
+	props := notionapi.Properties{
+		titlePropName: notionapi.TitleProperty{
+			Type:  notionapi.PropertyTypeTitle,
+			Title: plainToRichText(m.Title.ValueString()),
+		},
+	}
+	// Clear first, so the values below overwrite a property still in use.
+	if prior != nil && !prior.Date.IsNull() {
+		props[prior.DateProperty.ValueString()] = notionapi.DateProperty{Type: notionapi.PropertyTypeDate}
+	}
+	if prior != nil && !prior.Tags.IsNull() {
+		props[prior.TagsProperty.ValueString()] = notionapi.MultiSelectProperty{
+			Type:        notionapi.PropertyTypeMultiSelect,
+			MultiSelect: []notionapi.Option{},
+		}
+	}
+
+	if !m.Date.IsNull() {
+		t, _, err := parseNotionDate(m.Date.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("date"), "Invalid Date Value", err.Error()+".")
+			return nil
+		}
+		d := notionapi.Date(t)
+		props[m.DateProperty.ValueString()] = notionapi.DateProperty{
+			Type: notionapi.PropertyTypeDate,
+			Date: &notionapi.DateObject{Start: &d},
+		}
+	}
+
+	if !m.Tags.IsNull() {
+		var tags []string
+		diags.Append(m.Tags.ElementsAs(ctx, &tags, false)...)
+		// Sets have no order; sort so the options are sent the same way
+		// every time.
+		sort.Strings(tags)
+		options := make([]notionapi.Option, len(tags))
+		for i, tag := range tags {
+			options[i] = notionapi.Option{Name: tag}
+		}
+		props[m.TagsProperty.ValueString()] = notionapi.MultiSelectProperty{
+			Type:        notionapi.PropertyTypeMultiSelect,
+			MultiSelect: options,
+		}
+	}
+	return props
+}
+
+// readChangelogEntry records the date and tags among props in m. An empty
+// date or tags property reads as null, matching an entry that doesn't set
+// them, or as an empty set when m has tags = [].
+func readChangelogEntry(props map[string]rawProperty, m *ChangelogEntryResourceModel, diags *diag.Diagnostics) {
+	m.Date = DateStringValue{StringValue: types.StringNull()}
+	if prop, ok := props[m.DateProperty.ValueString()]; ok && prop.Date != nil && prop.Date.Start != "" {
+		m.Date = NewDateStringValue(prop.Date.Start)
+	}
+
+	// tags = [] reads back as set, rather than null.
+	if m.Tags.IsNull() || len(m.Tags.Elements()) > 0 {
+		m.Tags = types.SetNull(types.StringType)
+	}
+	if prop, ok := props[m.TagsProperty.ValueString()]; ok && len(prop.MultiSelect) > 0 {
+		tags := make([]attr.Value, len(prop.MultiSelect))
+		for i, opt := range prop.MultiSelect {
+			tags[i] = types.StringValue(opt.Name)
+		}
+		set, d := types.SetValue(types.StringType, tags)
+		diags.Append(d...)
+		m.Tags = set
+	}
+}
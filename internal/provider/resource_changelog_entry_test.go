@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestChangelogEntryProperties(t *testing.T) {
+	ctx := context.Background()
+	entry := func(date string, tags ...string) *ChangelogEntryResourceModel {
+		m := &ChangelogEntryResourceModel{
+			Title:        NewRichTextStringValue("v1.4.0"),
+			Date:         DateStringValue{StringValue: types.StringNull()},
+			Tags:         types.SetNull(types.StringType),
+			DateProperty: types.StringValue("Released"),
+			TagsProperty: types.StringValue("Tags"),
+		}
+		if date != "" {
+			m.Date = NewDateStringValue(date)
+		}
+		if tags != nil {
+			vals := make([]attr.Value, len(tags))
+			for i, tag := range tags {
+				vals[i] = types.StringValue(tag)
+			}
+			m.Tags = types.SetValueMust(types.StringType, vals)
+		}
+		return m
+	}
+
+	var diags diag.Diagnostics
+	props := changelogEntryProperties(ctx, entry(""), nil, "Name", &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(props) != 1 {
+		t.Errorf("expected only the title for an entry without a date or tags, got %v", props)
+	}
+
+	props = changelogEntryProperties(ctx, entry("2026-10-15", "fix", "api"), nil, "Name", &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	tags, ok := props["Tags"].(notionapi.MultiSelectProperty)
+	if !ok || len(tags.MultiSelect) != 2 || tags.MultiSelect[0].Name != "api" || tags.MultiSelect[1].Name != "fix" {
+		t.Errorf("expected the tags sorted, got %v", props["Tags"])
+	}
+	if date, ok := props["Released"].(notionapi.DateProperty); !ok || date.Date == nil {
+		t.Errorf("expected the date in the date property, got %v", props["Released"])
+	}
+
+	// Dropping the tags clears them; a renamed date property is cleared
+	// and the date written to the new one.
+	prior := entry("2026-10-15", "fix")
+	plan := entry("2026-10-15")
+	plan.DateProperty = types.StringValue("Date")
+	props = changelogEntryProperties(ctx, plan, prior, "Name", &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if tags, ok := props["Tags"].(notionapi.MultiSelectProperty); !ok || len(tags.MultiSelect) != 0 {
+		t.Errorf("expected the tags to be cleared, got %v", props["Tags"])
+	}
+	if date, ok := props["Released"].(notionapi.DateProperty); !ok || date.Date != nil {
+		t.Errorf("expected the old date property to be cleared, got %v", props["Released"])
+	}
+	if date, ok := props["Date"].(notionapi.DateProperty); !ok || date.Date == nil {
+		t.Errorf("expected the date in the new date property, got %v", props["Date"])
+	}
+}
+
+func TestReadChangelogEntry(t *testing.T) {
+	var page rawPage
+	err := json.Unmarshal([]byte(`{
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"plain_text": "v1.4.0"}]},
+			"Date": {"id": "a", "type": "date", "date": {"start": "2026-10-15"}},
+			"Tags": {"id": "b", "type": "multi_select", "multi_select": []}
+		}
+	}`), &page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &ChangelogEntryResourceModel{
+		DateProperty: types.StringValue("Date"),
+		TagsProperty: types.StringValue("Tags"),
+		Tags:         types.SetValueMust(types.StringType, []attr.Value{types.StringValue("fix")}),
+	}
+	var diags diag.Diagnostics
+	readChangelogEntry(page.Properties, m, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !m.Date.Equal(NewDateStringValue("2026-10-15")) {
+		t.Errorf("date: got %v", m.Date)
+	}
+	if !m.Tags.IsNull() {
+		t.Errorf("expected tags cleared in Notion to read as null, got %v", m.Tags)
+	}
+
+	m.Tags = types.SetValueMust(types.StringType, []attr.Value{})
+	readChangelogEntry(page.Properties, m, &diags)
+	if m.Tags.IsNull() || len(m.Tags.Elements()) != 0 {
+		t.Errorf("expected tags = [] to stay an empty set, got %v", m.Tags)
+	}
+}
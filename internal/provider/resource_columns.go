@@ -0,0 +1,573 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                   = &ColumnsResource{}
+	_ resource.ResourceWithModifyPlan     = &ColumnsResource{}
+	_ resource.ResourceWithValidateConfig = &ColumnsResource{}
+)
+
+// ColumnsResource manages a column layout: a column_list block, its columns
+// and the content of each column. notion_block can create column_list and
+// column blocks but not their children, and Notion rejects a column_list
+// without at least two columns that each have content, so the layout is
+// created whole in a single append call.
+type ColumnsResource struct {
+	client *notionapi.Client
+}
+
+type ColumnsResourceModel struct {
+	ID       types.String  `tfsdk:"id"`
+	ParentID NotionIDValue `tfsdk:"parent_id"`
+	After    types.String  `tfsdk:"after"`
+	Columns  []ColumnModel `tfsdk:"columns"`
+}
+
+// ColumnModel is one element of notion_columns.columns.
+type ColumnModel struct {
+	ID         types.String         `tfsdk:"id"`
+	WidthRatio types.Float64        `tfsdk:"width_ratio"`
+	Blocks     []BlockListItemModel `tfsdk:"blocks"`
+	BlockIDs   types.List           `tfsdk:"block_ids"`
+}
+
+// widthRatioTolerance is how far the configured width ratios may sum from 1.
+const widthRatioTolerance = 0.001
+
+func NewColumnsResource() resource.Resource {
+	return &ColumnsResource{}
+}
+
+func (r *ColumnsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_columns"
+}
+
+func (r *ColumnsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a column layout: a column_list block with its columns and the content of each column.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the column_list block.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "The ID of the page or block the layout is created under.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"after": schema.StringAttribute{
+				Description: "Insert the layout after the specified block ID. If omitted, appends to the end.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"columns": schema.ListNestedAttribute{
+				Description: "The columns, left to right. At least two are required. Adding, removing or reordering columns, " +
+					"or changing the shape of a column's blocks, replaces the layout; other changes are made in place.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the column block.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"width_ratio": schema.Float64Attribute{
+							Description: "The share of the layout's width this column takes, between 0 and 1. " +
+								"Set it on every column or on none; the ratios must add up to 1. If omitted, the columns are equally wide.",
+							Optional: true,
+						},
+						"blocks": schema.ListNestedAttribute{
+							Description: "The content of the column, in order. Must not be empty.",
+							Required:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: blockListItemAttributes(),
+							},
+						},
+						"block_ids": schema.ListAttribute{
+							Description: "The IDs of the column's content blocks, in the same order as blocks.",
+							Computed:    true,
+							ElementType: types.StringType,
+							PlanModifiers: []planmodifier.List{
+								listplanmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the column count, each column's content, and the
+// width ratios.
+func (r *ColumnsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var columns types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("columns"), &columns)...)
+	if resp.Diagnostics.HasError() || columns.IsNull() || columns.IsUnknown() {
+		return
+	}
+	if len(columns.Elements()) < 2 {
+		resp.Diagnostics.AddAttributeError(path.Root("columns"), "Too Few Columns",
+			"A column layout must have at least two columns.")
+		return
+	}
+
+	var widths []types.Float64
+	for i, elem := range columns.Elements() {
+		column, ok := elem.(types.Object)
+		if !ok || column.IsUnknown() {
+			return
+		}
+		at := path.Root("columns").AtListIndex(i)
+		if width, ok := column.Attributes()["width_ratio"].(types.Float64); ok {
+			widths = append(widths, width)
+		}
+
+		blocks, ok := column.Attributes()["blocks"].(types.List)
+		if !ok || blocks.IsNull() || blocks.IsUnknown() {
+			continue
+		}
+		if n := len(blocks.Elements()); n == 0 || n > maxAppendChildren {
+			resp.Diagnostics.AddAttributeError(at.AtName("blocks"), "Invalid Column Content",
+				fmt.Sprintf("Each column must have between 1 and %d blocks, got %d.", maxAppendChildren, n))
+			continue
+		}
+		var items []BlockListItemModel
+		resp.Diagnostics.Append(blocks.ElementsAs(ctx, &items, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for j, item := range items {
+			itemPath := at.AtName("blocks").AtListIndex(j)
+			switch item.Type.ValueString() {
+			case "column_list", "column":
+				resp.Diagnostics.AddAttributeError(itemPath.AtName("type"), "Nested Column Layout",
+					"Columns can't contain column_list or column blocks.")
+				continue
+			}
+			model := item.toBlockModel(NewNotionIDNull())
+			resp.Diagnostics.Append(blockTypeAttributeDiagnostics(&model, itemPath)...)
+		}
+	}
+
+	resp.Diagnostics.Append(columnWidthDiagnostics(widths)...)
+}
+
+// columnWidthDiagnostics checks that widths are set on every column or on
+// none, that each is between 0 and 1, and that they add up to 1.
+func columnWidthDiagnostics(widths []types.Float64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	set, sum := 0, 0.0
+	for i, width := range widths {
+		if width.IsUnknown() {
+			return diags
+		}
+		if width.IsNull() {
+			continue
+		}
+		set++
+		sum += width.ValueFloat64()
+		if v := width.ValueFloat64(); v <= 0 || v >= 1 {
+			diags.AddAttributeError(path.Root("columns").AtListIndex(i).AtName("width_ratio"), "Invalid Width Ratio",
+				fmt.Sprintf("width_ratio must be between 0 and 1, got %g.", v))
+		}
+	}
+	if set == 0 || diags.HasError() {
+		return diags
+	}
+	if set != len(widths) {
+		diags.AddAttributeError(path.Root("columns"), "Incomplete Width Ratios",
+			"width_ratio must be set on every column or on none.")
+		return diags
+	}
+	if math.Abs(sum-1) > widthRatioTolerance {
+		diags.AddAttributeError(path.Root("columns"), "Invalid Width Ratios",
+			fmt.Sprintf("The width_ratio values must add up to 1, got %g.", sum))
+	}
+	return diags
+}
+
+func (r *ColumnsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+// ModifyPlan replaces the layout when the number of columns changes or a
+// column's content changes shape, as notion_blocks does for its list.
+func (r *ColumnsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ColumnsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if columnsNeedReplace(state.Columns, plan.Columns) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("columns"))
+	}
+}
+
+// columnsNeedReplace reports whether going from prior to next can't be done
+// by updating the existing columns and their blocks in place.
+func columnsNeedReplace(prior, next []ColumnModel) bool {
+	if len(prior) != len(next) {
+		return true
+	}
+	for i := range next {
+		if blockListNeedsReplace(prior[i].Blocks, next[i].Blocks) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnWidths returns the width each column takes: the configured ratios,
+// or an equal share each when none is configured.
+func columnWidths(columns []ColumnModel) []float64 {
+	widths := make([]float64, len(columns))
+	for i, column := range columns {
+		if column.WidthRatio.IsNull() || column.WidthRatio.IsUnknown() {
+			for j := range widths {
+				widths[j] = 1 / float64(len(columns))
+			}
+			return widths
+		}
+		widths[i] = column.WidthRatio.ValueFloat64()
+	}
+	return widths
+}
+
+// columnBlock is a column block with the width_ratio that the SDK's
+// ColumnBlock doesn't carry.
+type columnBlock struct {
+	notionapi.BasicBlock
+	Column columnBlockContent `json:"column"`
+}
+
+type columnBlockContent struct {
+	Children   notionapi.Blocks `json:"children,omitempty"`
+	WidthRatio *float64         `json:"width_ratio,omitempty"`
+}
+
+// columnListChild is the part of a column block the provider reads back.
+type columnListChild struct {
+	ID       string `json:"id"`
+	Archived bool   `json:"archived"`
+	Column   struct {
+		WidthRatio *float64 `json:"width_ratio"`
+	} `json:"column"`
+}
+
+func (r *ColumnsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ColumnsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	columns := make([]notionapi.Block, len(plan.Columns))
+	for i, column := range plan.Columns {
+		children, diags := buildBlockList(column.Blocks, plan.ParentID, path.Root("columns").AtListIndex(i).AtName("blocks"))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		content := columnBlockContent{Children: children}
+		if !column.WidthRatio.IsNull() && !column.WidthRatio.IsUnknown() {
+			width := column.WidthRatio.ValueFloat64()
+			content.WidthRatio = &width
+		}
+		columns[i] = &columnBlock{
+			BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeColumn},
+			Column:     content,
+		}
+	}
+
+	var after notionapi.BlockID
+	if !plan.After.IsNull() && !plan.After.IsUnknown() {
+		after = notionapi.BlockID(plan.After.ValueString())
+	}
+
+	result, err := r.client.Block.AppendChildren(ctx, notionapi.BlockID(plan.ParentID.ValueNotionID()), &notionapi.AppendBlockChildrenRequest{
+		After: after,
+		Children: []notionapi.Block{&notionapi.ColumnListBlock{
+			BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeColumnList},
+			ColumnList: notionapi.ColumnList{Children: columns},
+		}},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating column layout", err.Error())
+		return
+	}
+	if len(result.Results) != 1 {
+		resp.Diagnostics.AddError("Error creating column layout",
+			fmt.Sprintf("Notion API returned %d blocks for 1 appended", len(result.Results)))
+		return
+	}
+	plan.ID = types.StringValue(normalizeID(string(result.Results[0].GetID())))
+
+	// The layout exists from here on, so it is saved to state even when
+	// reading it back fails; the next apply replaces it.
+	resp.Diagnostics.Append(r.readLayout(ctx, &plan, true)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// readLayout refreshes the columns of model and their content. created
+// means the layout was just created and every child belongs to it, in
+// order; otherwise columns and blocks are matched by their tracked IDs, and
+// ones that no longer exist drop out of the model.
+func (r *ColumnsResource) readLayout(ctx context.Context, model *ColumnsResourceModel, created bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if created {
+		for i := range model.Columns {
+			model.Columns[i].ID = types.StringNull()
+			model.Columns[i].BlockIDs = types.ListNull(types.StringType)
+		}
+	}
+
+	children, err := listColumns(ctx, r.client, model.ID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading columns", err.Error())
+		return diags
+	}
+	if created && len(children) != len(model.Columns) {
+		diags.AddError("Error creating column layout",
+			fmt.Sprintf("Notion API returned %d columns for %d appended", len(children), len(model.Columns)))
+		return diags
+	}
+	byID := make(map[string]columnListChild, len(children))
+	for i, child := range children {
+		id := normalizeID(child.ID)
+		byID[id] = child
+		if created {
+			model.Columns[i].ID = types.StringValue(id)
+		}
+	}
+
+	var kept []ColumnModel
+	for _, column := range model.Columns {
+		child, ok := byID[column.ID.ValueString()]
+		if !ok || child.Archived {
+			continue
+		}
+		// Only ratios set in configuration are refreshed; Notion reports
+		// one for columns resized in the UI even when none was configured.
+		if !column.WidthRatio.IsNull() && child.Column.WidthRatio != nil {
+			column.WidthRatio = types.Float64Value(*child.Column.WidthRatio)
+		}
+
+		ordered, err := listChildBlocks(ctx, r.client, column.ID.ValueString())
+		if err != nil {
+			diags.AddError("Error reading column content", err.Error())
+			return diags
+		}
+		var ids []string
+		if created {
+			if len(ordered) != len(column.Blocks) {
+				diags.AddError("Error creating column layout",
+					fmt.Sprintf("Notion API returned %d blocks for %d appended", len(ordered), len(column.Blocks)))
+				return diags
+			}
+			for _, block := range ordered {
+				ids = append(ids, normalizeID(string(block.GetID())))
+			}
+		} else if !column.BlockIDs.IsNull() && !column.BlockIDs.IsUnknown() {
+			diags.Append(column.BlockIDs.ElementsAs(ctx, &ids, false)...)
+		}
+
+		keptIDs, blocks := refreshBlockList(ordered, ids, column.Blocks, NewNotionIDValue(column.ID.ValueString()))
+		if blocks == nil {
+			blocks = []BlockListItemModel{}
+		}
+		idList, d := types.ListValueFrom(ctx, types.StringType, keptIDs)
+		diags.Append(d...)
+		column.Blocks = blocks
+		column.BlockIDs = idList
+		kept = append(kept, column)
+	}
+	if kept == nil {
+		kept = []ColumnModel{}
+	}
+	model.Columns = kept
+	return diags
+}
+
+// listColumns returns the columns of a column_list block, in order. It is a
+// raw call because the SDK doesn't decode width_ratio.
+func listColumns(ctx context.Context, client *notionapi.Client, columnListID string) ([]columnListChild, error) {
+	token, err := tokenForClient(client)
+	if err != nil {
+		return nil, err
+	}
+	var columns []columnListChild
+	cursor := ""
+	for {
+		url := fmt.Sprintf("%s/blocks/%s/children?page_size=100", notionAPIBaseURL, columnListID)
+		if cursor != "" {
+			url += "&start_cursor=" + cursor
+		}
+		respBody, err := notionAPICall(ctx, http.MethodGet, url, token, notionSDKAPIVersion, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Results    []columnListChild `json:"results"`
+			HasMore    bool              `json:"has_more"`
+			NextCursor string            `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(respBody, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		columns = append(columns, page.Results...)
+		if !page.HasMore {
+			return columns, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// setColumnWidth sets the width_ratio of a column block.
+func setColumnWidth(ctx context.Context, client *notionapi.Client, columnID string, width float64) error {
+	token, err := tokenForClient(client)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]any{
+		"column": map[string]any{"width_ratio": width},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = notionAPICall(ctx, http.MethodPatch, notionAPIBaseURL+"/blocks/"+columnID, token, notionSDKAPIVersion, body)
+	return err
+}
+
+func (r *ColumnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ColumnsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	columnList, err := r.client.Block.Get(ctx, notionapi.BlockID(state.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading column layout", err.Error())
+		return
+	}
+	if columnList.GetArchived() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Columns or blocks deleted outside Terraform drop out of the lists,
+	// which plans a replacement.
+	resp.Diagnostics.Append(r.readLayout(ctx, &state, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ColumnsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ColumnsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(state.Columns) != len(plan.Columns) {
+		// ModifyPlan replaces the resource in this case.
+		resp.Diagnostics.AddError("Error updating column layout",
+			fmt.Sprintf("State tracks %d columns but the plan has %d. Please report this to the provider developers.", len(state.Columns), len(plan.Columns)))
+		return
+	}
+
+	priorWidths, nextWidths := columnWidths(state.Columns), columnWidths(plan.Columns)
+	for i := range plan.Columns {
+		prior, next := state.Columns[i], &plan.Columns[i]
+		at := path.Root("columns").AtListIndex(i)
+
+		var ids []string
+		resp.Diagnostics.Append(prior.BlockIDs.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(ids) != len(next.Blocks) {
+			resp.Diagnostics.AddError("Error updating column layout",
+				fmt.Sprintf("State tracks %d blocks in column %d but the plan has %d. Please report this to the provider developers.", len(ids), i, len(next.Blocks)))
+			return
+		}
+
+		column := NewNotionIDValue(prior.ID.ValueString())
+		resp.Diagnostics.Append(updateBlockList(ctx, r.client, ids, prior.Blocks, next.Blocks, column, at.AtName("blocks"))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if math.Abs(priorWidths[i]-nextWidths[i]) > widthRatioTolerance/10 {
+			if err := setColumnWidth(ctx, r.client, prior.ID.ValueString(), nextWidths[i]); err != nil {
+				resp.Diagnostics.AddAttributeError(at.AtName("width_ratio"), "Error updating column width", err.Error())
+				return
+			}
+		}
+		next.ID = prior.ID
+		next.BlockIDs = prior.BlockIDs
+	}
+	plan.ID = state.ID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ColumnsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ColumnsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Archiving the column_list archives its columns and their content.
+	if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(state.ID.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Error deleting column layout", err.Error())
+	}
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccColumnsResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccColumnsConfig(parentPageID, "Left column", 0.6),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("notion_columns.test", "id"),
+					resource.TestCheckResourceAttrSet("notion_columns.test", "columns.0.id"),
+					resource.TestCheckResourceAttr("notion_columns.test", "columns.0.block_ids.#", "2"),
+					resource.TestCheckResourceAttr("notion_columns.test", "columns.1.block_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccColumnsConfig(parentPageID, "Left column, revised", 0.7),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_columns.test", "columns.0.blocks.1.rich_text", "Left column, revised"),
+					resource.TestCheckResourceAttr("notion_columns.test", "columns.0.width_ratio", "0.7"),
+				),
+			},
+		},
+	})
+}
+
+func testAccColumnsConfig(parentPageID, text string, leftWidth float64) string {
+	return fmt.Sprintf(`
+resource "notion_page" "test" {
+  parent_page_id = %[1]q
+  title          = "TF Acc Columns"
+}
+
+resource "notion_columns" "test" {
+  parent_id = notion_page.test.id
+  columns = [
+    {
+      width_ratio = %[3]g
+      blocks = [
+        { type = "heading_3", rich_text = "Left" },
+        { type = "paragraph", rich_text = %[2]q },
+      ]
+    },
+    {
+      width_ratio = 1 - %[3]g
+      blocks = [
+        { type = "paragraph", rich_text = "Right column" },
+      ]
+    },
+  ]
+}
+`, parentPageID, text, leftWidth)
+}
+
+func TestColumnWidthDiagnostics(t *testing.T) {
+	tests := []struct {
+		name    string
+		widths  []types.Float64
+		wantErr bool
+	}{
+		{"none set", []types.Float64{types.Float64Null(), types.Float64Null()}, false},
+		{"all set", []types.Float64{types.Float64Value(0.25), types.Float64Value(0.75)}, false},
+		{"thirds", []types.Float64{types.Float64Value(0.333), types.Float64Value(0.333), types.Float64Value(0.334)}, false},
+		{"unknown", []types.Float64{types.Float64Unknown(), types.Float64Value(2)}, false},
+		{"partly set", []types.Float64{types.Float64Value(0.5), types.Float64Null()}, true},
+		{"wrong sum", []types.Float64{types.Float64Value(0.5), types.Float64Value(0.6)}, true},
+		{"out of range", []types.Float64{types.Float64Value(1), types.Float64Value(0)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := columnWidthDiagnostics(tt.widths).HasError(); got != tt.wantErr {
+				t.Errorf("columnWidthDiagnostics() error = %v, want %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	equal := columnWidths([]ColumnModel{
+		{WidthRatio: types.Float64Null()},
+		{WidthRatio: types.Float64Null()},
+		{WidthRatio: types.Float64Null()},
+		{WidthRatio: types.Float64Null()},
+	})
+	if !reflect.DeepEqual(equal, []float64{0.25, 0.25, 0.25, 0.25}) {
+		t.Errorf("columnWidths() = %v, want equal quarters", equal)
+	}
+
+	set := columnWidths([]ColumnModel{
+		{WidthRatio: types.Float64Value(0.7)},
+		{WidthRatio: types.Float64Value(0.3)},
+	})
+	if !reflect.DeepEqual(set, []float64{0.7, 0.3}) {
+		t.Errorf("columnWidths() = %v, want [0.7 0.3]", set)
+	}
+}
+
+func TestColumnRawCalls(t *testing.T) {
+	prev := notionHTTPClient
+	t.Cleanup(func() { notionHTTPClient = prev })
+
+	var requests []string
+	var patched string
+	token := "ntn_first"
+	notionHTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.RequestURI())
+			if got := req.Header.Get("Authorization"); got != "Bearer "+token {
+				t.Errorf("%s: got Authorization %q, want the current token %s", req.URL.Path, got, token)
+			}
+			body := `{}`
+			switch {
+			case req.Method == http.MethodPatch:
+				b, _ := io.ReadAll(req.Body)
+				patched = string(b)
+			case req.URL.Query().Get("start_cursor") == "":
+				body = `{"results":[{"id":"c1","column":{"width_ratio":0.25}},{"id":"c2","archived":true,"column":{}}],` +
+					`"has_more":true,"next_cursor":"next"}`
+			default:
+				body = `{"results":[{"id":"c3","column":{"width_ratio":0.75}}],"has_more":false}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	// The calls read the token file as they go, so a rotated token is
+	// picked up between them.
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte(token), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source, err := fileTokenSource(tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	client := notionapi.NewClient("ntn_first")
+	registerClientTokenSource(client, source)
+
+	columns, err := listColumns(ctx, client, "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got []string
+	for _, c := range columns {
+		width := "unset"
+		if c.Column.WidthRatio != nil {
+			width = fmt.Sprint(*c.Column.WidthRatio)
+		}
+		got = append(got, fmt.Sprintf("%s:%t:%s", c.ID, c.Archived, width))
+	}
+	if want := []string{"c1:false:0.25", "c2:true:unset", "c3:false:0.75"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got columns %v, want %v", got, want)
+	}
+
+	token = "ntn_rotated"
+	if err := os.WriteFile(tokenPath, []byte(token), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(tokenPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := setColumnWidth(ctx, client, "c1", 0.4); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `{"column":{"width_ratio":0.4}}`; patched != want {
+		t.Errorf("got PATCH body %s, want %s", patched, want)
+	}
+
+	want := []string{
+		"GET /v1/blocks/list/children?page_size=100",
+		"GET /v1/blocks/list/children?page_size=100&start_cursor=next",
+		"PATCH /v1/blocks/c1",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("got requests %v, want %v", requests, want)
+	}
+}
@@ -2,13 +2,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -18,39 +23,32 @@ import (
 var (
 	_ resource.Resource                = &DatabaseResource{}
 	_ resource.ResourceWithImportState = &DatabaseResource{}
+	_ resource.ResourceWithModifyPlan  = &DatabaseResource{}
 )
 
 type DatabaseResource struct {
-	client *notionapi.Client
+	client   *notionapi.Client
+	provider *providerData
 }
 
 type DatabaseResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Parent           types.String `tfsdk:"parent"`
-	Title            types.String `tfsdk:"title"`
-	TitleColumnTitle types.String `tfsdk:"title_column_title"`
-	TitleColumnID    types.String `tfsdk:"title_column_id"`
-	URL              types.String `tfsdk:"url"`
-	IsInline         types.Bool   `tfsdk:"is_inline"`
-	Description      types.String `tfsdk:"description"`
-	Icon             types.String `tfsdk:"icon"`
-}
-
-// titlePropertyConfigWithName wraps the Notion title property config with a
-// "name" field for renaming. The SDK's TitlePropertyConfig lacks this field.
-type titlePropertyConfigWithName struct {
-	ID    notionapi.PropertyID         `json:"id,omitempty"`
-	Type  notionapi.PropertyConfigType `json:"type"`
-	Title struct{}                     `json:"title"`
-	Name  string                       `json:"name"`
-}
-
-func (p titlePropertyConfigWithName) GetType() notionapi.PropertyConfigType {
-	return p.Type
-}
-
-func (p titlePropertyConfigWithName) GetID() notionapi.PropertyID {
-	return p.ID
+	ID                       types.String  `tfsdk:"id"`
+	Parent                   NotionIDValue `tfsdk:"parent"`
+	ParentType               types.String  `tfsdk:"parent_type"`
+	Title                    types.String  `tfsdk:"title"`
+	TitleColumnTitle         types.String  `tfsdk:"title_column_title"`
+	TitleColumnID            types.String  `tfsdk:"title_column_id"`
+	URL                      types.String  `tfsdk:"url"`
+	IsInline                 types.Bool    `tfsdk:"is_inline"`
+	Description              types.String  `tfsdk:"description"`
+	Icon                     types.String  `tfsdk:"icon"`
+	PropertyOrder            types.List    `tfsdk:"property_order"`
+	PropertyIDs              types.Map     `tfsdk:"property_ids"`
+	Archived                 types.Bool    `tfsdk:"archived"`
+	InTrash                  types.Bool    `tfsdk:"in_trash"`
+	InitialEntries           types.List    `tfsdk:"initial_entries"`
+	InitialEntryIDs          types.List    `tfsdk:"initial_entry_ids"`
+	PreventDestroyIfNonempty types.Bool    `tfsdk:"prevent_destroy_if_nonempty"`
 }
 
 func NewDatabaseResource() resource.Resource {
@@ -73,10 +71,16 @@ func (r *DatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"parent": schema.StringAttribute{
-				Description: "The ID of the parent page.",
-				Required:    true,
+				Description: "The ID of the parent page. Changing it moves the database, keeping its rows. " +
+					"Leave unset only for an imported database at the top level of the workspace.",
+				CustomType: NotionIDType{},
+				Optional:   true,
+			},
+			"parent_type": schema.StringAttribute{
+				Description: "The kind of parent Notion reports for the database, such as page_id, block_id or workspace.",
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"title": schema.StringAttribute{
@@ -102,13 +106,11 @@ func (r *DatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"is_inline": schema.BoolAttribute{
-				Description: "Whether the database appears inline on the parent page. If false, it appears as a child page.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
-				},
+				Description: "Whether the database appears inline on the parent page. If false, it appears as a child page. " +
+					"Can be changed in place.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the database (read-only, set in Notion UI).",
@@ -118,12 +120,75 @@ func (r *DatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"icon": schema.StringAttribute{
-				Description: "Emoji icon of the database (read-only, set in Notion UI).",
+				Description: "Icon of the database: an emoji or an external image URL (read-only, set in Notion UI).",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"property_order": schema.ListAttribute{
+				Description: "Names of the database's properties in the order the Notion API lists them. The API doesn't " +
+					"let integrations reorder properties; arrange a view's columns with notion_view's configuration.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"property_ids": schema.MapAttribute{
+				Description: "Map of the database's property names to their IDs. IDs don't change when a property is " +
+					"renamed, so they make stable keys, e.g. for notion_database_entry's properties_by_id.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"archived": schema.BoolAttribute{
+				Description: "Whether the database is archived. An archived database is removed from state on refresh.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"in_trash": schema.BoolAttribute{
+				Description: "Whether the database is in the trash, including through a trashed ancestor page.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"initial_entries": schema.ListNestedAttribute{
+				Description: "Rows to create in the database when it is created, in order, e.g. to bootstrap a status " +
+					"board. They are written once and changes made afterwards are ignored. Rows setting properties " +
+					"the new database doesn't have yet, such as those added by notion_database_property, are " +
+					"written on the first apply after the properties exist.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							Description: "The value of the title column.",
+							Required:    true,
+						},
+						"properties": schema.MapAttribute{
+							Description: "Map of property names to values, converted according to the property's type " +
+								"as in notion_database_entry's sensitive_properties.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"prevent_destroy_if_nonempty": schema.BoolAttribute{
+				Description: "Set to true to make destroying the database fail while it has entries. Defaults to false, " +
+					"which allows destroy and trashes the entries with the database. To destroy a guarded database on " +
+					"purpose, set it back to false and apply first.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"initial_entry_ids": schema.ListAttribute{
+				Description: "The IDs of the rows created from initial_entries, in order. Null until they are written.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -132,13 +197,48 @@ func (r *DatabaseResource) Configure(_ context.Context, req resource.ConfigureRe
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planParent(ctx, req, resp, "parent", "database")
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Pending initial entries are written by the first update after the
+	// database has their properties.
+	pending, diags := pendingInitialEntries(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if !pending || resp.Diagnostics.HasError() {
+		return
+	}
+	var plan, state DatabaseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.InitialEntries.IsUnknown() {
+		return
+	}
+	entries, diags := initialEntries(ctx, plan.InitialEntries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	db, err := getDatabaseSchema(ctx, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+	if len(missingInitialEntryProperties(entries, db.Properties)) == 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("initial_entry_ids"), types.ListUnknown(types.StringType))...)
+	}
 }
 
 func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -151,7 +251,7 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 	params := &notionapi.DatabaseCreateRequest{
 		Parent: notionapi.Parent{
 			Type:   notionapi.ParentTypePageID,
-			PageID: notionapi.PageID(plan.Parent.ValueString()),
+			PageID: notionapi.PageID(plan.Parent.ValueNotionID()),
 		},
 		Title: plainToRichText(plan.Title.ValueString()),
 		Properties: notionapi.PropertyConfigs{
@@ -173,18 +273,23 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 	plan.URL = types.StringValue(db.URL)
 	plan.IsInline = types.BoolValue(db.IsInline)
 	plan.Description = types.StringValue(richTextToPlain(db.Description))
-	if db.Icon != nil && db.Icon.Emoji != nil {
-		plan.Icon = types.StringValue(string(*db.Icon.Emoji))
-	} else {
-		plan.Icon = types.StringValue("")
-	}
+	plan.Icon = iconToState(db.Icon, plan.Icon)
 
 	for name, prop := range db.Properties {
 		if name == plan.TitleColumnTitle.ValueString() {
 			plan.TitleColumnID = types.StringValue(string(prop.GetID()))
+			r.provider.rememberTitlePropertyName(plan.ID.ValueString(), name)
 			break
 		}
 	}
+	// A new database has only its title column.
+	plan.PropertyOrder = types.ListValueMust(types.StringType, []attr.Value{plan.TitleColumnTitle})
+	plan.PropertyIDs = propertyIDsMap(db.Properties, &resp.Diagnostics)
+	plan.Archived = types.BoolValue(db.Archived)
+	plan.InTrash = types.BoolValue(false)
+
+	// The database is saved even if its initial entries fail.
+	resp.Diagnostics.Append(r.writeInitialEntries(ctx, &plan, resp.Private)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -196,11 +301,12 @@ func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.ID.ValueString()))
+	full, err := getFullDatabaseSchema(ctx, r.client, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
+	db := &full.Database
 
 	if db.Archived {
 		resp.State.RemoveResource(ctx)
@@ -212,20 +318,28 @@ func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 	state.URL = types.StringValue(db.URL)
 	state.IsInline = types.BoolValue(db.IsInline)
 	state.Description = types.StringValue(richTextToPlain(db.Description))
-	if db.Icon != nil && db.Icon.Emoji != nil {
-		state.Icon = types.StringValue(string(*db.Icon.Emoji))
-	} else {
-		state.Icon = types.StringValue("")
+	state.Icon = iconToState(db.Icon, state.Icon)
+	state.PropertyOrder = propertyOrderList(ctx, full.PropertyOrder, &resp.Diagnostics)
+	state.PropertyIDs = propertyIDsMap(db.Properties, &resp.Diagnostics)
+	state.Archived = types.BoolValue(db.Archived)
+	state.InTrash = types.BoolValue(full.InTrash)
+	if state.PreventDestroyIfNonempty.IsNull() {
+		state.PreventDestroyIfNonempty = types.BoolValue(false)
 	}
 
-	if db.Parent.Type == notionapi.ParentTypePageID {
-		state.Parent = types.StringValue(normalizeID(string(db.Parent.PageID)))
+	state.ParentType = types.StringValue(string(db.Parent.Type))
+	switch db.Parent.Type {
+	case notionapi.ParentTypePageID:
+		state.Parent = NewNotionIDValue(normalizeID(string(db.Parent.PageID)))
+	case notionapi.ParentTypeWorkspace:
+		state.Parent = NewNotionIDNull()
 	}
 
 	for name, prop := range db.Properties {
 		if prop.GetType() == notionapi.PropertyConfigTypeTitle {
 			state.TitleColumnTitle = types.StringValue(name)
 			state.TitleColumnID = types.StringValue(string(prop.GetID()))
+			r.provider.rememberTitlePropertyName(state.ID.ValueString(), name)
 			break
 		}
 	}
@@ -242,23 +356,51 @@ func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	params := &notionapi.DatabaseUpdateRequest{
-		Title: plainToRichText(plan.Title.ValueString()),
+	// Move first so the rest of the update lands on the database at its new
+	// location.
+	if plan.Parent.ValueNotionID() != state.Parent.ValueNotionID() {
+		token, err := tokenForClient(r.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Error moving database", err.Error())
+			return
+		}
+		if err := moveDatabase(ctx, token, plan.ID.ValueString(), plan.Parent.ValueNotionID()); err != nil {
+			resp.Diagnostics.AddError("Error moving database", err.Error())
+			return
+		}
 	}
 
-	// If the title column name changed, rename it via the Notion API.
-	// We send the current (state) name as the key with a "name" field set to
-	// the desired (plan) name. The SDK's TitlePropertyConfig doesn't support
-	// the "name" field, so we use titlePropertyConfigWithName.
+	// The SDK's update request has no is_inline and its property configs no
+	// "name" field, so those changes go in a raw request. The title column is
+	// keyed by its property ID.
+	raw := map[string]interface{}{}
 	if plan.TitleColumnTitle.ValueString() != state.TitleColumnTitle.ValueString() {
-		params.Properties = notionapi.PropertyConfigs{
-			state.TitleColumnTitle.ValueString(): titlePropertyConfigWithName{
-				Type:  notionapi.PropertyConfigTypeTitle,
-				Title: struct{}{},
-				Name:  plan.TitleColumnTitle.ValueString(),
-			},
+		key := state.TitleColumnID.ValueString()
+		if key == "" {
+			key = state.TitleColumnTitle.ValueString()
+		}
+		raw["properties"] = map[string]interface{}{
+			key: map[string]string{"name": plan.TitleColumnTitle.ValueString()},
 		}
 	}
+	if !plan.IsInline.Equal(state.IsInline) {
+		raw["is_inline"] = plan.IsInline.ValueBool()
+	}
+	if len(raw) > 0 {
+		token, err := tokenForClient(r.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating database", err.Error())
+			return
+		}
+		if err := patchDatabase(ctx, token, plan.ID.ValueString(), raw); err != nil {
+			resp.Diagnostics.AddError("Error updating database", err.Error())
+			return
+		}
+	}
+
+	params := &notionapi.DatabaseUpdateRequest{
+		Title: plainToRichText(plan.Title.ValueString()),
+	}
 
 	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.ID.ValueString()), params)
 	if err != nil {
@@ -269,23 +411,64 @@ func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateReques
 	plan.URL = types.StringValue(db.URL)
 	plan.IsInline = types.BoolValue(db.IsInline)
 	plan.Description = types.StringValue(richTextToPlain(db.Description))
-	if db.Icon != nil && db.Icon.Emoji != nil {
-		plan.Icon = types.StringValue(string(*db.Icon.Emoji))
-	} else {
-		plan.Icon = types.StringValue("")
-	}
+	plan.Icon = iconToState(db.Icon, plan.Icon)
 
 	for name, prop := range db.Properties {
 		if prop.GetType() == notionapi.PropertyConfigTypeTitle {
 			plan.TitleColumnTitle = types.StringValue(name)
 			plan.TitleColumnID = types.StringValue(string(prop.GetID()))
+			r.provider.rememberTitlePropertyName(plan.ID.ValueString(), name)
 			break
 		}
 	}
 
+	// The SDK decodes the update response's properties into a map, losing
+	// their order, so read it back.
+	full, err := getFullDatabaseSchema(ctx, r.client, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+	plan.PropertyOrder = propertyOrderList(ctx, full.PropertyOrder, &resp.Diagnostics)
+	plan.PropertyIDs = propertyIDsMap(full.Properties, &resp.Diagnostics)
+	plan.Archived = types.BoolValue(full.Archived)
+	plan.InTrash = types.BoolValue(full.InTrash)
+
+	if plan.InitialEntryIDs.IsUnknown() {
+		resp.Diagnostics.Append(r.writeInitialEntries(ctx, &plan, resp.Private)...)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// patchDatabase sends a raw database update.
+func patchDatabase(ctx context.Context, token, databaseID string, payload map[string]interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = notionAPICall(ctx, http.MethodPatch, notionAPIBaseURL+"/databases/"+databaseID, token, notionSDKAPIVersion, body)
+	return err
+}
+
+// propertyOrderList converts property names to a property_order value.
+func propertyOrderList(ctx context.Context, order []string, diags *diag.Diagnostics) types.List {
+	list, d := types.ListValueFrom(ctx, types.StringType, order)
+	diags.Append(d...)
+	return list
+}
+
+// propertyIDsMap converts a database's properties to a property_ids value.
+func propertyIDsMap(props notionapi.PropertyConfigs, diags *diag.Diagnostics) types.Map {
+	ids := make(map[string]attr.Value, len(props))
+	for name, prop := range props {
+		ids[name] = types.StringValue(string(prop.GetID()))
+	}
+	m, d := types.MapValue(types.StringType, ids)
+	diags.Append(d...)
+	return m
+}
+
 func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state DatabaseResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -293,6 +476,21 @@ func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if state.PreventDestroyIfNonempty.ValueBool() {
+		nonempty, err := databaseHasEntries(ctx, r.client, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error checking for database entries", err.Error())
+			return
+		}
+		if nonempty {
+			resp.Diagnostics.AddError("Database has entries",
+				fmt.Sprintf("The database %q has entries, which destroying it would move to the trash, and "+
+					"prevent_destroy_if_nonempty is set. Set it to false and apply before destroying the database.",
+					state.Title.ValueString()))
+			return
+		}
+	}
+
 	token, err := tokenForClient(r.client)
 	if err != nil {
 		resp.Diagnostics.AddError("Error trashing database", err.Error())
@@ -304,6 +502,30 @@ func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// databaseHasEntries reports whether databaseID has a live row. It reads
+// one row at a time and stops at the first live one.
+func databaseHasEntries(ctx context.Context, client *notionapi.Client, databaseID string) (bool, error) {
+	var cursor notionapi.Cursor
+	for {
+		res, err := client.Database.Query(ctx, notionapi.DatabaseID(databaseID), &notionapi.DatabaseQueryRequest{
+			StartCursor: cursor,
+			PageSize:    1,
+		})
+		if err != nil {
+			return false, err
+		}
+		for i := range res.Results {
+			if !res.Results[i].Archived {
+				return true, nil
+			}
+		}
+		if !res.HasMore {
+			return false, nil
+		}
+		cursor = res.NextCursor
+	}
+}
+
 func (r *DatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
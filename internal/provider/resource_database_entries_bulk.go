@@ -0,0 +1,665 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource               = &DatabaseEntriesBulkResource{}
+	_ resource.ResourceWithModifyPlan = &DatabaseEntriesBulkResource{}
+)
+
+// bulkEntryConcurrency bounds the row writes in flight at once, for the same
+// rate-limit reason as databaseQueryConcurrency.
+const bulkEntryConcurrency = databaseQueryConcurrency
+
+// DatabaseEntriesBulkResource manages a set of rows in one database from a
+// single map, keyed by the value of a rich text "key" property. Hundreds of
+// notion_database_entry resources each cost a database lookup and a page
+// read per refresh and a write per apply; this resource refreshes every row
+// with one paginated query and only writes the rows whose configuration
+// changed.
+type DatabaseEntriesBulkResource struct {
+	client   *notionapi.Client
+	provider *providerData
+}
+
+type DatabaseEntriesBulkResourceModel struct {
+	ID          types.String                 `tfsdk:"id"`
+	Database    NotionIDValue                `tfsdk:"database"`
+	KeyProperty types.String                 `tfsdk:"key_property"`
+	Rows        map[string]BulkEntryRowModel `tfsdk:"rows"`
+	EntryIDs    types.Map                    `tfsdk:"entry_ids"`
+}
+
+// BulkEntryRowModel is one row of notion_database_entries_bulk. Its
+// attributes mean the same as the notion_database_entry attributes of the
+// same name.
+type BulkEntryRowModel struct {
+	Title                 RichTextStringValue `tfsdk:"title"`
+	RichTextProperties    types.Map           `tfsdk:"rich_text_properties"`
+	NumberProperties      types.Map           `tfsdk:"number_properties"`
+	CheckboxProperties    types.Map           `tfsdk:"checkbox_properties"`
+	SelectProperties      types.Map           `tfsdk:"select_properties"`
+	MultiSelectProperties types.Map           `tfsdk:"multi_select_properties"`
+	StatusProperties      types.Map           `tfsdk:"status_properties"`
+	URLProperties         types.Map           `tfsdk:"url_properties"`
+	EmailProperties       types.Map           `tfsdk:"email_properties"`
+	PhoneNumberProperties types.Map           `tfsdk:"phone_number_properties"`
+	DateProperties        types.Map           `tfsdk:"date_properties"`
+}
+
+// toEntryModel returns the row as a notion_database_entry model, so the
+// entry property builders and readers can be reused.
+func (m BulkEntryRowModel) toEntryModel(database NotionIDValue, id string) DatabaseEntryResourceModel {
+	return DatabaseEntryResourceModel{
+		ID:                    types.StringValue(id),
+		Database:              database,
+		Title:                 m.Title,
+		URL:                   types.StringNull(),
+		Markdown:              types.StringNull(),
+		RichTextProperties:    m.RichTextProperties,
+		NumberProperties:      m.NumberProperties,
+		CheckboxProperties:    m.CheckboxProperties,
+		SelectProperties:      m.SelectProperties,
+		MultiSelectProperties: m.MultiSelectProperties,
+		StatusProperties:      m.StatusProperties,
+		URLProperties:         m.URLProperties,
+		EmailProperties:       m.EmailProperties,
+		PhoneNumberProperties: m.PhoneNumberProperties,
+		DateProperties:        m.DateProperties,
+	}
+}
+
+// bulkEntryRowFromModel is the inverse of toEntryModel.
+func bulkEntryRowFromModel(e DatabaseEntryResourceModel) BulkEntryRowModel {
+	return BulkEntryRowModel{
+		Title:                 e.Title,
+		RichTextProperties:    e.RichTextProperties,
+		NumberProperties:      e.NumberProperties,
+		CheckboxProperties:    e.CheckboxProperties,
+		SelectProperties:      e.SelectProperties,
+		MultiSelectProperties: e.MultiSelectProperties,
+		StatusProperties:      e.StatusProperties,
+		URLProperties:         e.URLProperties,
+		EmailProperties:       e.EmailProperties,
+		PhoneNumberProperties: e.PhoneNumberProperties,
+		DateProperties:        e.DateProperties,
+	}
+}
+
+// equal reports whether two rows configure the same content.
+func (m BulkEntryRowModel) equal(o BulkEntryRowModel) bool {
+	return m.Title.Equal(o.Title) &&
+		m.RichTextProperties.Equal(o.RichTextProperties) &&
+		m.NumberProperties.Equal(o.NumberProperties) &&
+		m.CheckboxProperties.Equal(o.CheckboxProperties) &&
+		m.SelectProperties.Equal(o.SelectProperties) &&
+		m.MultiSelectProperties.Equal(o.MultiSelectProperties) &&
+		m.StatusProperties.Equal(o.StatusProperties) &&
+		m.URLProperties.Equal(o.URLProperties) &&
+		m.EmailProperties.Equal(o.EmailProperties) &&
+		m.PhoneNumberProperties.Equal(o.PhoneNumberProperties) &&
+		m.DateProperties.Equal(o.DateProperties)
+}
+
+func NewDatabaseEntriesBulkResource() resource.Resource {
+	return &DatabaseEntriesBulkResource{}
+}
+
+func (r *DatabaseEntriesBulkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_entries_bulk"
+}
+
+func (r *DatabaseEntriesBulkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	rowAttributes := entryPropertyAttributes()
+	rowAttributes["title"] = schema.StringAttribute{
+		Description: "The title of the entry. Supports the same inline markdown as rich_text_properties and is compared semantically.",
+		CustomType:  RichTextStringType{},
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of entries in a Notion database from a single map, matched to existing rows by a key property.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the database.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "The ID of the database the entries belong to.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"key_property": schema.StringAttribute{
+				Description: "The name of a rich text property that identifies each row. Every row's map key is written " +
+					"to it, and existing rows with a matching key are adopted instead of duplicated.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rows": schema.MapNestedAttribute{
+				Description: "The entries, keyed by the value of the key property.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: rowAttributes,
+				},
+			},
+			"entry_ids": schema.MapAttribute{
+				Description: "The page ID of each entry, keyed like rows.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *DatabaseEntriesBulkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.provider = data
+}
+
+// ModifyPlan keeps entry_ids known when no rows are added or removed, so
+// an edit to a row's properties doesn't show every ID as changing.
+func (r *DatabaseEntriesBulkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state DatabaseEntriesBulkResourceModel
+	// Rows that aren't known until apply can't be read into Go values or
+	// compared, so entry_ids is left unknown.
+	var rows types.Map
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rows"), &rows)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !req.State.Raw.IsNull() && !req.Plan.Raw.IsNull() && mapElementsKnown(rows) {
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		toCreate, _, toArchive := diffBulkRows(state.Rows, plan.Rows)
+		if len(toCreate) == 0 && len(toArchive) == 0 {
+			plan.EntryIDs = state.EntryIDs
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+	}
+
+	logAPICallEstimate(ctx, r.client, "notion_database_entries_bulk", req, resp, func(action string) int {
+		// Planned rows that aren't known yet can't be read, and count as
+		// none.
+		if !req.Plan.Raw.IsNull() {
+			req.Plan.Get(ctx, &plan)
+		}
+		if !req.State.Raw.IsNull() {
+			req.State.Get(ctx, &state)
+		}
+		return bulkEntriesCallEstimate(action, state.Rows, plan.Rows)
+	})
+}
+
+// mapElementsKnown reports whether m and each of its elements are known, so
+// it can be read into a Go map.
+func mapElementsKnown(m types.Map) bool {
+	if m.IsUnknown() {
+		return false
+	}
+	for _, elem := range m.Elements() {
+		if elem.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// diffBulkRows compares the prior rows with the planned ones and returns,
+// in sorted order, the keys to create, the keys whose content changed, and
+// the keys to archive.
+func diffBulkRows(prior, next map[string]BulkEntryRowModel) (toCreate, toUpdate, toArchive []string) {
+	for key, row := range next {
+		priorRow, ok := prior[key]
+		switch {
+		case !ok:
+			toCreate = append(toCreate, key)
+		case !priorRow.equal(row):
+			toUpdate = append(toUpdate, key)
+		}
+	}
+	for key := range prior {
+		if _, ok := next[key]; !ok {
+			toArchive = append(toArchive, key)
+		}
+	}
+	sort.Strings(toCreate)
+	sort.Strings(toUpdate)
+	sort.Strings(toArchive)
+	return toCreate, toUpdate, toArchive
+}
+
+func (r *DatabaseEntriesBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DatabaseEntriesBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.Database.ValueNotionID())
+	state := DatabaseEntriesBulkResourceModel{
+		ID:          plan.ID,
+		Database:    plan.Database,
+		KeyProperty: plan.KeyProperty,
+		Rows:        map[string]BulkEntryRowModel{},
+	}
+	resp.Diagnostics.Append(r.apply(ctx, &plan, map[string]string{}, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DatabaseEntriesBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DatabaseEntriesBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database entries", err.Error())
+		return
+	}
+	remote, err := queryEntriesByKey(ctx, token, state.Database.ValueNotionID(), state.KeyProperty.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database entries", err.Error())
+		return
+	}
+
+	// Rows whose entry was deleted, or whose key was edited, in Notion drop
+	// out of state, so the next apply writes them again.
+	rows := make(map[string]BulkEntryRowModel, len(state.Rows))
+	ids := make(map[string]string, len(state.Rows))
+	for key, row := range state.Rows {
+		page, ok := remote[key]
+		if !ok {
+			continue
+		}
+		entry := row.toEntryModel(state.Database, normalizeID(string(page.ID)))
+		for _, prop := range page.Properties {
+			if tp, ok := prop.(*notionapi.TitleProperty); ok {
+				entry.Title = NewRichTextStringValue(richTextToPlain(tp.Title))
+				break
+			}
+		}
+		readEntryProperties(page, &entry, &resp.Diagnostics)
+		rows[key] = bulkEntryRowFromModel(entry)
+		ids[key] = entry.ID.ValueString()
+	}
+	state.Rows = rows
+	entryIDs, diags := entryIDsValue(ids)
+	resp.Diagnostics.Append(diags...)
+	state.EntryIDs = entryIDs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DatabaseEntriesBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DatabaseEntriesBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state DatabaseEntriesBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := map[string]string{}
+	if !state.EntryIDs.IsNull() && !state.EntryIDs.IsUnknown() {
+		resp.Diagnostics.Append(state.EntryIDs.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan, ids, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// apply brings the database in line with plan. ids maps the keys of
+// state.Rows to their entry IDs. Rows are written concurrently, and state
+// is updated with every write that succeeded, so a partial failure leaves
+// state describing what actually exists.
+func (r *DatabaseEntriesBulkResource) apply(ctx context.Context, plan *DatabaseEntriesBulkResourceModel, ids map[string]string, state *DatabaseEntriesBulkResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		diags.AddError("Error updating database entries", err.Error())
+		return diags
+	}
+
+	databaseID := plan.Database.ValueNotionID()
+	keyProperty := plan.KeyProperty.ValueString()
+	titlePropName, err := r.checkKeyProperty(ctx, databaseID, keyProperty)
+	if err != nil {
+		diags.AddError("Invalid Key Property", err.Error())
+		return diags
+	}
+
+	toCreate, toUpdate, toArchive := diffBulkRows(state.Rows, plan.Rows)
+
+	// A new key may already have a row in the database, e.g. one created
+	// by hand or by an earlier run whose state was lost: adopt it.
+	if len(toCreate) > 0 {
+		remote, err := queryEntriesByKey(ctx, token, databaseID, keyProperty)
+		if err != nil {
+			diags.AddError("Error reading database entries", err.Error())
+			return diags
+		}
+		var adopted []string
+		var created []string
+		for _, key := range toCreate {
+			if page, ok := remote[key]; ok {
+				ids[key] = normalizeID(string(page.ID))
+				adopted = append(adopted, key)
+			} else {
+				created = append(created, key)
+			}
+		}
+		toCreate = created
+		toUpdate = append(toUpdate, adopted...)
+	}
+
+	type write struct {
+		key     string
+		archive bool
+	}
+	writes := make([]write, 0, len(toCreate)+len(toUpdate)+len(toArchive))
+	for _, key := range toCreate {
+		writes = append(writes, write{key: key})
+	}
+	for _, key := range toUpdate {
+		writes = append(writes, write{key: key})
+	}
+	for _, key := range toArchive {
+		writes = append(writes, write{key: key, archive: true})
+	}
+
+	var mu sync.Mutex
+	forEachConcurrently(len(writes), bulkEntryConcurrency, func(i int) {
+		w := writes[i]
+
+		mu.Lock()
+		id := ids[w.key]
+		prior, hasPrior := state.Rows[w.key]
+		mu.Unlock()
+
+		if w.archive {
+			err := trashObject(ctx, token, "pages", id)
+			mu.Lock()
+			defer mu.Unlock()
+			// A row already deleted or trashed outside Terraform is gone
+			// all the same.
+			if err != nil && !isGoneError(err) {
+				diags.AddError("Error archiving database entry", fmt.Sprintf("Row %q: %s", w.key, err))
+				return
+			}
+			delete(state.Rows, w.key)
+			delete(ids, w.key)
+			return
+		}
+
+		row := plan.Rows[w.key]
+		var priorEntry *DatabaseEntryResourceModel
+		if hasPrior {
+			e := prior.toEntryModel(plan.Database, id)
+			priorEntry = &e
+		}
+		newID, rowDiags := r.writeRow(ctx, plan, w.key, row, id, titlePropName, priorEntry)
+		mu.Lock()
+		defer mu.Unlock()
+		diags.Append(rowDiags...)
+		if rowDiags.HasError() {
+			return
+		}
+		state.Rows[w.key] = row
+		ids[w.key] = newID
+	})
+
+	entryIDs, d := entryIDsValue(ids)
+	diags.Append(d...)
+	state.EntryIDs = entryIDs
+	return diags
+}
+
+// checkKeyProperty checks that keyProperty is a rich text property of the
+// database and returns the name of its title property.
+func (r *DatabaseEntriesBulkResource) checkKeyProperty(ctx context.Context, databaseID, keyProperty string) (string, error) {
+	db, err := getDatabaseSchema(ctx, r.client, databaseID)
+	if err != nil {
+		return "", err
+	}
+	prop, ok := db.Properties[keyProperty]
+	if !ok {
+		return "", fmt.Errorf("database %s has no property named %q", databaseID, keyProperty)
+	}
+	if prop.GetType() != notionapi.PropertyConfigTypeRichText {
+		return "", fmt.Errorf("key property %q is a %s property; it must be a rich_text property", keyProperty, prop.GetType())
+	}
+
+	titlePropName := "Name"
+	for name, p := range db.Properties {
+		if p.GetType() == notionapi.PropertyConfigTypeTitle {
+			titlePropName = name
+			break
+		}
+	}
+	r.provider.rememberTitlePropertyName(databaseID, titlePropName)
+	return titlePropName, nil
+}
+
+// writeRow creates the row when id is empty and otherwise updates entry id,
+// clearing properties that prior managed and row no longer sets. It returns
+// the entry's ID.
+func (r *DatabaseEntriesBulkResource) writeRow(ctx context.Context, plan *DatabaseEntriesBulkResourceModel, key string, row BulkEntryRowModel, id, titlePropName string, prior *DatabaseEntryResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	entry := row.toEntryModel(plan.Database, id)
+	properties := buildEntryProperties(ctx, &entry, &diags)
+	if diags.HasError() {
+		return "", diags
+	}
+	if prior != nil {
+		clearRemovedProperties(prior, &entry, properties)
+	}
+	properties[titlePropName] = notionapi.TitleProperty{
+		Type:  notionapi.PropertyTypeTitle,
+		Title: plainToRichText(row.Title.ValueString()),
+	}
+	properties[plan.KeyProperty.ValueString()] = notionapi.RichTextProperty{
+		Type:     notionapi.PropertyTypeRichText,
+		RichText: plainToRichText(key),
+	}
+
+	if id == "" {
+		page, err := r.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+			Parent: notionapi.Parent{
+				Type:       notionapi.ParentTypeDatabaseID,
+				DatabaseID: notionapi.DatabaseID(plan.Database.ValueNotionID()),
+			},
+			Properties: properties,
+		})
+		if err != nil {
+			diags.AddError("Error creating database entry", fmt.Sprintf("Row %q: %s", key, err))
+			return "", diags
+		}
+		return normalizeID(string(page.ID)), diags
+	}
+
+	_, err := r.client.Page.Update(ctx, notionapi.PageID(id), &notionapi.PageUpdateRequest{
+		Properties: properties,
+	})
+	if err != nil {
+		diags.AddError("Error updating database entry", fmt.Sprintf("Row %q: %s", key, err))
+		return "", diags
+	}
+	return id, diags
+}
+
+// forEachConcurrently calls fn for 0..n-1, running at most limit calls at
+// once, and returns when all have finished.
+func forEachConcurrently(n, limit int, fn func(i int)) {
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// entryIDsValue returns ids, entry IDs by row key, as a map value.
+func entryIDsValue(ids map[string]string) (types.Map, diag.Diagnostics) {
+	vals := make(map[string]attr.Value, len(ids))
+	for key, id := range ids {
+		vals[key] = types.StringValue(id)
+	}
+	return types.MapValue(types.StringType, vals)
+}
+
+func (r *DatabaseEntriesBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DatabaseEntriesBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error trashing database entries", err.Error())
+		return
+	}
+
+	var ids map[string]string
+	resp.Diagnostics.Append(state.EntryIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys := make([]string, 0, len(ids))
+	for key := range ids {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := trashObject(ctx, token, "pages", ids[key]); err != nil && !isGoneError(err) {
+			resp.Diagnostics.AddError("Error trashing database entry", fmt.Sprintf("Row %q: %s", key, err))
+		}
+	}
+}
+
+// entriesQueryPage is one page of a raw database query whose results are
+// decoded individually with decodePageLenient.
+type entriesQueryPage struct {
+	Results    []json.RawMessage `json:"results"`
+	HasMore    bool              `json:"has_more"`
+	NextCursor string            `json:"next_cursor"`
+}
+
+// queryEntriesByKey returns the database's entries that have a value for
+// keyProperty, a title or rich text property, keyed by that value. When
+// several entries share a key the oldest one wins.
+func queryEntriesByKey(ctx context.Context, token, databaseID, keyProperty string) (map[string]*notionapi.Page, error) {
+	reqURL := fmt.Sprintf("%s/databases/%s/query", notionAPIBaseURL, databaseID)
+	body := map[string]interface{}{
+		"page_size": 100,
+		"filter": map[string]interface{}{
+			"property":  keyProperty,
+			"rich_text": map[string]bool{"is_not_empty": true},
+		},
+		"sorts": []map[string]string{{"timestamp": "created_time", "direction": "ascending"}},
+	}
+
+	entries := map[string]*notionapi.Page{}
+	for {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		respBody, err := notionAPICall(ctx, http.MethodPost, reqURL, token, notionSDKAPIVersion, bodyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query database: %w", err)
+		}
+		var result entriesQueryPage
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		for _, raw := range result.Results {
+			page, err := decodePageLenient(raw)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := textPropertyValue(page.Properties[keyProperty])
+			if !ok {
+				continue
+			}
+			if _, seen := entries[key]; !seen {
+				entries[key] = page
+			}
+		}
+
+		if !result.HasMore || result.NextCursor == "" {
+			return entries, nil
+		}
+		body["start_cursor"] = result.NextCursor
+	}
+}
+
+// textPropertyValue returns the plain text of a title or rich text property.
+func textPropertyValue(prop notionapi.Property) (string, bool) {
+	switch p := prop.(type) {
+	case *notionapi.TitleProperty:
+		return richTextToPlain(p.Title), true
+	case *notionapi.RichTextProperty:
+		return richTextToPlain(p.RichText), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccDatabaseEntriesBulkResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseEntriesBulkConfig(parentPageID, `
+    se = { title = "Sweden" }
+    no = { title = "Norway" }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database_entries_bulk.test", "entry_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("notion_database_entries_bulk.test", "entry_ids.se"),
+					resource.TestCheckResourceAttr("notion_database_entries_bulk.test", "rows.no.title", "Norway"),
+				),
+			},
+			{
+				Config: testAccDatabaseEntriesBulkConfig(parentPageID, `
+    se = { title = "Sweden" }
+    fi = { title = "Finland" }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database_entries_bulk.test", "entry_ids.%", "2"),
+					resource.TestCheckNoResourceAttr("notion_database_entries_bulk.test", "entry_ids.no"),
+					resource.TestCheckResourceAttr("notion_database_entries_bulk.test", "rows.fi.title", "Finland"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabaseEntriesBulkConfig(parentPageID, rows string) string {
+	return fmt.Sprintf(`
+resource "notion_database" "test_bulk_parent" {
+  parent             = %q
+  title              = "Bulk Entries Test DB"
+  title_column_title = "Name"
+}
+
+resource "notion_database_property_rich_text" "code" {
+  database = notion_database.test_bulk_parent.id
+  name     = "Code"
+}
+
+resource "notion_database_entries_bulk" "test" {
+  database     = notion_database.test_bulk_parent.id
+  key_property = notion_database_property_rich_text.code.name
+  rows = {
+%s  }
+}
+`, parentPageID, rows)
+}
+
+func TestDiffBulkRows(t *testing.T) {
+	row := func(title string) BulkEntryRowModel {
+		nullMap := types.MapNull(types.StringType)
+		return BulkEntryRowModel{
+			Title:                 NewRichTextStringValue(title),
+			RichTextProperties:    types.MapNull(RichTextStringType{}),
+			NumberProperties:      types.MapNull(types.Float64Type),
+			CheckboxProperties:    types.MapNull(types.BoolType),
+			SelectProperties:      nullMap,
+			MultiSelectProperties: types.MapNull(types.SetType{ElemType: types.StringType}),
+			StatusProperties:      nullMap,
+			URLProperties:         nullMap,
+			EmailProperties:       nullMap,
+			PhoneNumberProperties: nullMap,
+			DateProperties:        types.MapNull(DateStringType{}),
+		}
+	}
+	prior := map[string]BulkEntryRowModel{
+		"se": row("Sweden"),
+		"no": row("Norway"),
+		"dk": row("Denmark"),
+	}
+	next := map[string]BulkEntryRowModel{
+		"se": row("Sweden"),
+		"no": row("Norge"),
+		"fi": row("Finland"),
+		"is": row("Iceland"),
+	}
+
+	toCreate, toUpdate, toArchive := diffBulkRows(prior, next)
+	if want := []string{"fi", "is"}; !reflect.DeepEqual(toCreate, want) {
+		t.Errorf("toCreate = %v, want %v", toCreate, want)
+	}
+	if want := []string{"no"}; !reflect.DeepEqual(toUpdate, want) {
+		t.Errorf("toUpdate = %v, want %v", toUpdate, want)
+	}
+	if want := []string{"dk"}; !reflect.DeepEqual(toArchive, want) {
+		t.Errorf("toArchive = %v, want %v", toArchive, want)
+	}
+}
+
+func TestDatabaseEntriesBulkResourceModifyPlanUnknownRows(t *testing.T) {
+	ctx := context.Background()
+	r := &DatabaseEntriesBulkResource{}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	entryIDs, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{})
+	diags := state.Set(ctx, &DatabaseEntriesBulkResourceModel{
+		ID:          types.StringValue("db"),
+		Database:    NewNotionIDValue("db"),
+		KeyProperty: types.StringValue("Code"),
+		Rows:        map[string]BulkEntryRowModel{},
+		EntryIDs:    entryIDs,
+	})
+	if diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	// The rows come from another resource's attribute, so they aren't known
+	// until apply.
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["id"] = tftypes.NewValue(tftypes.String, "db")
+	values["database"] = tftypes.NewValue(tftypes.String, "db")
+	values["key_property"] = tftypes.NewValue(tftypes.String, "Code")
+	values["rows"] = tftypes.NewValue(objType.AttributeTypes["rows"], tftypes.UnknownValue)
+	values["entry_ids"] = tftypes.NewValue(objType.AttributeTypes["entry_ids"], tftypes.UnknownValue)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, values)}
+
+	resp := fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{State: state, Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var ids types.Map
+	resp.Plan.GetAttribute(ctx, fwpath.Root("entry_ids"), &ids)
+	if !ids.IsUnknown() {
+		t.Errorf("got entry_ids %v, want unknown while the rows are", ids)
+	}
+}
+
+func TestDatabaseEntriesBulkResourceDeleteIgnoresGoneRows(t *testing.T) {
+	prev := notionHTTPClient
+	t.Cleanup(func() { notionHTTPClient = prev })
+
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		// Deleted outside Terraform.
+		"p1": {http.StatusNotFound, `{"object":"error","status":404,"code":"object_not_found","message":"Could not find page"}`},
+		// Trashed outside Terraform.
+		"p2": {http.StatusBadRequest, `{"object":"error","status":400,"code":"validation_error","message":"Can't edit block that is archived. You must unarchive the block before editing."}`},
+		"p3": {http.StatusOK, `{"object":"page","id":"p3","in_trash":true}`},
+	}
+	var trashed []string
+	notionHTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			id := path.Base(req.URL.Path)
+			trashed = append(trashed, id)
+			return &http.Response{
+				StatusCode: responses[id].status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(responses[id].body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	ctx := context.Background()
+	client := notionapi.NewClient("test-token")
+	registerClientToken(client, "test-token")
+	r := &DatabaseEntriesBulkResource{client: client}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	entryIDs, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"a": "p1", "b": "p2", "c": "p3"})
+	diags := state.Set(ctx, &DatabaseEntriesBulkResourceModel{
+		ID:          types.StringValue("db"),
+		Database:    NewNotionIDValue("db"),
+		KeyProperty: types.StringValue("Code"),
+		EntryIDs:    entryIDs,
+	})
+	if diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	var resp fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if strings.Join(trashed, ",") != "p1,p2,p3" {
+		t.Errorf("trashed %v, want p1, p2 and p3", trashed)
+	}
+}
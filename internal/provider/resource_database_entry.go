@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -10,37 +11,61 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jomei/notionapi"
 )
 
 var (
-	_ resource.Resource                = &DatabaseEntryResource{}
-	_ resource.ResourceWithImportState = &DatabaseEntryResource{}
+	_ resource.Resource                   = &DatabaseEntryResource{}
+	_ resource.ResourceWithImportState    = &DatabaseEntryResource{}
+	_ resource.ResourceWithUpgradeState   = &DatabaseEntryResource{}
+	_ resource.ResourceWithValidateConfig = &DatabaseEntryResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabaseEntryResource{}
 )
 
 type DatabaseEntryResource struct {
 	client   *notionapi.Client
 	mdClient *markdownClient
+	provider *providerData
 }
 
 type DatabaseEntryResourceModel struct {
-	ID                    types.String `tfsdk:"id"`
-	Database              types.String `tfsdk:"database"`
-	Title                 types.String `tfsdk:"title"`
-	URL                   types.String `tfsdk:"url"`
-	Markdown              types.String `tfsdk:"markdown"`
-	RichTextProperties    types.Map    `tfsdk:"rich_text_properties"`
-	NumberProperties      types.Map    `tfsdk:"number_properties"`
-	CheckboxProperties    types.Map    `tfsdk:"checkbox_properties"`
-	SelectProperties      types.Map    `tfsdk:"select_properties"`
-	StatusProperties      types.Map    `tfsdk:"status_properties"`
-	URLProperties         types.Map    `tfsdk:"url_properties"`
-	EmailProperties       types.Map    `tfsdk:"email_properties"`
-	PhoneNumberProperties types.Map    `tfsdk:"phone_number_properties"`
-	DateProperties        types.Map    `tfsdk:"date_properties"`
+	ID                    types.String        `tfsdk:"id"`
+	Database              NotionIDValue       `tfsdk:"database"`
+	Title                 RichTextStringValue `tfsdk:"title"`
+	TitleProperty         types.Map           `tfsdk:"title_property"`
+	URL                   types.String        `tfsdk:"url"`
+	PublicURL             types.String        `tfsdk:"public_url"`
+	Archived              types.Bool          `tfsdk:"archived"`
+	InTrash               types.Bool          `tfsdk:"in_trash"`
+	Markdown              types.String        `tfsdk:"markdown"`
+	RichTextProperties    types.Map           `tfsdk:"rich_text_properties"`
+	NumberProperties      types.Map           `tfsdk:"number_properties"`
+	CheckboxProperties    types.Map           `tfsdk:"checkbox_properties"`
+	SelectProperties      types.Map           `tfsdk:"select_properties"`
+	MultiSelectProperties types.Map           `tfsdk:"multi_select_properties"`
+	StatusProperties      types.Map           `tfsdk:"status_properties"`
+	URLProperties         types.Map           `tfsdk:"url_properties"`
+	EmailProperties       types.Map           `tfsdk:"email_properties"`
+	PhoneNumberProperties types.Map           `tfsdk:"phone_number_properties"`
+	DateProperties        types.Map           `tfsdk:"date_properties"`
+	SensitiveProperties   types.Map           `tfsdk:"sensitive_properties"`
+	MatchOn               types.String        `tfsdk:"match_on"`
+	IdempotencyProperty   types.String        `tfsdk:"idempotency_property"`
+	IdempotencyKey        types.String        `tfsdk:"idempotency_key"`
+	PropertiesByID        types.Bool          `tfsdk:"properties_by_id"`
+	DetectExternal        types.Bool          `tfsdk:"detect_external_properties"`
+	CreateMissingOptions  types.Bool          `tfsdk:"create_missing_options"`
+	NewOptionColor        types.String        `tfsdk:"new_option_color"`
+	OnRemove              types.String        `tfsdk:"on_remove"`
+	IgnoreChanges         types.List          `tfsdk:"ignore_changes_properties"`
+	AllProperties         types.Map           `tfsdk:"all_properties"`
+	People                types.Map           `tfsdk:"people"`
 }
 
 func NewDatabaseEntryResource() resource.Resource {
@@ -53,6 +78,7 @@ func (r *DatabaseEntryResource) Metadata(_ context.Context, req resource.Metadat
 
 func (r *DatabaseEntryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion(databaseEntryStateMigrations),
 		Description: "Manages an entry (page) in a Notion database.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -64,14 +90,25 @@ func (r *DatabaseEntryResource) Schema(_ context.Context, _ resource.SchemaReque
 			},
 			"database": schema.StringAttribute{
 				Description: "The ID of the parent database.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"title": schema.StringAttribute{
-				Description: "The title of the entry.",
-				Required:    true,
+				Description: "The title of the entry. Supports inline markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and `code`. " +
+					"Compared semantically, so Notion's re-serialization of the title does not produce a diff. " +
+					"Exactly one of title and title_property must be set; when title_property is used this mirrors its value.",
+				CustomType: RichTextStringType{},
+				Optional:   true,
+				Computed:   true,
+			},
+			"title_property": schema.MapAttribute{
+				Description: "The title as a single-element map of the database's title property name to its value, for " +
+					"configurations that treat the title like any other property. An alternative to title.",
+				Optional:    true,
+				ElementType: RichTextStringType{},
 			},
 			"url": schema.StringAttribute{
 				Description: "The URL of the entry.",
@@ -80,86 +117,356 @@ func (r *DatabaseEntryResource) Schema(_ context.Context, _ resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"markdown": schema.StringAttribute{
-				Description: "Entry page body content as enhanced markdown. " +
-					"Note: Notion may normalize the markdown content, so the stored value may differ slightly from what was submitted.",
+			"public_url": schema.StringAttribute{
+				Description: "The public URL of the entry when it is published to the web, either directly or through a published parent, and empty otherwise.",
+				Computed:    true,
+			},
+			"archived": schema.BoolAttribute{
+				Description: "Whether the entry is archived. An archived entry is removed from state on refresh.",
+				Computed:    true,
+			},
+			"in_trash": schema.BoolAttribute{
+				Description: "Whether the entry is in the trash, including through a trashed database or ancestor page.",
+				Computed:    true,
+			},
+			"match_on": schema.StringAttribute{
+				Description: "When creating, look for a live row in the database whose key matches this entry and adopt it " +
+					"instead of creating a duplicate. Either \"title\" or the name of a rich text property set in rich_text_properties " +
+					"(e.g. an external ID). If several rows match, the oldest is adopted.",
 				Optional: true,
 			},
-			"rich_text_properties": schema.MapAttribute{
-				Description: "Map of rich text property name to string value.",
-				Optional:    true,
-				ElementType: types.StringType,
+			"idempotency_property": schema.StringAttribute{
+				Description: "The name of a rich text property that create writes idempotency_key to. Before creating, a live " +
+					"row that already has the key, such as one created by an apply that was interrupted before it saved state, " +
+					"is adopted instead of duplicated; without idempotency_key, such a row is an error instead. Must not be " +
+					"set in rich_text_properties.",
+				Optional: true,
 			},
-			"number_properties": schema.MapAttribute{
-				Description: "Map of number property name to numeric value.",
-				Optional:    true,
-				ElementType: types.Float64Type,
+			"idempotency_key": schema.StringAttribute{
+				Description: "The key written to idempotency_property. Set it to a value unique to the entry, e.g. each.key, " +
+					"for rows to be adopted. Defaults to a hash of the database, title and property values the entry is created " +
+					"with, which entries with the same content share, so a row with it is reported rather than adopted. Only " +
+					"used on create.",
+				Optional: true,
 			},
-			"checkbox_properties": schema.MapAttribute{
-				Description: "Map of checkbox property name to boolean value.",
-				Optional:    true,
-				ElementType: types.BoolType,
+			"properties_by_id": schema.BoolAttribute{
+				Description: "Key the typed property maps and ignore_changes_properties by property ID instead of by name, so " +
+					"renaming a property in Notion doesn't break the configuration. match_on may then also be an ID. See " +
+					"notion_database's property_ids.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"select_properties": schema.MapAttribute{
-				Description: "Map of select property name to option name.",
-				Optional:    true,
-				ElementType: types.StringType,
+			"detect_external_properties": schema.BoolAttribute{
+				Description: "Warn, when the entry is refreshed, about properties Terraform doesn't manage whose values " +
+					"were changed in Notion since the last refresh.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"status_properties": schema.MapAttribute{
-				Description: "Map of status property name to status name.",
-				Optional:    true,
-				ElementType: types.StringType,
+			"create_missing_options": schema.BoolAttribute{
+				Description: "Add select and multi-select values that aren't options of their properties to the database schema, " +
+					"with new_option_color, before the entry is written. Without it such values fail the plan. " +
+					"Status options can't be added through the API, so unknown status values always fail the plan.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"url_properties": schema.MapAttribute{
-				Description: "Map of URL property name to URL value.",
+			"new_option_color": schema.StringAttribute{
+				Description: "Color of the options added by create_missing_options. Defaults to \"default\".",
 				Optional:    true,
-				ElementType: types.StringType,
+				Computed:    true,
+				Default:     stringdefault.StaticString("default"),
+				Validators: []validator.String{
+					ColorValidator(),
+				},
+			},
+			"on_remove": schema.StringAttribute{
+				Description: "What happens to a property whose key is dropped from the typed property maps: \"clear\" empties it " +
+					"in Notion (numbers become 0 and checkboxes false), \"ignore\" leaves its current value and stops managing it.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("clear"),
+				Validators: []validator.String{
+					OnRemoveValidator(),
+				},
 			},
-			"email_properties": schema.MapAttribute{
-				Description: "Map of email property name to email value.",
+			"ignore_changes_properties": schema.ListAttribute{
+				Description: "Names of properties that are set when the entry is created and then left alone, such as a status " +
+					"people move through a workflow. Changes made in Notion are not reported as drift, and later changes to " +
+					"their configured values, or their removal, are not sent to Notion.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
-			"phone_number_properties": schema.MapAttribute{
-				Description: "Map of phone number property name to phone number value.",
+			"sensitive_properties": schema.MapAttribute{
+				Description: "Map of property name to value for properties whose values must not show in plan output or logs, " +
+					"such as salary bands or customer contacts. Values are converted according to the property's type: rich " +
+					"text (written as plain text), number, checkbox (\"true\" or \"false\"), select, status, URL, email, phone " +
+					"number or date. The properties are left out of all_properties. A property must not also be set in a " +
+					"typed property map.",
 				Optional:    true,
+				Sensitive:   true,
 				ElementType: types.StringType,
 			},
-			"date_properties": schema.MapAttribute{
-				Description: "Map of date property name to ISO 8601 date string.",
-				Optional:    true,
+			"all_properties": schema.MapAttribute{
+				Description: "Every property of the entry, managed or not, rendered as a string the same way as the " +
+					"properties of the notion_database_entries data source. Includes computed values such as formulas, " +
+					"rollups and unique IDs.",
+				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"people": schema.MapAttribute{
+				Description: "The users of every people property of the entry, by property name, as objects with the " +
+					"user's id and name.",
+				Computed:    true,
+				ElementType: peoplePropertyType,
+			},
+			"markdown": schema.StringAttribute{
+				Description: "Entry page body content as enhanced markdown. " +
+					"Note: Notion may normalize the markdown content, so the stored value may differ slightly from what was submitted.",
+				Optional: true,
+			},
 		},
 	}
+	for name, attribute := range entryPropertyAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// entryPropertyAttributes returns the typed property map attributes shared
+// by notion_database_entry and the rows of notion_database_entries_bulk.
+func entryPropertyAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"rich_text_properties": schema.MapAttribute{
+			Description: "Map of rich text property name to string value. Supports inline markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and `code`. " +
+				"Values are compared semantically, so Notion's re-serialization of runs and whitespace does not produce a diff.",
+			Optional:    true,
+			ElementType: RichTextStringType{},
+		},
+		"number_properties": schema.MapAttribute{
+			Description: "Map of number property name to numeric value.",
+			Optional:    true,
+			ElementType: types.Float64Type,
+		},
+		"checkbox_properties": schema.MapAttribute{
+			Description: "Map of checkbox property name to boolean value.",
+			Optional:    true,
+			ElementType: types.BoolType,
+		},
+		"select_properties": schema.MapAttribute{
+			Description: "Map of select property name to option name.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"multi_select_properties": schema.MapAttribute{
+			Description: "Map of multi-select property name to the set of option names.",
+			Optional:    true,
+			ElementType: types.SetType{ElemType: types.StringType},
+		},
+		"status_properties": schema.MapAttribute{
+			Description: "Map of status property name to status name.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"url_properties": schema.MapAttribute{
+			Description: "Map of URL property name to URL value. Values must be absolute http(s) URLs.",
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.Map{
+				HTTPURLMapValidator(),
+			},
+		},
+		"email_properties": schema.MapAttribute{
+			Description: "Map of email property name to email value.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"phone_number_properties": schema.MapAttribute{
+			Description: "Map of phone number property name to phone number value.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"date_properties": schema.MapAttribute{
+			Description: "Map of date property name to ISO 8601 date string. " +
+				"Date-only and datetime values that describe the same date or instant are treated as equal.",
+			Optional:    true,
+			ElementType: DateStringType{},
+			Validators: []validator.Map{
+				NotionDateMapValidator(),
+			},
+		},
+	}
+}
+
+func (r *DatabaseEntryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DatabaseEntryResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(entryTitleDiagnostics(config)...)
+
+	if !config.SensitiveProperties.IsNull() && !config.SensitiveProperties.IsUnknown() {
+		for _, m := range entryPropertyMaps(&config) {
+			if m.IsUnknown() {
+				continue
+			}
+			for key := range m.Elements() {
+				if _, ok := config.SensitiveProperties.Elements()[key]; ok {
+					resp.Diagnostics.AddAttributeError(path.Root("sensitive_properties"), "Conflicting Sensitive Property",
+						fmt.Sprintf("%q is also set in a typed property map, where its value would be shown in plans.", key))
+				}
+			}
+		}
+	}
+
+	if prop := config.IdempotencyProperty; !prop.IsNull() && !prop.IsUnknown() && !config.RichTextProperties.IsUnknown() {
+		if _, ok := config.RichTextProperties.Elements()[prop.ValueString()]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("idempotency_property"), "Conflicting Idempotency Property",
+				fmt.Sprintf("%q is set in rich_text_properties; idempotency_property must name a property that holds only the idempotency key.", prop.ValueString()))
+		}
+	}
+
+	if config.MatchOn.IsNull() || config.MatchOn.IsUnknown() || config.MatchOn.ValueString() == "title" ||
+		config.RichTextProperties.IsUnknown() {
+		return
+	}
+	if _, ok := config.RichTextProperties.Elements()[config.MatchOn.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("match_on"), "Invalid Match Key",
+			fmt.Sprintf("match_on must be \"title\" or a key of rich_text_properties, got %q.", config.MatchOn.ValueString()))
+	}
+}
+
+// entryTitleDiagnostics checks that exactly one of title and title_property
+// is set, and that title_property has a single element.
+func entryTitleDiagnostics(config DatabaseEntryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.Title.IsUnknown() || config.TitleProperty.IsUnknown() {
+		return diags
+	}
+	switch {
+	case config.Title.IsNull() && config.TitleProperty.IsNull():
+		diags.AddAttributeError(path.Root("title"), "Missing Title",
+			"One of title or title_property must be set.")
+	case !config.Title.IsNull() && !config.TitleProperty.IsNull():
+		diags.AddAttributeError(path.Root("title_property"), "Conflicting Title",
+			"Only one of title or title_property can be set.")
+	case !config.TitleProperty.IsNull() && len(config.TitleProperty.Elements()) != 1:
+		diags.AddAttributeError(path.Root("title_property"), "Invalid Title Property",
+			fmt.Sprintf("title_property must have exactly one element, the title property, got %d.", len(config.TitleProperty.Elements())))
+	}
+	return diags
+}
+
+// titlePropertyEntry returns the single key and value of title_property, or
+// false if it isn't set or known.
+func titlePropertyEntry(m *DatabaseEntryResourceModel) (string, RichTextStringValue, bool) {
+	if m.TitleProperty.IsNull() || m.TitleProperty.IsUnknown() || len(m.TitleProperty.Elements()) != 1 {
+		return "", RichTextStringValue{}, false
+	}
+	for name, value := range m.TitleProperty.Elements() {
+		if v, ok := value.(RichTextStringValue); ok {
+			return name, v, true
+		}
+	}
+	return "", RichTextStringValue{}, false
+}
+
+// checkTitleProperty reports a title_property keyed by something other than
+// the database's title property.
+func checkTitleProperty(m *DatabaseEntryResourceModel, titlePropName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if name, _, ok := titlePropertyEntry(m); ok && name != titlePropName {
+		diags.AddAttributeError(path.Root("title_property"), "Invalid Title Property",
+			fmt.Sprintf("%q is not the title property of the database; it is %q.", name, titlePropName))
+	}
+	return diags
+}
+
+// ModifyPlan checks select and status values against the database's
+// options and mirrors title_property into title, so the rest of the
+// resource only has to deal with title.
+func (r *DatabaseEntryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer logAPICallEstimate(ctx, r.client, "notion_database_entry", req, resp, singleObjectCallEstimate)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DatabaseEntryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkEntryOptions(ctx, r.client, req, resp, &plan)
+	if resp.Diagnostics.HasError() || plan.TitleProperty.IsNull() {
+		return
+	}
+
+	title := RichTextStringValue{StringValue: types.StringUnknown()}
+	if _, value, ok := titlePropertyEntry(&plan); ok {
+		title = value
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("title"), title)...)
+}
+
+func (r *DatabaseEntryResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return migratedStateUpgraders(databaseEntryStateMigrations)
 }
 
 func (r *DatabaseEntryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
-	r.mdClient = newMarkdownClient(client)
+	r.client = data.client
+	r.provider = data
+	r.mdClient = newMarkdownClient(data.client)
 }
 
-// findTitlePropertyName retrieves the database and returns the name of the title property.
-func (r *DatabaseEntryResource) findTitlePropertyName(ctx context.Context, databaseID string) (string, error) {
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+// titlePropertyName returns the name of the database's title property,
+// fetching the database only when the name isn't cached yet. Every entry
+// Create and Update needs the name, and fetching the database each time
+// doubled the API calls of an apply touching many rows. notion_database
+// records renames of the title column, so the cache doesn't go stale.
+func (p *providerData) titlePropertyName(ctx context.Context, databaseID string) (string, error) {
+	if name, ok := p.cachedTitlePropertyName(databaseID); ok {
+		return name, nil
+	}
+
+	db, err := getDatabaseSchema(ctx, p.client, databaseID)
 	if err != nil {
 		return "", err
 	}
-	for name, prop := range db.Properties {
+	name := "Name"
+	for propName, prop := range db.Properties {
 		if prop.GetType() == notionapi.PropertyConfigTypeTitle {
-			return name, nil
+			name = propName
+			break
 		}
 	}
-	return "Name", nil
+	p.rememberTitlePropertyName(databaseID, name)
+	return name, nil
+}
+
+func (p *providerData) cachedTitlePropertyName(databaseID string) (string, bool) {
+	v, ok := p.titlePropertyNames.Load(canonicalNotionID(databaseID))
+	if !ok {
+		return "", false
+	}
+	return v.(string), true
+}
+
+// rememberTitlePropertyName records the current title property name of a
+// database.
+func (p *providerData) rememberTitlePropertyName(databaseID, name string) {
+	p.titlePropertyNames.Store(canonicalNotionID(databaseID), name)
 }
 
 func (r *DatabaseEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -169,11 +476,29 @@ func (r *DatabaseEntryResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	titlePropName, err := r.findTitlePropertyName(ctx, plan.Database.ValueString())
+	ctx = maskSensitiveProperties(ctx, &plan)
+
+	titlePropName, err := r.provider.titlePropertyName(ctx, plan.Database.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
+	resp.Diagnostics.Append(checkTitleProperty(&plan, titlePropName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.IdempotencyProperty.IsNull() {
+		if r.adoptIdempotent(ctx, &plan, titlePropName, resp) || resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !plan.MatchOn.IsNull() {
+		if r.adoptMatching(ctx, &plan, titlePropName, resp) || resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	if !plan.Markdown.IsNull() && !plan.Markdown.IsUnknown() {
 		r.createWithMarkdown(ctx, &plan, titlePropName, resp)
@@ -183,70 +508,100 @@ func (r *DatabaseEntryResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *DatabaseEntryResource) createWithMarkdown(ctx context.Context, plan *DatabaseEntryResourceModel, titlePropName string, resp *resource.CreateResponse) {
-	// Build properties as raw JSON-compatible map for the markdown client
-	props := make(map[string]interface{})
-	props[titlePropName] = map[string]interface{}{
-		"type": "title",
-		"title": []map[string]interface{}{
-			{"type": "text", "text": map[string]string{"content": plan.Title.ValueString()}},
-		},
+	properties := r.createProperties(ctx, plan, titlePropName, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The markdown client takes the properties as a JSON-compatible map;
+	// the SDK property types marshal as Notion expects.
+	props := make(map[string]interface{}, len(properties))
+	for name, prop := range properties {
+		props[name] = prop
 	}
 
-	pageID, pageURL, err := r.mdClient.CreateDatabaseEntryWithMarkdown(
+	created, err := r.mdClient.CreateDatabaseEntryWithMarkdown(
 		ctx,
-		plan.Database.ValueString(),
+		plan.Database.ValueNotionID(),
 		plan.Markdown.ValueString(),
 		props,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating database entry with markdown", err.Error())
+		resp.Diagnostics.AddError("Error creating database entry with markdown", redactSensitiveProperties(err.Error(), plan))
 		return
 	}
 
-	plan.ID = types.StringValue(normalizeID(pageID))
-	plan.URL = types.StringValue(pageURL)
+	plan.ID = types.StringValue(normalizeID(created.ID))
+	plan.URL = types.StringValue(created.URL)
+	resp.Diagnostics.Append(r.readComputed(ctx, plan)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func (r *DatabaseEntryResource) createWithoutMarkdown(ctx context.Context, plan *DatabaseEntryResourceModel, titlePropName string, resp *resource.CreateResponse) {
-	properties := buildEntryProperties(ctx, plan, &resp.Diagnostics)
+	properties := r.createProperties(ctx, plan, titlePropName, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	properties[titlePropName] = notionapi.TitleProperty{
-		Type:  notionapi.PropertyTypeTitle,
-		Title: plainToRichText(plan.Title.ValueString()),
-	}
 
 	params := &notionapi.PageCreateRequest{
 		Parent: notionapi.Parent{
 			Type:       notionapi.ParentTypeDatabaseID,
-			DatabaseID: notionapi.DatabaseID(plan.Database.ValueString()),
+			DatabaseID: notionapi.DatabaseID(plan.Database.ValueNotionID()),
 		},
 		Properties: properties,
 	}
 
 	page, err := r.client.Page.Create(ctx, params)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating database entry", err.Error())
+		resp.Diagnostics.AddError("Error creating database entry", redactSensitiveProperties(err.Error(), plan))
 		return
 	}
 
 	plan.ID = types.StringValue(normalizeID(string(page.ID)))
 	plan.URL = types.StringValue(page.URL)
+	resp.Diagnostics.Append(r.readComputed(ctx, plan)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+// createProperties builds the properties a new entry is created with: the
+// title, the idempotency key, and the typed and sensitive properties,
+// adding any missing options first.
+func (r *DatabaseEntryResource) createProperties(ctx context.Context, plan *DatabaseEntryResourceModel, titlePropName string, diags *diag.Diagnostics) notionapi.Properties {
+	diags.Append(addMissingEntryOptions(ctx, r.provider, plan)...)
+	properties := buildEntryProperties(ctx, plan, diags)
+	diags.Append(writeSensitiveProperties(ctx, r.client, plan, nil, properties)...)
+	if diags.HasError() {
+		return nil
+	}
+	properties[titlePropName] = notionapi.TitleProperty{
+		Type:  notionapi.PropertyTypeTitle,
+		Title: plainToRichText(plan.Title.ValueString()),
+	}
+	if !plan.IdempotencyProperty.IsNull() {
+		properties[plan.IdempotencyProperty.ValueString()] = notionapi.RichTextProperty{
+			Type:     notionapi.PropertyTypeRichText,
+			RichText: plainToRichText(entryIdempotencyKey(plan)),
+		}
+	}
+	return properties
+}
+
 func (r *DatabaseEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state DatabaseEntryResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = maskSensitiveProperties(ctx, &state)
 
-	page, err := r.client.Page.Get(ctx, notionapi.PageID(state.ID.ValueString()))
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database entry", err.Error())
+		return
+	}
+	page, raw, err := getPageWithRaw(ctx, token, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database entry", err.Error())
 		return
@@ -259,19 +614,55 @@ func (r *DatabaseEntryResource) Read(ctx context.Context, req resource.ReadReque
 
 	state.ID = types.StringValue(normalizeID(string(page.ID)))
 	state.URL = types.StringValue(page.URL)
+	state.PublicURL = types.StringValue(page.PublicURL)
+	state.Archived = types.BoolValue(raw.Archived)
+	state.InTrash = types.BoolValue(raw.InTrash)
 
 	if page.Parent.Type == notionapi.ParentTypeDatabaseID {
-		state.Database = types.StringValue(normalizeID(string(page.Parent.DatabaseID)))
+		state.Database = NewNotionIDValue(normalizeID(string(page.Parent.DatabaseID)))
 	}
 
 	for _, prop := range page.Properties {
 		if tp, ok := prop.(*notionapi.TitleProperty); ok {
-			state.Title = types.StringValue(richTextToPlain(tp.Title))
+			state.Title = NewRichTextStringValue(richTextToPlain(tp.Title))
 			break
 		}
 	}
+	if name, _, ok := titlePropertyEntry(&state); ok {
+		m, d := types.MapValue(RichTextStringType{}, map[string]attr.Value{name: state.Title})
+		resp.Diagnostics.Append(d...)
+		state.TitleProperty = m
+	}
 
+	prior := state
 	readEntryProperties(page, &state, &resp.Diagnostics)
+	readSensitiveProperties(page, &state, &resp.Diagnostics)
+	state.AllProperties = renderRawProperties(nonSensitiveProperties(page, raw.Properties, &state), &resp.Diagnostics)
+	state.People = rawPeopleProperties(raw.Properties, &resp.Diagnostics)
+	keepIgnoredProperties(ctx, &prior, &state, ignoredProperties(ctx, &state, &resp.Diagnostics), &resp.Diagnostics)
+	if state.DetectExternal.ValueBool() {
+		current := externalPropertyValues(page, raw.Properties, &state)
+		resp.Diagnostics.Append(detectExternalProperties(ctx, req.Private, resp.Private, state.ID.ValueString(), current)...)
+	} else {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, externalPropertiesPrivateKey, nil)...)
+	}
+
+	// Imported entries have no value yet; match the schema default.
+	if state.OnRemove.IsNull() {
+		state.OnRemove = types.StringValue("clear")
+	}
+	if state.PropertiesByID.IsNull() {
+		state.PropertiesByID = types.BoolValue(false)
+	}
+	if state.DetectExternal.IsNull() {
+		state.DetectExternal = types.BoolValue(false)
+	}
+	if state.CreateMissingOptions.IsNull() {
+		state.CreateMissingOptions = types.BoolValue(false)
+	}
+	if state.NewOptionColor.IsNull() {
+		state.NewOptionColor = types.StringValue("default")
+	}
 
 	// Markdown is managed by the user's config; we don't read it back from the
 	// API to avoid perpetual diffs caused by Notion's content normalization.
@@ -291,47 +682,264 @@ func (r *DatabaseEntryResource) Update(ctx context.Context, req resource.UpdateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	titlePropName, err := r.findTitlePropertyName(ctx, plan.Database.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading database", err.Error())
-		return
+	ctx = maskSensitiveProperties(ctx, &plan, &state)
+
+	// Only resolve the title column, and resend the title, when the title
+	// changed: an edit to any other property shouldn't cost a database read
+	// or rewrite the title.
+	var titlePropName string
+	if !plan.Title.Equal(state.Title) || !plan.TitleProperty.Equal(state.TitleProperty) {
+		var err error
+		titlePropName, err = r.provider.titlePropertyName(ctx, plan.Database.ValueNotionID())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading database", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(checkTitleProperty(&plan, titlePropName)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	properties := buildEntryProperties(ctx, &plan, &resp.Diagnostics)
+	resp.Diagnostics.Append(r.applyEntryContent(ctx, &plan, titlePropName, &state)...)
+	resp.Diagnostics.Append(r.readComputed(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	properties[titlePropName] = notionapi.TitleProperty{
-		Type:  notionapi.PropertyTypeTitle,
-		Title: plainToRichText(plan.Title.ValueString()),
-	}
 
-	clearRemovedProperties(&state, &plan, properties)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-	params := &notionapi.PageUpdateRequest{
-		Properties: properties,
+// applyEntryContent writes the planned title, properties and markdown to an
+// existing entry and records the returned URL in plan. The title is left
+// untouched when titlePropName is empty. When prior is non-nil, properties
+// it managed that are absent from the plan are cleared, unless on_remove is
+// "ignore", and properties in ignore_changes_properties are not written.
+// Shared by Update and
+// by Create when it adopts an existing entry.
+func (r *DatabaseEntryResource) applyEntryContent(ctx context.Context, plan *DatabaseEntryResourceModel, titlePropName string, prior *DatabaseEntryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(addMissingEntryOptions(ctx, r.provider, plan)...)
+	properties := buildEntryProperties(ctx, plan, &diags)
+	if diags.HasError() {
+		return diags
+	}
+	if titlePropName != "" {
+		properties[titlePropName] = notionapi.TitleProperty{
+			Type:  notionapi.PropertyTypeTitle,
+			Title: plainToRichText(plan.Title.ValueString()),
+		}
 	}
 
-	page, err := r.client.Page.Update(ctx, notionapi.PageID(plan.ID.ValueString()), params)
-	if err != nil {
-		resp.Diagnostics.AddError("Error updating database entry", err.Error())
-		return
+	// Switching properties_by_id rekeys the maps rather than removing
+	// anything, and the old keys name the same properties as the new ones.
+	if prior != nil && plan.OnRemove.ValueString() != "ignore" &&
+		plan.PropertiesByID.ValueBool() == prior.PropertiesByID.ValueBool() {
+		clearRemovedProperties(prior, plan, properties)
+	}
+	diags.Append(writeSensitiveProperties(ctx, r.client, plan, prior, properties)...)
+	if diags.HasError() {
+		return diags
+	}
+	if prior != nil {
+		for name := range ignoredProperties(ctx, plan, &diags) {
+			delete(properties, name)
+		}
 	}
 
-	plan.URL = types.StringValue(page.URL)
+	// With no properties to write (e.g. only the markdown changed) skip the
+	// page update; the URL is carried over from state.
+	if len(properties) > 0 {
+		params := &notionapi.PageUpdateRequest{
+			Properties: properties,
+		}
+
+		page, err := r.client.Page.Update(ctx, notionapi.PageID(plan.ID.ValueString()), params)
+		if err != nil {
+			diags.AddError("Error updating database entry", redactSensitiveProperties(err.Error(), plan))
+			return diags
+		}
+
+		plan.URL = types.StringValue(page.URL)
+	} else if prior != nil {
+		plan.URL = prior.URL
+	}
 
 	// Update markdown content if set
 	if !plan.Markdown.IsNull() && !plan.Markdown.IsUnknown() {
-		_, err = r.mdClient.ReplacePageMarkdown(ctx, plan.ID.ValueString(), plan.Markdown.ValueString())
+		_, err := r.mdClient.ReplacePageMarkdown(ctx, plan.ID.ValueString(), plan.Markdown.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating entry markdown", err.Error())
-			return
+			diags.AddError("Error updating entry markdown", err.Error())
+			return diags
 		}
 		// Keep plan value in state rather than API response to avoid normalization diffs
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	return diags
+}
+
+// readComputed fetches the entry and records its public URL, archived and
+// in_trash flags, and every property, rendered as a string, in m.
+func (r *DatabaseEntryResource) readComputed(ctx context.Context, m *DatabaseEntryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		diags.AddError("Error reading database entry", err.Error())
+		return diags
+	}
+	page, raw, err := getPageWithRaw(ctx, token, m.ID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading database entry", err.Error())
+		return diags
+	}
+	m.PublicURL = types.StringValue(page.PublicURL)
+	m.Archived = types.BoolValue(raw.Archived)
+	m.InTrash = types.BoolValue(raw.InTrash)
+	m.AllProperties = renderRawProperties(nonSensitiveProperties(page, raw.Properties, m), &diags)
+	m.People = rawPeopleProperties(raw.Properties, &diags)
+	return diags
+}
+
+// renderRawProperties renders properties the way the entries data source
+// does.
+func renderRawProperties(props map[string]rawProperty, diags *diag.Diagnostics) types.Map {
+	vals := make(map[string]attr.Value, len(props))
+	for name, prop := range props {
+		vals[name] = types.StringValue(rawPropertyToString(prop))
+	}
+	m, d := types.MapValue(types.StringType, vals)
+	diags.Append(d...)
+	return m
+}
+
+// peoplePropertyType is the type of the value of a people property in the
+// people maps: its users, in order.
+var peoplePropertyType = types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+}}}
+
+// rawPeopleProperties returns the users of the people properties among
+// props, keyed by property name.
+func rawPeopleProperties(props map[string]rawProperty, diags *diag.Diagnostics) types.Map {
+	objType := peoplePropertyType.ElemType.(types.ObjectType)
+	vals := map[string]attr.Value{}
+	for name, prop := range props {
+		if prop.Type != "people" {
+			continue
+		}
+		users := make([]attr.Value, 0, len(prop.People))
+		for _, user := range prop.People {
+			obj, d := types.ObjectValue(objType.AttrTypes, map[string]attr.Value{
+				"id":   types.StringValue(normalizeID(user.ID)),
+				"name": types.StringValue(user.Name),
+			})
+			diags.Append(d...)
+			users = append(users, obj)
+		}
+		list, d := types.ListValue(objType, users)
+		diags.Append(d...)
+		vals[name] = list
+	}
+	m, d := types.MapValue(peoplePropertyType, vals)
+	diags.Append(d...)
+	return m
+}
+
+// adoptMatching implements match_on: if a live row with the planned key
+// exists in the database, it is brought in line with the plan and written to
+// state. Returns whether an entry was adopted.
+func (r *DatabaseEntryResource) adoptMatching(ctx context.Context, plan *DatabaseEntryResourceModel, titlePropName string, resp *resource.CreateResponse) bool {
+	property, value, ok := entryMatchKey(plan, titlePropName)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("match_on"), "Missing Match Key",
+			fmt.Sprintf("match_on is %q, but rich_text_properties has no value for it.", plan.MatchOn.ValueString()))
+		return false
+	}
+
+	entryID, err := findEntryByKey(ctx, r.client, plan.Database.ValueNotionID(), property, value)
+	if err != nil {
+		resp.Diagnostics.AddError("Error looking for matching database entry", err.Error())
+		return false
+	}
+	if entryID == "" {
+		return false
+	}
+	return r.adoptEntry(ctx, plan, titlePropName, entryID, resp)
+}
+
+// adoptEntry takes over the live row entryID in place of creating one,
+// brings it in line with the plan and writes it to state. Returns whether
+// the row was adopted.
+func (r *DatabaseEntryResource) adoptEntry(ctx context.Context, plan *DatabaseEntryResourceModel, titlePropName, entryID string, resp *resource.CreateResponse) bool {
+	plan.ID = types.StringValue(entryID)
+	resp.Diagnostics.Append(r.applyEntryContent(ctx, plan, titlePropName, nil)...)
+	resp.Diagnostics.Append(r.readComputed(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	return true
+}
+
+// entryMatchKey returns the property and value match_on looks rows up by,
+// or false if the plan has no value for the match_on property.
+func entryMatchKey(plan *DatabaseEntryResourceModel, titlePropName string) (string, string, bool) {
+	matchOn := plan.MatchOn.ValueString()
+	if matchOn == "title" {
+		return titlePropName, plan.Title.ValueString(), true
+	}
+	if plan.RichTextProperties.IsNull() || plan.RichTextProperties.IsUnknown() {
+		return "", "", false
+	}
+	value, ok := plan.RichTextProperties.Elements()[matchOn].(RichTextStringValue)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return "", "", false
+	}
+	return matchOn, value.ValueString(), true
+}
+
+// findEntryByKey returns the ID of the oldest live row in databaseID whose
+// title or rich text property equals value, or "" if there is none. The
+// property is given by name or by ID.
+func findEntryByKey(ctx context.Context, client *notionapi.Client, databaseID, property, value string) (string, error) {
+	var cursor notionapi.Cursor
+	for {
+		res, err := client.Database.Query(ctx, notionapi.DatabaseID(databaseID), &notionapi.DatabaseQueryRequest{
+			Filter: notionapi.PropertyFilter{
+				Property: property,
+				// Notion filters on plain text, so drop the inline markdown.
+				RichText: &notionapi.TextFilterCondition{Equals: markdownPlainText(value)},
+			},
+			Sorts:       []notionapi.SortObject{{Timestamp: notionapi.TimestampCreated, Direction: notionapi.SortOrderASC}},
+			StartCursor: cursor,
+			PageSize:    100,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		for i := range res.Results {
+			page := &res.Results[i]
+			if page.Archived {
+				continue
+			}
+			prop, found := page.Properties[property]
+			if !found {
+				prop = propertiesByID(page.Properties)[property]
+			}
+			if got, ok := textPropertyValue(prop); ok && got == value {
+				return normalizeID(string(page.ID)), nil
+			}
+		}
+
+		if !res.HasMore {
+			return "", nil
+		}
+		cursor = res.NextCursor
+	}
 }
 
 func (r *DatabaseEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -352,8 +960,126 @@ func (r *DatabaseEntryResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 }
 
+// ImportState fills in the database, the title and, so that an imported
+// entry manages what it has, a typed property map entry for every property
+// with a value, before Read refreshes the rest.
 func (r *DatabaseEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := normalizeID(req.ID)
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing database entry", err.Error())
+		return
+	}
+	page, raw, err := getPageWithRaw(ctx, token, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing database entry", err.Error())
+		return
+	}
+	if page.Parent.Type != notionapi.ParentTypeDatabaseID {
+		resp.Diagnostics.AddError("Error importing database entry",
+			fmt.Sprintf("Page %s has parent type %q, not a database; import it as a notion_page instead.", id, page.Parent.Type))
+		return
+	}
+
+	var m DatabaseEntryResourceModel
+	importedEntryProperties(page, raw.Properties, &m, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(normalizeID(string(page.Parent.DatabaseID))))...)
+	for _, prop := range page.Properties {
+		if tp, ok := prop.(*notionapi.TitleProperty); ok {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("title"), NewRichTextStringValue(richTextToPlain(tp.Title)))...)
+			break
+		}
+	}
+	for i, props := range entryPropertyMaps(&m) {
+		if !props.IsNull() {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(entryPropertyMapAttributes[i]), *props)...)
+		}
+	}
+}
+
+// entryPropertyMapAttributes are the attribute names of the maps returned by
+// entryPropertyMaps, in the same order.
+var entryPropertyMapAttributes = []string{
+	"rich_text_properties", "number_properties", "checkbox_properties",
+	"select_properties", "status_properties", "url_properties",
+	"email_properties", "phone_number_properties", "date_properties",
+	"multi_select_properties",
+}
+
+// importedEntryProperties sets the typed property maps of m to the page's
+// properties that have a value, leaving the maps with none null. Empty
+// rich text, select, multi-select, status, URL, email, phone number and date properties,
+// unchecked checkboxes and numbers without a value are left out.
+func importedEntryProperties(page *notionapi.Page, raw map[string]rawProperty, m *DatabaseEntryResourceModel, diags *diag.Diagnostics) {
+	vals := map[*types.Map]map[string]attr.Value{}
+	add := func(target *types.Map, name string, v attr.Value) {
+		if vals[target] == nil {
+			vals[target] = map[string]attr.Value{}
+		}
+		vals[target][name] = v
+	}
+	for name, prop := range page.Properties {
+		switch p := prop.(type) {
+		case *notionapi.RichTextProperty:
+			if len(p.RichText) > 0 {
+				add(&m.RichTextProperties, name, NewRichTextStringValue(richTextToPlain(p.RichText)))
+			}
+		case *notionapi.NumberProperty:
+			if raw[name].Number != nil {
+				add(&m.NumberProperties, name, types.Float64Value(p.Number))
+			}
+		case *notionapi.CheckboxProperty:
+			if p.Checkbox {
+				add(&m.CheckboxProperties, name, types.BoolValue(true))
+			}
+		case *notionapi.SelectProperty:
+			if p.Select.Name != "" {
+				add(&m.SelectProperties, name, types.StringValue(p.Select.Name))
+			}
+		case *notionapi.MultiSelectProperty:
+			if len(p.MultiSelect) > 0 {
+				add(&m.MultiSelectProperties, name, optionNameSet(p.MultiSelect, diags))
+			}
+		case *notionapi.StatusProperty:
+			if p.Status.Name != "" {
+				add(&m.StatusProperties, name, types.StringValue(p.Status.Name))
+			}
+		case *notionapi.URLProperty:
+			if p.URL != "" {
+				add(&m.URLProperties, name, types.StringValue(p.URL))
+			}
+		case *notionapi.EmailProperty:
+			if p.Email != "" {
+				add(&m.EmailProperties, name, types.StringValue(p.Email))
+			}
+		case *notionapi.PhoneNumberProperty:
+			if p.PhoneNumber != "" {
+				add(&m.PhoneNumberProperties, name, types.StringValue(p.PhoneNumber))
+			}
+		case *notionapi.DateProperty:
+			if p.Date != nil && p.Date.Start != nil {
+				add(&m.DateProperties, name, NewDateStringValue(formatNotionDate(p.Date.Start)))
+			}
+		}
+	}
+
+	elemTypes := []attr.Type{
+		RichTextStringType{}, types.Float64Type, types.BoolType,
+		types.StringType, types.StringType, types.StringType,
+		types.StringType, types.StringType, DateStringType{},
+		types.SetType{ElemType: types.StringType},
+	}
+	for i, target := range entryPropertyMaps(m) {
+		if len(vals[target]) == 0 {
+			*target = types.MapNull(elemTypes[i])
+			continue
+		}
+		v, d := types.MapValue(elemTypes[i], vals[target])
+		diags.Append(d...)
+		*target = v
+	}
 }
 
 // buildEntryProperties constructs notionapi.Properties from all typed map fields in the plan.
@@ -404,6 +1130,21 @@ func buildEntryProperties(ctx context.Context, plan *DatabaseEntryResourceModel,
 		}
 	}
 
+	if !plan.MultiSelectProperties.IsNull() && !plan.MultiSelectProperties.IsUnknown() {
+		var vals map[string][]string
+		diags.Append(plan.MultiSelectProperties.ElementsAs(ctx, &vals, false)...)
+		for name, val := range vals {
+			options := make([]notionapi.Option, len(val))
+			for i, v := range val {
+				options[i] = notionapi.Option{Name: v}
+			}
+			props[name] = notionapi.MultiSelectProperty{
+				Type:        notionapi.PropertyTypeMultiSelect,
+				MultiSelect: options,
+			}
+		}
+	}
+
 	if !plan.StatusProperties.IsNull() && !plan.StatusProperties.IsUnknown() {
 		var vals map[string]string
 		diags.Append(plan.StatusProperties.ElementsAs(ctx, &vals, false)...)
@@ -452,14 +1193,13 @@ func buildEntryProperties(ctx context.Context, plan *DatabaseEntryResourceModel,
 		var vals map[string]string
 		diags.Append(plan.DateProperties.ElementsAs(ctx, &vals, false)...)
 		for name, val := range vals {
-			t, err := time.Parse(time.RFC3339, val)
+			// Known values are already checked at plan time by
+			// NotionDateMapValidator; this catches values that were
+			// unknown until apply.
+			t, _, err := parseNotionDate(val)
 			if err != nil {
-				t, err = time.Parse("2006-01-02", val)
-				if err != nil {
-					diags.AddError("Invalid date value",
-						fmt.Sprintf("Property %q: %q is not a valid ISO 8601 date or datetime.", name, val))
-					continue
-				}
+				diags.AddError("Invalid date value", fmt.Sprintf("Property %q: %s.", name, err))
+				continue
 			}
 			d := notionapi.Date(t)
 			props[name] = notionapi.DateProperty{
@@ -475,16 +1215,21 @@ func buildEntryProperties(ctx context.Context, plan *DatabaseEntryResourceModel,
 // readEntryProperties reads API response properties back into the matching state maps.
 // Only properties whose keys are already managed (present in the current state maps) are read.
 func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel, diags *diag.Diagnostics) {
+	props := page.Properties
+	if state.PropertiesByID.ValueBool() {
+		props = propertiesByID(page.Properties)
+	}
+
 	if !state.RichTextProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.RichTextProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if rtp, ok := prop.(*notionapi.RichTextProperty); ok {
-					vals[name] = types.StringValue(richTextToPlain(rtp.RichText))
+					vals[name] = NewRichTextStringValue(richTextToPlain(rtp.RichText))
 				}
 			}
 		}
-		m, d := types.MapValue(types.StringType, vals)
+		m, d := types.MapValue(RichTextStringType{}, vals)
 		diags.Append(d...)
 		state.RichTextProperties = m
 	}
@@ -492,7 +1237,7 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 	if !state.NumberProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.NumberProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if np, ok := prop.(*notionapi.NumberProperty); ok {
 					vals[name] = types.Float64Value(np.Number)
 				}
@@ -506,7 +1251,7 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 	if !state.CheckboxProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.CheckboxProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if cp, ok := prop.(*notionapi.CheckboxProperty); ok {
 					vals[name] = types.BoolValue(cp.Checkbox)
 				}
@@ -520,7 +1265,7 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 	if !state.SelectProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.SelectProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if sp, ok := prop.(*notionapi.SelectProperty); ok {
 					vals[name] = types.StringValue(sp.Select.Name)
 				}
@@ -531,10 +1276,24 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 		state.SelectProperties = m
 	}
 
+	if !state.MultiSelectProperties.IsNull() {
+		vals := make(map[string]attr.Value)
+		for name := range state.MultiSelectProperties.Elements() {
+			if prop, ok := props[name]; ok {
+				if mp, ok := prop.(*notionapi.MultiSelectProperty); ok {
+					vals[name] = optionNameSet(mp.MultiSelect, diags)
+				}
+			}
+		}
+		m, d := types.MapValue(types.SetType{ElemType: types.StringType}, vals)
+		diags.Append(d...)
+		state.MultiSelectProperties = m
+	}
+
 	if !state.StatusProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.StatusProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if sp, ok := prop.(*notionapi.StatusProperty); ok {
 					vals[name] = types.StringValue(sp.Status.Name)
 				}
@@ -548,7 +1307,7 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 	if !state.URLProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.URLProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if up, ok := prop.(*notionapi.URLProperty); ok {
 					vals[name] = types.StringValue(up.URL)
 				}
@@ -562,7 +1321,7 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 	if !state.EmailProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.EmailProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if ep, ok := prop.(*notionapi.EmailProperty); ok {
 					vals[name] = types.StringValue(ep.Email)
 				}
@@ -576,7 +1335,7 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 	if !state.PhoneNumberProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.PhoneNumberProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if pp, ok := prop.(*notionapi.PhoneNumberProperty); ok {
 					vals[name] = types.StringValue(pp.PhoneNumber)
 				}
@@ -590,20 +1349,93 @@ func readEntryProperties(page *notionapi.Page, state *DatabaseEntryResourceModel
 	if !state.DateProperties.IsNull() {
 		vals := make(map[string]attr.Value)
 		for name := range state.DateProperties.Elements() {
-			if prop, ok := page.Properties[name]; ok {
+			if prop, ok := props[name]; ok {
 				if dp, ok := prop.(*notionapi.DateProperty); ok {
 					if dp.Date != nil && dp.Date.Start != nil {
-						vals[name] = types.StringValue(formatNotionDate(dp.Date.Start))
+						vals[name] = NewDateStringValue(formatNotionDate(dp.Date.Start))
 					}
 				}
 			}
 		}
-		m, d := types.MapValue(types.StringType, vals)
+		m, d := types.MapValue(DateStringType{}, vals)
 		diags.Append(d...)
 		state.DateProperties = m
 	}
 }
 
+// optionNameSet returns the names of options as a set value.
+func optionNameSet(options []notionapi.Option, diags *diag.Diagnostics) types.Set {
+	names := make([]attr.Value, len(options))
+	for i, o := range options {
+		names[i] = types.StringValue(o.Name)
+	}
+	v, d := types.SetValue(types.StringType, names)
+	diags.Append(d...)
+	return v
+}
+
+// propertiesByID rekeys page properties, which the API keys by name, by
+// property ID.
+func propertiesByID(props notionapi.Properties) notionapi.Properties {
+	byID := make(notionapi.Properties, len(props))
+	for _, prop := range props {
+		byID[string(prop.GetID())] = prop
+	}
+	return byID
+}
+
+// ignoredProperties returns the names in ignore_changes_properties.
+func ignoredProperties(ctx context.Context, m *DatabaseEntryResourceModel, diags *diag.Diagnostics) map[string]bool {
+	if m.IgnoreChanges.IsNull() || m.IgnoreChanges.IsUnknown() {
+		return nil
+	}
+	var names []string
+	diags.Append(m.IgnoreChanges.ElementsAs(ctx, &names, false)...)
+	ignored := make(map[string]bool, len(names))
+	for _, name := range names {
+		ignored[name] = true
+	}
+	return ignored
+}
+
+// entryPropertyMaps returns the typed property maps of m, in a fixed order.
+func entryPropertyMaps(m *DatabaseEntryResourceModel) []*types.Map {
+	return []*types.Map{
+		&m.RichTextProperties, &m.NumberProperties, &m.CheckboxProperties,
+		&m.SelectProperties, &m.StatusProperties, &m.URLProperties,
+		&m.EmailProperties, &m.PhoneNumberProperties, &m.DateProperties,
+		&m.MultiSelectProperties,
+	}
+}
+
+// keepIgnoredProperties copies the prior values of ignored properties into
+// the freshly read state, so changes made in Notion don't show as drift.
+func keepIgnoredProperties(ctx context.Context, prior, state *DatabaseEntryResourceModel, ignored map[string]bool, diags *diag.Diagnostics) {
+	if len(ignored) == 0 {
+		return
+	}
+	priorMaps := append(entryPropertyMaps(prior), &prior.SensitiveProperties)
+	for i, stateMap := range append(entryPropertyMaps(state), &state.SensitiveProperties) {
+		if stateMap.IsNull() || stateMap.IsUnknown() || priorMaps[i].IsUnknown() {
+			continue
+		}
+		elems := maps.Clone(stateMap.Elements())
+		kept := false
+		for name, value := range priorMaps[i].Elements() {
+			if ignored[name] {
+				elems[name] = value
+				kept = true
+			}
+		}
+		if !kept {
+			continue
+		}
+		m, d := types.MapValue(stateMap.ElementType(ctx), elems)
+		diags.Append(d...)
+		*stateMap = m
+	}
+}
+
 // removedKeys returns keys present in stateMap but absent from planMap.
 func removedKeys(stateMap, planMap types.Map) []string {
 	if stateMap.IsNull() || stateMap.IsUnknown() {
@@ -650,6 +1482,12 @@ func clearRemovedProperties(state, plan *DatabaseEntryResourceModel, props notio
 			Select: notionapi.Option{},
 		}
 	}
+	for _, name := range removedKeys(state.MultiSelectProperties, plan.MultiSelectProperties) {
+		props[name] = notionapi.MultiSelectProperty{
+			Type:        notionapi.PropertyTypeMultiSelect,
+			MultiSelect: []notionapi.Option{},
+		}
+	}
 	for _, name := range removedKeys(state.StatusProperties, plan.StatusProperties) {
 		props[name] = notionapi.StatusProperty{
 			Type:   notionapi.PropertyTypeStatus,
@@ -687,7 +1525,7 @@ func clearRemovedProperties(state, plan *DatabaseEntryResourceModel, props notio
 func formatNotionDate(d *notionapi.Date) string {
 	t := time.Time(*d)
 	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
-		return t.Format("2006-01-02")
+		return t.Format(notionDateOnlyLayout)
 	}
 	return t.Format(time.RFC3339)
 }
@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
 )
 
 func TestAccDatabaseEntryResource(t *testing.T) {
@@ -25,6 +31,11 @@ func TestAccDatabaseEntryResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("notion_database_entry.test", "url"),
 				),
 			},
+			{
+				ResourceName:      "notion_database_entry.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccDatabaseEntryResourceConfig(parentPageID, "Test Entry Updated"),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -92,3 +103,274 @@ resource "notion_database_entry" "test_md" {
 }
 `, parentPageID, title, markdown)
 }
+
+func TestTitlePropertyNameCache(t *testing.T) {
+	data := newProviderData(notionapi.NewClient("test-token"))
+	other := newProviderData(notionapi.NewClient("test-token"))
+	const id = "0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+
+	if _, ok := data.cachedTitlePropertyName(id); ok {
+		t.Fatal("expected an empty cache")
+	}
+
+	data.rememberTitlePropertyName(id, "Task")
+	if name, ok := data.cachedTitlePropertyName("0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"); !ok || name != "Task" {
+		t.Errorf("expected hyphenated ID to hit the cache, got %q, %v", name, ok)
+	}
+	if _, ok := other.cachedTitlePropertyName(id); ok {
+		t.Error("expected the cache to be scoped to the provider instance")
+	}
+
+	data.rememberTitlePropertyName(id, "Renamed")
+	if name, _ := data.cachedTitlePropertyName(id); name != "Renamed" {
+		t.Errorf("expected the rename to replace the cached name, got %q", name)
+	}
+}
+
+func TestEntryMatchKey(t *testing.T) {
+	richText := types.MapValueMust(RichTextStringType{}, map[string]attr.Value{
+		"External ID": NewRichTextStringValue("cus_0042"),
+	})
+
+	tests := []struct {
+		name         string
+		matchOn      string
+		richText     types.Map
+		wantProperty string
+		wantValue    string
+		wantOK       bool
+	}{
+		{"title", "title", types.MapNull(RichTextStringType{}), "Name", "Acme Corp", true},
+		{"rich text property", "External ID", richText, "External ID", "cus_0042", true},
+		{"missing property", "Code", richText, "", "", false},
+		{"no rich text properties", "External ID", types.MapNull(RichTextStringType{}), "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &DatabaseEntryResourceModel{
+				Title:              NewRichTextStringValue("Acme Corp"),
+				MatchOn:            types.StringValue(tt.matchOn),
+				RichTextProperties: tt.richText,
+			}
+			property, value, ok := entryMatchKey(plan, "Name")
+			if property != tt.wantProperty || value != tt.wantValue || ok != tt.wantOK {
+				t.Errorf("got (%q, %q, %v), want (%q, %q, %v)", property, value, ok, tt.wantProperty, tt.wantValue, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestKeepIgnoredProperties(t *testing.T) {
+	ctx := context.Background()
+	prior := &DatabaseEntryResourceModel{
+		StatusProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Status":   types.StringValue("Not started"),
+			"Priority": types.StringValue("High"),
+		}),
+		NumberProperties: types.MapValueMust(types.Float64Type, map[string]attr.Value{
+			"Estimate": types.Float64Value(3),
+		}),
+	}
+	// Someone moved the status on and removed the priority in Notion.
+	state := &DatabaseEntryResourceModel{
+		StatusProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Status": types.StringValue("Done"),
+		}),
+		NumberProperties: types.MapValueMust(types.Float64Type, map[string]attr.Value{
+			"Estimate": types.Float64Value(5),
+		}),
+	}
+
+	var diags diag.Diagnostics
+	keepIgnoredProperties(ctx, prior, state, map[string]bool{"Status": true, "Priority": true}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !state.StatusProperties.Equal(prior.StatusProperties) {
+		t.Errorf("expected ignored statuses to keep their prior values, got %v", state.StatusProperties)
+	}
+	if got := state.NumberProperties.Elements()["Estimate"]; !got.Equal(types.Float64Value(5)) {
+		t.Errorf("expected a managed property to report drift, got %v", got)
+	}
+}
+
+func TestReadEntryPropertiesByID(t *testing.T) {
+	page := &notionapi.Page{Properties: notionapi.Properties{
+		"Status (renamed)": &notionapi.StatusProperty{ID: "%3AUPp", Status: notionapi.Option{Name: "Done"}},
+		"Points":           &notionapi.NumberProperty{ID: "xYz1", Number: 5},
+	}}
+	state := &DatabaseEntryResourceModel{
+		PropertiesByID: types.BoolValue(true),
+		StatusProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"%3AUPp": types.StringValue("Not started"),
+		}),
+		NumberProperties: types.MapValueMust(types.Float64Type, map[string]attr.Value{
+			"Points": types.Float64Value(3),
+		}),
+	}
+
+	var diags diag.Diagnostics
+	readEntryProperties(page, state, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if got := state.StatusProperties.Elements()["%3AUPp"]; !got.Equal(types.StringValue("Done")) {
+		t.Errorf("expected the status to be read by ID after the rename, got %v", got)
+	}
+	if got := state.NumberProperties.Elements(); len(got) != 0 {
+		t.Errorf("expected a name key not to match in ID mode, got %v", got)
+	}
+}
+
+func TestRenderRawProperties(t *testing.T) {
+	var page rawPage
+	err := json.Unmarshal([]byte(`{
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Row"}]},
+			"Total": {"id": "a", "type": "formula", "formula": {"type": "number", "number": 0}},
+			"Ticket": {"id": "b", "type": "unique_id", "unique_id": {"prefix": "OPS", "number": 42}},
+			"Done": {"id": "c", "type": "checkbox", "checkbox": false}
+		}
+	}`), &page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var diags diag.Diagnostics
+	got := renderRawProperties(page.Properties, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	want := map[string]string{"Name": "Row", "Total": "0", "Ticket": "OPS-42", "Done": "false"}
+	for name, value := range want {
+		if v := got.Elements()[name]; !v.Equal(types.StringValue(value)) {
+			t.Errorf("%s: got %v, want %q", name, v, value)
+		}
+	}
+}
+
+func TestEntryTitleDiagnostics(t *testing.T) {
+	titleProperty := func(elems map[string]attr.Value) types.Map {
+		return types.MapValueMust(RichTextStringType{}, elems)
+	}
+	nullTitle := RichTextStringValue{StringValue: types.StringNull()}
+	nullMap := types.MapNull(RichTextStringType{})
+
+	tests := []struct {
+		name      string
+		title     RichTextStringValue
+		property  types.Map
+		wantError bool
+	}{
+		{"title", NewRichTextStringValue("Row"), nullMap, false},
+		{"title_property", nullTitle, titleProperty(map[string]attr.Value{"Name": NewRichTextStringValue("Row")}), false},
+		{"neither", nullTitle, nullMap, true},
+		{"both", NewRichTextStringValue("Row"), titleProperty(map[string]attr.Value{"Name": NewRichTextStringValue("Row")}), true},
+		{"several elements", nullTitle, titleProperty(map[string]attr.Value{
+			"Name":  NewRichTextStringValue("Row"),
+			"Other": NewRichTextStringValue("Row"),
+		}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := entryTitleDiagnostics(DatabaseEntryResourceModel{Title: tt.title, TitleProperty: tt.property})
+			if diags.HasError() != tt.wantError {
+				t.Errorf("got errors %v, want error %v", diags, tt.wantError)
+			}
+		})
+	}
+
+	model := &DatabaseEntryResourceModel{
+		TitleProperty: titleProperty(map[string]attr.Value{"Name": NewRichTextStringValue("Row")}),
+	}
+	if diags := checkTitleProperty(model, "Name"); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := checkTitleProperty(model, "Task"); !diags.HasError() {
+		t.Error("expected a title_property not keyed by the title property to be rejected")
+	}
+}
+
+func TestImportedEntryProperties(t *testing.T) {
+	number := 3.0
+	page := &notionapi.Page{Properties: notionapi.Properties{
+		"Name":     &notionapi.TitleProperty{Title: []notionapi.RichText{{PlainText: "Row"}}},
+		"Notes":    &notionapi.RichTextProperty{RichText: []notionapi.RichText{{PlainText: "hi", Text: &notionapi.Text{Content: "hi"}}}},
+		"Empty":    &notionapi.RichTextProperty{},
+		"Points":   &notionapi.NumberProperty{Number: 3},
+		"Unset":    &notionapi.NumberProperty{},
+		"Done":     &notionapi.CheckboxProperty{Checkbox: false},
+		"Priority": &notionapi.SelectProperty{Select: notionapi.Option{Name: "High"}},
+	}}
+	raw := map[string]rawProperty{
+		"Points": {Type: "number", Number: &number},
+		"Unset":  {Type: "number"},
+	}
+
+	var m DatabaseEntryResourceModel
+	var diags diag.Diagnostics
+	importedEntryProperties(page, raw, &m, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if got := m.RichTextProperties.Elements(); len(got) != 1 || !got["Notes"].Equal(NewRichTextStringValue("hi")) {
+		t.Errorf("rich_text_properties = %v, want only Notes", got)
+	}
+	if got := m.NumberProperties.Elements(); len(got) != 1 || !got["Points"].Equal(types.Float64Value(3)) {
+		t.Errorf("number_properties = %v, want only Points", got)
+	}
+	if got := m.SelectProperties.Elements(); len(got) != 1 || !got["Priority"].Equal(types.StringValue("High")) {
+		t.Errorf("select_properties = %v, want only Priority", got)
+	}
+	if !m.CheckboxProperties.IsNull() || !m.DateProperties.IsNull() {
+		t.Errorf("expected maps without values to stay null, got checkbox %v, date %v", m.CheckboxProperties, m.DateProperties)
+	}
+	if len(entryPropertyMapAttributes) != len(entryPropertyMaps(&m)) {
+		t.Errorf("entryPropertyMapAttributes has %d names for %d maps", len(entryPropertyMapAttributes), len(entryPropertyMaps(&m)))
+	}
+}
+
+func TestRawPeopleProperties(t *testing.T) {
+	var page rawPage
+	err := json.Unmarshal([]byte(`{
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Row"}]},
+			"Owners": {"id": "a", "type": "people", "people": [
+				{"object": "user", "id": "11111111-1111-1111-1111-111111111111", "name": "Ada"},
+				{"object": "user", "id": "22222222-2222-2222-2222-222222222222", "name": "Grace"}
+			]},
+			"Reviewers": {"id": "b", "type": "people", "people": []}
+		}
+	}`), &page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var diags diag.Diagnostics
+	got := rawPeopleProperties(page.Properties, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(got.Elements()) != 2 {
+		t.Fatalf("expected the two people properties, got %v", got)
+	}
+
+	type user struct {
+		ID   string `tfsdk:"id"`
+		Name string `tfsdk:"name"`
+	}
+	var owners []user
+	if d := got.Elements()["Owners"].(types.List).ElementsAs(context.Background(), &owners, false); d.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	want := []user{{"11111111111111111111111111111111", "Ada"}, {"22222222222222222222222222222222", "Grace"}}
+	if fmt.Sprint(owners) != fmt.Sprint(want) {
+		t.Errorf("Owners: got %v, want %v", owners, want)
+	}
+	if n := len(got.Elements()["Reviewers"].(types.List).Elements()); n != 0 {
+		t.Errorf("Reviewers: got %d users, want none", n)
+	}
+}
@@ -0,0 +1,561 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                     = &DatabaseImportResource{}
+	_ resource.ResourceWithConfigValidators = &DatabaseImportResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabaseImportResource{}
+)
+
+// DatabaseImportResource seeds a database from a CSV file, for reference
+// data that lives in a spreadsheet. Unlike notion_database_entries_bulk it
+// doesn't keep every row in state: it records a hash of the CSV and
+// re-syncs every row when the hash changes.
+type DatabaseImportResource struct {
+	client   *notionapi.Client
+	provider *providerData
+}
+
+type DatabaseImportResourceModel struct {
+	ID          types.String  `tfsdk:"id"`
+	Database    NotionIDValue `tfsdk:"database"`
+	CSVPath     types.String  `tfsdk:"csv_path"`
+	CSVContent  types.String  `tfsdk:"csv_content"`
+	Columns     types.Map     `tfsdk:"columns"`
+	KeyColumn   types.String  `tfsdk:"key_column"`
+	ContentHash types.String  `tfsdk:"content_hash"`
+	EntryIDs    types.Map     `tfsdk:"entry_ids"`
+}
+
+func NewDatabaseImportResource() resource.Resource {
+	return &DatabaseImportResource{}
+}
+
+func (r *DatabaseImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_import"
+}
+
+func (r *DatabaseImportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Materializes the rows of a CSV file as entries in a Notion database, re-syncing them when the CSV changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the database.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "The ID of the database to import into.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"csv_path": schema.StringAttribute{
+				Description: "Path to the CSV file. Exactly one of csv_path and csv_content must be set.",
+				Optional:    true,
+			},
+			"csv_content": schema.StringAttribute{
+				Description: "The CSV content. Exactly one of csv_path and csv_content must be set.",
+				Optional:    true,
+			},
+			"columns": schema.MapAttribute{
+				Description: "Map of CSV column name (from the header row) to the database property it fills. " +
+					"CSV columns not in the map are ignored.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"key_column": schema.StringAttribute{
+				Description: "The CSV column that identifies each row. It must be in columns and map to the title or a " +
+					"rich text property. Existing entries with a matching value are updated instead of duplicated.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Description: "SHA-256 of the CSV content. A change re-syncs every row.",
+				Computed:    true,
+			},
+			"entry_ids": schema.MapAttribute{
+				Description: "The page ID of each imported entry, keyed by its key_column value.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *DatabaseImportResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		DatabaseImportSourceValidator(),
+	}
+}
+
+func (r *DatabaseImportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.provider = data
+}
+
+// ModifyPlan hashes the CSV so an edit to the file, which Terraform can't
+// see in the configuration, still plans an update.
+func (r *DatabaseImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DatabaseImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.CSVPath.IsUnknown() || plan.CSVContent.IsUnknown() {
+		plan.ContentHash = types.StringUnknown()
+		plan.EntryIDs = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	content, err := loadImportCSV(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("csv_path"), "Error Reading CSV", err.Error())
+		return
+	}
+	plan.ContentHash = types.StringValue(csvContentHash(content))
+
+	if !req.State.Raw.IsNull() {
+		var state DatabaseImportResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.ContentHash.Equal(state.ContentHash) {
+			plan.EntryIDs = types.MapUnknown(types.StringType)
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *DatabaseImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DatabaseImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.Database.ValueNotionID())
+	resp.Diagnostics.Append(r.sync(ctx, &plan, map[string]string{})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabaseImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DatabaseImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var columns map[string]string
+	resp.Diagnostics.Append(state.Columns.ElementsAs(ctx, &columns, false)...)
+	var ids map[string]string
+	resp.Diagnostics.Append(state.EntryIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading imported entries", err.Error())
+		return
+	}
+	remote, err := queryEntriesByKey(ctx, token, state.Database.ValueNotionID(), columns[state.KeyColumn.ValueString()])
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading imported entries", err.Error())
+		return
+	}
+
+	// Row contents aren't tracked individually, so an entry deleted (or
+	// its key edited) in Notion clears the hash instead, and the next plan
+	// re-syncs every row.
+	for key := range ids {
+		page, ok := remote[key]
+		if !ok {
+			delete(ids, key)
+			state.ContentHash = types.StringValue("")
+			continue
+		}
+		ids[key] = normalizeID(string(page.ID))
+	}
+	entryIDs, diags := entryIDsValue(ids)
+	resp.Diagnostics.Append(diags...)
+	state.EntryIDs = entryIDs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DatabaseImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DatabaseImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state DatabaseImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids map[string]string
+	resp.Diagnostics.Append(state.EntryIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// sync writes every CSV row to the database and archives the entries in
+// prior (entry IDs by key from the last sync) whose key is no longer in the
+// CSV. It records the resulting entry IDs in plan. If any write fails the
+// hash is cleared so the next plan retries the sync.
+func (r *DatabaseImportResource) sync(ctx context.Context, plan *DatabaseImportResourceModel, prior map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ids := make(map[string]string, len(prior))
+	for key, id := range prior {
+		ids[key] = id
+	}
+	defer func() {
+		entryIDs, d := entryIDsValue(ids)
+		diags.Append(d...)
+		plan.EntryIDs = entryIDs
+	}()
+
+	content, err := loadImportCSV(*plan)
+	if err != nil {
+		diags.AddError("Error reading CSV", err.Error())
+		return diags
+	}
+	plan.ContentHash = types.StringValue(csvContentHash(content))
+
+	var columns map[string]string
+	diags.Append(plan.Columns.ElementsAs(ctx, &columns, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	databaseID := plan.Database.ValueNotionID()
+	db, err := getDatabaseSchema(ctx, r.client, databaseID)
+	if err != nil {
+		diags.AddError("Error reading database", err.Error())
+		return diags
+	}
+	propTypes := make(map[string]notionapi.PropertyConfigType, len(db.Properties))
+	for name, prop := range db.Properties {
+		propTypes[name] = prop.GetType()
+		if prop.GetType() == notionapi.PropertyConfigTypeTitle {
+			r.provider.rememberTitlePropertyName(databaseID, name)
+		}
+	}
+
+	rows, err := parseImportCSV(content, plan.KeyColumn.ValueString(), columns, propTypes)
+	if err != nil {
+		diags.AddError("Error parsing CSV", err.Error())
+		return diags
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		diags.AddError("Error importing database entries", err.Error())
+		return diags
+	}
+	remote, err := queryEntriesByKey(ctx, token, databaseID, columns[plan.KeyColumn.ValueString()])
+	if err != nil {
+		diags.AddError("Error reading database entries", err.Error())
+		return diags
+	}
+
+	inCSV := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		inCSV[row.key] = true
+	}
+	var stale []string
+	for key := range prior {
+		if !inCSV[key] {
+			stale = append(stale, key)
+		}
+	}
+	sort.Strings(stale)
+
+	var mu sync.Mutex
+	failed := false
+	forEachConcurrently(len(rows)+len(stale), bulkEntryConcurrency, func(i int) {
+		if i >= len(rows) {
+			key := stale[i-len(rows)]
+			err := trashObject(ctx, token, "pages", prior[key])
+			mu.Lock()
+			defer mu.Unlock()
+			// A row already deleted or trashed outside Terraform is gone
+			// all the same.
+			if err != nil && !isGoneError(err) {
+				diags.AddError("Error archiving database entry", fmt.Sprintf("Row %q: %s", key, err))
+				failed = true
+				return
+			}
+			delete(ids, key)
+			return
+		}
+
+		row := rows[i]
+		var id string
+		var err error
+		if page, ok := remote[row.key]; ok {
+			id = normalizeID(string(page.ID))
+			_, err = r.client.Page.Update(ctx, notionapi.PageID(id), &notionapi.PageUpdateRequest{
+				Properties: row.properties,
+			})
+		} else {
+			var page *notionapi.Page
+			page, err = r.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+				Parent: notionapi.Parent{
+					Type:       notionapi.ParentTypeDatabaseID,
+					DatabaseID: notionapi.DatabaseID(databaseID),
+				},
+				Properties: row.properties,
+			})
+			if err == nil {
+				id = normalizeID(string(page.ID))
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			diags.AddError("Error importing database entry", fmt.Sprintf("Row %q (CSV row %d): %s", row.key, row.number, err))
+			failed = true
+			return
+		}
+		ids[row.key] = id
+	})
+
+	if failed {
+		plan.ContentHash = types.StringValue("")
+	}
+	return diags
+}
+
+func (r *DatabaseImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DatabaseImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error trashing imported entries", err.Error())
+		return
+	}
+
+	var ids map[string]string
+	resp.Diagnostics.Append(state.EntryIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys := make([]string, 0, len(ids))
+	for key := range ids {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := trashObject(ctx, token, "pages", ids[key]); err != nil && !isGoneError(err) {
+			resp.Diagnostics.AddError("Error trashing database entry", fmt.Sprintf("Row %q: %s", key, err))
+		}
+	}
+}
+
+// loadImportCSV returns the CSV content from csv_content or csv_path.
+func loadImportCSV(m DatabaseImportResourceModel) (string, error) {
+	if !m.CSVContent.IsNull() {
+		return m.CSVContent.ValueString(), nil
+	}
+	b, err := os.ReadFile(m.CSVPath.ValueString())
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// csvContentHash returns the hex SHA-256 of content.
+func csvContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// importRow is one parsed CSV row.
+type importRow struct {
+	key        string
+	number     int
+	properties notionapi.Properties
+}
+
+// parseImportCSV parses content, whose first record is the header, into
+// rows of properties. columns maps CSV column names to property names and
+// propTypes gives each database property's type. Every mapped column must
+// be in the header and every key must be present and unique.
+func parseImportCSV(content, keyColumn string, columns map[string]string, propTypes map[string]notionapi.PropertyConfigType) ([]importRow, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("the CSV has no header row")
+	}
+
+	header := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		header[strings.TrimSpace(name)] = i
+	}
+	keyIndex, ok := header[keyColumn]
+	if !ok {
+		return nil, fmt.Errorf("key_column %q is not a column of the CSV", keyColumn)
+	}
+
+	columnNames := make([]string, 0, len(columns))
+	for column, property := range columns {
+		if _, ok := header[column]; !ok {
+			return nil, fmt.Errorf("column %q is not a column of the CSV", column)
+		}
+		if _, ok := propTypes[property]; !ok {
+			return nil, fmt.Errorf("column %q maps to %q, which is not a property of the database", column, property)
+		}
+		columnNames = append(columnNames, column)
+	}
+	sort.Strings(columnNames)
+
+	switch propTypes[columns[keyColumn]] {
+	case notionapi.PropertyConfigTypeTitle, notionapi.PropertyConfigTypeRichText:
+	default:
+		return nil, fmt.Errorf("key_column %q maps to %q, a %s property; it must map to the title or a rich_text property",
+			keyColumn, columns[keyColumn], propTypes[columns[keyColumn]])
+	}
+
+	rows := make([]importRow, 0, len(records)-1)
+	seen := map[string]int{}
+	for i, record := range records[1:] {
+		number := i + 2 // the header is row 1
+		key := strings.TrimSpace(record[keyIndex])
+		if key == "" {
+			return nil, fmt.Errorf("row %d: the %s column is empty", number, keyColumn)
+		}
+		if first, ok := seen[key]; ok {
+			return nil, fmt.Errorf("row %d: key %q already appears on row %d", number, key, first)
+		}
+		seen[key] = number
+
+		properties := notionapi.Properties{}
+		for _, column := range columnNames {
+			property := columns[column]
+			value, err := csvCellProperty(propTypes[property], record[header[column]])
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", number, column, err)
+			}
+			if value != nil {
+				properties[property] = value
+			}
+		}
+		rows = append(rows, importRow{key: key, number: number, properties: properties})
+	}
+	return rows, nil
+}
+
+// csvCellProperty converts a CSV cell to a value for a property of the
+// given type. Empty cells return nil, leaving the property as it is.
+// Multi-select cells are comma-separated option names.
+func csvCellProperty(propType notionapi.PropertyConfigType, cell string) (notionapi.Property, error) {
+	value := strings.TrimSpace(cell)
+	if value == "" {
+		return nil, nil
+	}
+
+	switch propType {
+	case notionapi.PropertyConfigTypeTitle:
+		return notionapi.TitleProperty{Type: notionapi.PropertyTypeTitle, Title: plainToRichText(value)}, nil
+	case notionapi.PropertyConfigTypeRichText:
+		return notionapi.RichTextProperty{Type: notionapi.PropertyTypeRichText, RichText: plainToRichText(value)}, nil
+	case notionapi.PropertyConfigTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: n}, nil
+	case notionapi.PropertyConfigTypeCheckbox:
+		b, err := strconv.ParseBool(strings.ToLower(value))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return notionapi.CheckboxProperty{Type: notionapi.PropertyTypeCheckbox, Checkbox: b}, nil
+	case notionapi.PropertyConfigTypeSelect:
+		return notionapi.SelectProperty{Type: notionapi.PropertyTypeSelect, Select: notionapi.Option{Name: value}}, nil
+	case notionapi.PropertyConfigStatus:
+		return notionapi.StatusProperty{Type: notionapi.PropertyTypeStatus, Status: notionapi.Option{Name: value}}, nil
+	case notionapi.PropertyConfigTypeMultiSelect:
+		var options []notionapi.Option
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				options = append(options, notionapi.Option{Name: name})
+			}
+		}
+		return notionapi.MultiSelectProperty{Type: notionapi.PropertyTypeMultiSelect, MultiSelect: options}, nil
+	case notionapi.PropertyConfigTypeURL:
+		return notionapi.URLProperty{Type: notionapi.PropertyTypeURL, URL: value}, nil
+	case notionapi.PropertyConfigTypeEmail:
+		return notionapi.EmailProperty{Type: notionapi.PropertyTypeEmail, Email: value}, nil
+	case notionapi.PropertyConfigTypePhoneNumber:
+		return notionapi.PhoneNumberProperty{Type: notionapi.PropertyTypePhoneNumber, PhoneNumber: value}, nil
+	case notionapi.PropertyConfigTypeDate:
+		t, _, err := parseNotionDate(value)
+		if err != nil {
+			return nil, err
+		}
+		d := notionapi.Date(t)
+		return notionapi.DateProperty{Type: notionapi.PropertyTypeDate, Date: &notionapi.DateObject{Start: &d}}, nil
+	default:
+		return nil, fmt.Errorf("importing into %s properties is not supported", propType)
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccDatabaseImportResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseImportConfig(parentPageID, "code,name\nSE,Sweden\nNO,Norway\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database_import.test", "entry_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("notion_database_import.test", "entry_ids.SE"),
+					resource.TestCheckResourceAttrSet("notion_database_import.test", "content_hash"),
+				),
+			},
+			{
+				Config: testAccDatabaseImportConfig(parentPageID, "code,name\nSE,Sweden\nFI,Finland\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database_import.test", "entry_ids.%", "2"),
+					resource.TestCheckNoResourceAttr("notion_database_import.test", "entry_ids.NO"),
+					resource.TestCheckResourceAttrSet("notion_database_import.test", "entry_ids.FI"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabaseImportConfig(parentPageID, csv string) string {
+	return fmt.Sprintf(`
+resource "notion_database" "test_import_parent" {
+  parent             = %q
+  title              = "CSV Import Test DB"
+  title_column_title = "Name"
+}
+
+resource "notion_database_property_rich_text" "code" {
+  database = notion_database.test_import_parent.id
+  name     = "Code"
+}
+
+resource "notion_database_import" "test" {
+  database    = notion_database.test_import_parent.id
+  csv_content = %q
+  key_column  = "code"
+  columns = {
+    code = notion_database_property_rich_text.code.name
+    name = "Name"
+  }
+}
+`, parentPageID, csv)
+}
+
+func TestParseImportCSV(t *testing.T) {
+	propTypes := map[string]notionapi.PropertyConfigType{
+		"Name":       notionapi.PropertyConfigTypeTitle,
+		"Code":       notionapi.PropertyConfigTypeRichText,
+		"Population": notionapi.PropertyConfigTypeNumber,
+		"Tags":       notionapi.PropertyConfigTypeMultiSelect,
+	}
+	columns := map[string]string{"code": "Code", "name": "Name", "population": "Population", "tags": "Tags"}
+
+	rows, err := parseImportCSV("code,name,population,tags,ignored\nSE,Sweden,10.5,\"Nordics, EU\",x\nNO,Norway,,Nordics,y\n", "code", columns, propTypes)
+	if err != nil {
+		t.Fatalf("parseImportCSV() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].key != "SE" || rows[0].number != 2 || rows[1].key != "NO" {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+	if n, ok := rows[0].properties["Population"].(notionapi.NumberProperty); !ok || n.Number != 10.5 {
+		t.Errorf("Population = %#v, want 10.5", rows[0].properties["Population"])
+	}
+	if ms, ok := rows[0].properties["Tags"].(notionapi.MultiSelectProperty); !ok || len(ms.MultiSelect) != 2 || ms.MultiSelect[1].Name != "EU" {
+		t.Errorf("Tags = %#v, want [Nordics EU]", rows[0].properties["Tags"])
+	}
+	if _, ok := rows[1].properties["Population"]; ok {
+		t.Errorf("empty cell should leave Population unset")
+	}
+
+	tests := []struct {
+		name    string
+		csv     string
+		key     string
+		columns map[string]string
+		wantErr string
+	}{
+		{"missing column", "code,title\nSE,Sweden\n", "code", columns, "is not a column of the CSV"},
+		{"unknown property", "code,name\nSE,Sweden\n", "code", map[string]string{"code": "Code", "name": "Title"}, "not a property of the database"},
+		{"key not text", "code,population\nSE,10\n", "population", map[string]string{"code": "Code", "population": "Population"}, "must map to the title or a rich_text property"},
+		{"empty key", "code,name\n,Sweden\n", "code", map[string]string{"code": "Code", "name": "Name"}, "row 2: the code column is empty"},
+		{"duplicate key", "code,name\nSE,Sweden\nSE,Sverige\n", "code", map[string]string{"code": "Code", "name": "Name"}, "already appears on row 2"},
+		{"bad number", "code,population\nSE,many\n", "code", map[string]string{"code": "Code", "population": "Population"}, "is not a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseImportCSV(tt.csv, tt.key, tt.columns, propTypes)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseImportCSV() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatabaseImportResourceDeleteIgnoresGoneRows(t *testing.T) {
+	prev := notionHTTPClient
+	t.Cleanup(func() { notionHTTPClient = prev })
+
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		// Deleted outside Terraform.
+		"p1": {http.StatusNotFound, `{"object":"error","status":404,"code":"object_not_found","message":"Could not find page"}`},
+		// Trashed outside Terraform.
+		"p2": {http.StatusBadRequest, `{"object":"error","status":400,"code":"validation_error","message":"Can't edit block that is archived. You must unarchive the block before editing."}`},
+		"p3": {http.StatusOK, `{"object":"page","id":"p3","in_trash":true}`},
+	}
+	var trashed []string
+	notionHTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			id := path.Base(req.URL.Path)
+			trashed = append(trashed, id)
+			return &http.Response{
+				StatusCode: responses[id].status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(responses[id].body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	ctx := context.Background()
+	client := notionapi.NewClient("test-token")
+	registerClientToken(client, "test-token")
+	r := &DatabaseImportResource{client: client}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	entryIDs, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"a": "p1", "b": "p2", "c": "p3"})
+	diags := state.Set(ctx, &DatabaseImportResourceModel{
+		ID:        types.StringValue("db"),
+		Database:  NewNotionIDValue("db"),
+		Columns:   types.MapNull(types.StringType),
+		KeyColumn: types.StringValue("Code"),
+		EntryIDs:  entryIDs,
+	})
+	if diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	var resp fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if strings.Join(trashed, ",") != "p1,p2,p3" {
+		t.Errorf("trashed %v, want p1, p2 and p3", trashed)
+	}
+}
@@ -0,0 +1,532 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                   = &DatabasePropertiesResource{}
+	_ resource.ResourceWithImportState    = &DatabasePropertiesResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabasePropertiesResource{}
+	_ resource.ResourceWithValidateConfig = &DatabasePropertiesResource{}
+)
+
+// databasePropertiesTypes are the property types notion_database_properties
+// can manage. Relations and rollups reference other databases and keep their
+// own resources.
+var databasePropertiesTypes = []string{
+	"rich_text", "number", "select", "multi_select", "status",
+	"date", "people", "checkbox", "url", "email",
+	"created_time", "created_by", "last_edited_time", "last_edited_by",
+}
+
+func isDatabasePropertiesType(propertyType string) bool {
+	for _, t := range databasePropertiesTypes {
+		if t == propertyType {
+			return true
+		}
+	}
+	return false
+}
+
+// DatabasePropertiesResource manages several properties of an existing
+// database from one map, writing every change in a single Database.Update
+// (shared with any property resources updating the database at the same
+// time; see database_property_batch.go).
+// It's for databases whose notion_database resource lives elsewhere (or
+// nowhere), where one resource per property would mean one schema write per
+// property.
+type DatabasePropertiesResource struct {
+	client   *notionapi.Client
+	provider *providerData
+}
+
+type DatabasePropertiesResourceModel struct {
+	ID          types.String                         `tfsdk:"id"`
+	Database    NotionIDValue                        `tfsdk:"database"`
+	Properties  map[string]DatabasePropertySpecModel `tfsdk:"properties"`
+	PropertyIDs types.Map                            `tfsdk:"property_ids"`
+}
+
+// DatabasePropertySpecModel is one property of notion_database_properties.
+type DatabasePropertySpecModel struct {
+	Type    types.String `tfsdk:"type"`
+	Format  types.String `tfsdk:"format"`
+	Options types.Map    `tfsdk:"options"`
+}
+
+func (m DatabasePropertySpecModel) equal(other DatabasePropertySpecModel) bool {
+	return m.Type.Equal(other.Type) && m.Format.Equal(other.Format) && m.Options.Equal(other.Options)
+}
+
+// config returns the Notion configuration of the property.
+func (m DatabasePropertySpecModel) config(ctx context.Context) (notionapi.PropertyConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	propertyType := notionapi.PropertyConfigType(m.Type.ValueString())
+
+	var options []notionapi.Option
+	if !m.Options.IsNull() {
+		options, diags = buildSelectOptions(ctx, m.Options)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	switch propertyType {
+	case notionapi.PropertyConfigTypeNumber:
+		format := "number"
+		if !m.Format.IsNull() {
+			format = m.Format.ValueString()
+		}
+		return notionapi.NumberPropertyConfig{
+			Type:   propertyType,
+			Number: notionapi.NumberFormat{Format: notionapi.FormatType(format)},
+		}, diags
+	case notionapi.PropertyConfigTypeSelect:
+		return notionapi.SelectPropertyConfig{Type: propertyType, Select: notionapi.Select{Options: options}}, diags
+	case notionapi.PropertyConfigTypeMultiSelect:
+		return notionapi.MultiSelectPropertyConfig{Type: propertyType, MultiSelect: notionapi.Select{Options: options}}, diags
+	case notionapi.PropertyConfigStatus:
+		return notionapi.StatusPropertyConfig{Type: propertyType, Status: notionapi.StatusConfig{Options: options}}, diags
+	}
+
+	config := basicPropertyConfig(propertyType)
+	if config == nil {
+		diags.AddError("Unsupported property type", fmt.Sprintf("notion_database_properties can't manage %q properties.", propertyType))
+	}
+	return config, diags
+}
+
+// databasePropertySpecFromConfig reads a property's configuration back into
+// prior's shape: format and options are only filled in when prior has them,
+// so leaving them unset in configuration doesn't produce a diff.
+func databasePropertySpecFromConfig(ctx context.Context, prior DatabasePropertySpecModel, prop notionapi.PropertyConfig) (DatabasePropertySpecModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	spec := DatabasePropertySpecModel{
+		Type:    types.StringValue(string(prop.GetType())),
+		Format:  types.StringNull(),
+		Options: types.MapNull(types.StringType),
+	}
+
+	var options []notionapi.Option
+	hasOptions := false
+	switch p := prop.(type) {
+	case *notionapi.NumberPropertyConfig:
+		if !prior.Format.IsNull() {
+			spec.Format = types.StringValue(string(p.Number.Format))
+		}
+	case *notionapi.SelectPropertyConfig:
+		options, hasOptions = p.Select.Options, true
+	case *notionapi.MultiSelectPropertyConfig:
+		options, hasOptions = p.MultiSelect.Options, true
+	case *notionapi.StatusPropertyConfig:
+		options, hasOptions = p.Status.Options, true
+	}
+
+	if hasOptions && !prior.Options.IsNull() {
+		optionsMap := make(map[string]string, len(options))
+		for _, opt := range options {
+			optionsMap[opt.Name] = string(opt.Color)
+		}
+		spec.Options, diags = types.MapValueFrom(ctx, types.StringType, optionsMap)
+	}
+	return spec, diags
+}
+
+// databasePropertiesChanges returns the property configurations a single
+// Database.Update needs to turn prior into next: every added or changed
+// property, and a nil entry for every removed one.
+func databasePropertiesChanges(ctx context.Context, prior, next map[string]DatabasePropertySpecModel) (notionapi.PropertyConfigs, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	changes := notionapi.PropertyConfigs{}
+	for name, spec := range next {
+		if old, ok := prior[name]; ok && old.equal(spec) {
+			continue
+		}
+		config, d := spec.config(ctx)
+		diags.Append(d...)
+		changes[name] = config
+	}
+	for name := range prior {
+		if _, ok := next[name]; !ok {
+			changes[name] = nil
+		}
+	}
+	return changes, diags
+}
+
+func NewDatabasePropertiesResource() resource.Resource {
+	return &DatabasePropertiesResource{}
+}
+
+func (r *DatabasePropertiesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_properties"
+}
+
+func (r *DatabasePropertiesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of properties on an existing Notion database, written in one update request.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the database.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "The ID of the database.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"properties": schema.MapNestedAttribute{
+				Description: "The properties, keyed by name. Properties of the database not listed here are left alone.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The property type: " + strings.Join(databasePropertiesTypes, ", ") + ".",
+							Required:    true,
+						},
+						"format": schema.StringAttribute{
+							Description: "The number format, for number properties. Defaults to number.",
+							Optional:    true,
+							Validators: []validator.String{
+								NumberFormatValidator(),
+							},
+						},
+						"options": schema.MapAttribute{
+							Description: "Map of option label to color, for select, multi_select and status properties.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"property_ids": schema.MapAttribute{
+				Description: "The ID of each property, keyed like properties.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *DatabasePropertiesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertiesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var properties map[string]DatabasePropertySpecModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("properties"), &properties)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, spec := range properties {
+		if spec.Type.IsUnknown() {
+			continue
+		}
+		at := path.Root("properties").AtMapKey(name)
+		propertyType := spec.Type.ValueString()
+		if !isDatabasePropertiesType(propertyType) {
+			resp.Diagnostics.AddAttributeError(at.AtName("type"), "Invalid Property Type",
+				fmt.Sprintf("Expected one of: %s, got: %s", strings.Join(databasePropertiesTypes, ", "), propertyType))
+			continue
+		}
+		if !spec.Format.IsNull() && propertyType != "number" {
+			resp.Diagnostics.AddAttributeError(at.AtName("format"), "Invalid Attribute Combination",
+				fmt.Sprintf("format only applies to number properties, not %s.", propertyType))
+		}
+		if !spec.Options.IsNull() && propertyType != "select" && propertyType != "multi_select" && propertyType != "status" {
+			resp.Diagnostics.AddAttributeError(at.AtName("options"), "Invalid Attribute Combination",
+				fmt.Sprintf("options only applies to select, multi_select and status properties, not %s.", propertyType))
+		}
+	}
+}
+
+// ModifyPlan keeps property_ids when no property is added or removed, and
+// fails the plan when a new property would take the title property's name
+// (see modifyPropertyPlan).
+func (r *DatabasePropertiesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan DatabasePropertiesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Database.IsUnknown() || plan.Properties == nil {
+		return
+	}
+
+	var prior map[string]DatabasePropertySpecModel
+	if !req.State.Raw.IsNull() {
+		var state DatabasePropertiesResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Database.ValueNotionID() == plan.Database.ValueNotionID() {
+			prior = state.Properties
+			if sameMapKeys(prior, plan.Properties) {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("property_ids"), state.PropertyIDs)...)
+			}
+		}
+	}
+
+	var added []string
+	for name := range plan.Properties {
+		if _, ok := prior[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	db, err := getDatabaseSchema(ctx, r.client, plan.Database.ValueNotionID())
+	if err != nil {
+		// Create reports an inaccessible database properly.
+		return
+	}
+	sort.Strings(added)
+	for _, name := range added {
+		if prop, ok := db.Properties[name]; ok && prop.GetType() == notionapi.PropertyConfigTypeTitle {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("properties").AtMapKey(name),
+				"Property Name Collides With Title Property",
+				fmt.Sprintf("%q is the title property of database %s and can't be managed by notion_database_properties. "+
+					"Choose a different name, or rename the title column with the title_column_title attribute of notion_database.",
+					name, plan.Database.ValueNotionID()),
+			)
+		}
+	}
+}
+
+// sameMapKeys reports whether a and b have the same keys.
+func sameMapKeys[V any](a, b map[string]V) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *DatabasePropertiesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DatabasePropertiesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.Database.ValueNotionID())
+	r.write(ctx, &plan, nil, types.MapNull(types.StringType), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabasePropertiesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DatabasePropertiesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	db, err := getDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+
+	ids := map[string]string{}
+	if !state.PropertyIDs.IsNull() && !state.PropertyIDs.IsUnknown() {
+		resp.Diagnostics.Append(state.PropertyIDs.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	properties := make(map[string]DatabasePropertySpecModel, len(state.Properties))
+	nextIDs := make(map[string]string, len(state.Properties))
+	for name, prior := range state.Properties {
+		remoteName, prop, ok := lookupDatabaseProperty(db, ids[name], name)
+		if !ok {
+			continue
+		}
+		warnIfPropertyRenamed(&resp.Diagnostics, string(prop.GetID()), name, remoteName)
+		spec, diags := databasePropertySpecFromConfig(ctx, prior, prop)
+		resp.Diagnostics.Append(diags...)
+		properties[remoteName] = spec
+		nextIDs[remoteName] = string(prop.GetID())
+	}
+
+	idsValue, diags := types.MapValueFrom(ctx, types.StringType, nextIDs)
+	resp.Diagnostics.Append(diags...)
+	state.Properties = properties
+	state.PropertyIDs = idsValue
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DatabasePropertiesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DatabasePropertiesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	r.write(ctx, &plan, state.Properties, state.PropertyIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// write sends the changes from prior to plan.Properties in one request and
+// fills in plan.PropertyIDs. priorIDs is kept when there is nothing to send.
+func (r *DatabasePropertiesResource) write(ctx context.Context, plan *DatabasePropertiesResourceModel, prior map[string]DatabasePropertySpecModel, priorIDs types.Map, diags *diag.Diagnostics) {
+	changes, d := databasePropertiesChanges(ctx, prior, plan.Properties)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	if len(changes) == 0 {
+		plan.PropertyIDs = priorIDs
+		return
+	}
+
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
+		Properties: changes,
+	})
+	if err != nil {
+		diags.AddError("Error updating database properties", err.Error())
+		return
+	}
+
+	ids := make(map[string]string, len(plan.Properties))
+	for name := range plan.Properties {
+		if prop, ok := db.Properties[name]; ok {
+			ids[name] = string(prop.GetID())
+		}
+	}
+	plan.PropertyIDs, d = types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+}
+
+func (r *DatabasePropertiesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DatabasePropertiesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	db, err := getDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+
+	ids := map[string]string{}
+	if !state.PropertyIDs.IsNull() {
+		resp.Diagnostics.Append(state.PropertyIDs.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Only delete properties that still exist, under their current names;
+	// the title property can't be deleted at all.
+	removals := notionapi.PropertyConfigs{}
+	for name := range state.Properties {
+		remoteName, prop, ok := lookupDatabaseProperty(db, ids[name], name)
+		if !ok || prop.GetType() == notionapi.PropertyConfigTypeTitle {
+			continue
+		}
+		removals[remoteName] = nil
+	}
+	if len(removals) == 0 {
+		return
+	}
+
+	_, err = r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(state.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
+		Properties: removals,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting database properties", err.Error())
+	}
+}
+
+// ImportState adopts every property of the database that this resource can
+// manage, with their current format and options. The import ID is the
+// database ID.
+func (r *DatabasePropertiesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	databaseID := canonicalNotionID(req.ID)
+	db, err := getDatabaseSchema(ctx, r.client, databaseID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database", err.Error())
+		return
+	}
+
+	// A prior spec with format and options set makes
+	// databasePropertySpecFromConfig fill both in.
+	withSettings := DatabasePropertySpecModel{
+		Format:  types.StringValue(""),
+		Options: types.MapValueMust(types.StringType, nil),
+	}
+	properties := map[string]DatabasePropertySpecModel{}
+	ids := map[string]string{}
+	for name, prop := range db.Properties {
+		if !isDatabasePropertiesType(string(prop.GetType())) {
+			continue
+		}
+		spec, diags := databasePropertySpecFromConfig(ctx, withSettings, prop)
+		resp.Diagnostics.Append(diags...)
+		properties[name] = spec
+		ids[name] = string(prop.GetID())
+	}
+
+	idsValue, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &DatabasePropertiesResourceModel{
+		ID:          types.StringValue(databaseID),
+		Database:    NewNotionIDValue(databaseID),
+		Properties:  properties,
+		PropertyIDs: idsValue,
+	})...)
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccDatabasePropertiesResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabasePropertiesConfig(parentPageID, `
+    "Notes"    = { type = "rich_text" }
+    "Price"    = { type = "number", format = "dollar" }
+    "Priority" = { type = "select", options = { "High" = "red", "Low" = "gray" } }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database_properties.test", "property_ids.%", "3"),
+					resource.TestCheckResourceAttrSet("notion_database_properties.test", "property_ids.Price"),
+					resource.TestCheckResourceAttr("notion_database_properties.test", "properties.Priority.options.High", "red"),
+				),
+			},
+			{
+				Config: testAccDatabasePropertiesConfig(parentPageID, `
+    "Price"    = { type = "number", format = "euro" }
+    "Priority" = { type = "select", options = { "High" = "red", "Low" = "gray" } }
+    "Done"     = { type = "checkbox" }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database_properties.test", "property_ids.%", "3"),
+					resource.TestCheckNoResourceAttr("notion_database_properties.test", "property_ids.Notes"),
+					resource.TestCheckResourceAttr("notion_database_properties.test", "properties.Price.format", "euro"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabasePropertiesConfig(parentPageID, properties string) string {
+	return fmt.Sprintf(`
+resource "notion_database" "properties_test" {
+  parent             = %q
+  title              = "Properties Test DB"
+  title_column_title = "Name"
+}
+
+resource "notion_database_properties" "test" {
+  database = notion_database.properties_test.id
+
+  properties = {%s  }
+}
+`, parentPageID, properties)
+}
+
+func TestDatabasePropertiesChanges(t *testing.T) {
+	ctx := context.Background()
+	spec := func(propertyType string, options map[string]string) DatabasePropertySpecModel {
+		m := DatabasePropertySpecModel{
+			Type:    types.StringValue(propertyType),
+			Format:  types.StringNull(),
+			Options: types.MapNull(types.StringType),
+		}
+		if options != nil {
+			elems := map[string]attr.Value{}
+			for k, v := range options {
+				elems[k] = types.StringValue(v)
+			}
+			m.Options = types.MapValueMust(types.StringType, elems)
+		}
+		return m
+	}
+
+	prior := map[string]DatabasePropertySpecModel{
+		"Notes":    spec("rich_text", nil),
+		"Priority": spec("select", map[string]string{"High": "red"}),
+		"Old":      spec("checkbox", nil),
+	}
+	next := map[string]DatabasePropertySpecModel{
+		"Notes":    spec("rich_text", nil),
+		"Priority": spec("select", map[string]string{"High": "red", "Low": "gray"}),
+		"Due":      spec("date", nil),
+	}
+
+	changes, diags := databasePropertiesChanges(ctx, prior, next)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d: %v", len(changes), changes)
+	}
+	if _, ok := changes["Notes"]; ok {
+		t.Error("unchanged property should not be sent")
+	}
+	if config, ok := changes["Old"]; !ok || config != nil {
+		t.Errorf("removed property should be sent as nil, got %v", config)
+	}
+	if _, ok := changes["Due"].(notionapi.DatePropertyConfig); !ok {
+		t.Errorf("expected a date config for Due, got %T", changes["Due"])
+	}
+	selectConfig, ok := changes["Priority"].(notionapi.SelectPropertyConfig)
+	if !ok || len(selectConfig.Select.Options) != 2 {
+		t.Errorf("expected a select config with 2 options for Priority, got %#v", changes["Priority"])
+	}
+
+	if changes, _ := databasePropertiesChanges(ctx, next, next); len(changes) != 0 {
+		t.Errorf("expected no changes for an unchanged map, got %v", changes)
+	}
+}
@@ -2,10 +2,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -14,9 +22,9 @@ import (
 
 // databasePropertyBaseModel is the shared model for all database property resources.
 type databasePropertyBaseModel struct {
-	ID       types.String `tfsdk:"id"`
-	Database types.String `tfsdk:"database"`
-	Name     types.String `tfsdk:"name"`
+	ID       types.String  `tfsdk:"id"`
+	Database NotionIDValue `tfsdk:"database"`
+	Name     types.String  `tfsdk:"name"`
 }
 
 // databasePropertyBaseSchema returns the common schema attributes for all database property resources.
@@ -31,9 +39,10 @@ func databasePropertyBaseSchema() map[string]schema.Attribute {
 		},
 		"database": schema.StringAttribute{
 			Description: "The ID of the parent database.",
+			CustomType:  NotionIDType{},
 			Required:    true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				RequiresReplaceIfNotionIDChanged(),
 			},
 		},
 		"name": schema.StringAttribute{
@@ -48,31 +57,333 @@ func databasePropertyBaseSchema() map[string]schema.Attribute {
 
 // readPropertyFromDatabase reads a property from a database and returns its ID and current name.
 func readPropertyFromDatabase(ctx context.Context, client *notionapi.Client, databaseID string, propertyName string, propertyID string) (string, string, error) {
-	db, err := client.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	db, err := getDatabaseSchema(ctx, client, databaseID)
 	if err != nil {
 		return "", "", fmt.Errorf("error reading database: %w", err)
 	}
 
-	// Try to find property by ID first, then by name
+	name, prop, ok := lookupDatabaseProperty(db, propertyID, propertyName)
+	if !ok {
+		return "", "", fmt.Errorf("property %q not found in database", propertyName)
+	}
+	return string(prop.GetID()), name, nil
+}
+
+// lookupDatabaseProperty finds the property a resource manages. Once the
+// property ID is known only an ID match counts: falling back to the name
+// would silently adopt a different column if the managed one was renamed or
+// deleted and another property took its old name. The name is only used
+// when no ID has been recorded yet (e.g. straight after import).
+func lookupDatabaseProperty(db *notionapi.Database, propertyID, propertyName string) (string, notionapi.PropertyConfig, bool) {
 	for name, prop := range db.Properties {
-		if string(prop.GetID()) == propertyID || name == propertyName {
-			return string(prop.GetID()), name, nil
+		if propertyID != "" {
+			if string(prop.GetID()) == propertyID {
+				return name, prop, true
+			}
+			continue
+		}
+		if name == propertyName {
+			return name, prop, true
+		}
+	}
+	return "", nil, false
+}
+
+// propertyIDPrivateKey is the private state key holding the ID of the
+// property a property resource manages, as last confirmed against the API.
+const propertyIDPrivateKey = "property_id"
+
+// privateStateGetter and privateStateSetter are the subsets of the
+// framework's private state data used here.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// trackedPropertyID returns the property ID recorded in private state,
+// falling back to the id attribute for resources created before it was
+// tracked.
+func trackedPropertyID(ctx context.Context, private privateStateGetter, stateID types.String) (string, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, propertyIDPrivateKey)
+	if diags.HasError() {
+		return "", diags
+	}
+	var id string
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &id); err != nil {
+			diags.AddError("Error reading private state", fmt.Sprintf("Decoding %s: %s", propertyIDPrivateKey, err))
+			return "", diags
 		}
 	}
+	if id == "" && !stateID.IsNull() && !stateID.IsUnknown() {
+		id = stateID.ValueString()
+	}
+	return id, diags
+}
+
+// trackPropertyID records the managed property's ID in private state.
+func trackPropertyID(ctx context.Context, private privateStateSetter, id string) diag.Diagnostics {
+	if id == "" {
+		return nil
+	}
+	raw, err := json.Marshal(id)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error writing private state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, propertyIDPrivateKey, raw)
+}
 
-	return "", "", fmt.Errorf("property %q not found in database", propertyName)
+// warnIfPropertyRenamed adds a warning when the tracked property now has a
+// different name than the one in state, so the resulting diff on name is
+// explained rather than looking like spontaneous drift.
+func warnIfPropertyRenamed(diags *diag.Diagnostics, propertyID, stateName, remoteName string) {
+	if stateName == "" || stateName == remoteName {
+		return
+	}
+	diags.AddWarning(
+		"Database property renamed outside Terraform",
+		fmt.Sprintf("Property %s was renamed from %q to %q outside Terraform. The next plan will replace it to restore the configured name; rename it back in Notion instead to keep the column's data.", propertyID, stateName, remoteName),
+	)
 }
 
 // deletePropertyFromDatabase removes a property from a database by setting it to nil.
-func deletePropertyFromDatabase(ctx context.Context, client *notionapi.Client, databaseID string, propertyName string) error {
-	_, err := client.Database.Update(ctx, notionapi.DatabaseID(databaseID), &notionapi.DatabaseUpdateRequest{
+// It refuses to touch the title property: Notion requires every database to
+// keep one, and its rejection of the request doesn't say why. The property
+// is found as lookupDatabaseProperty finds it, and a property that is
+// already gone is left alone.
+func deletePropertyFromDatabase(ctx context.Context, provider *providerData, databaseID, propertyID, propertyName string) error {
+	db, err := getDatabaseSchema(ctx, provider.client, databaseID)
+	if err != nil {
+		return fmt.Errorf("error reading database: %w", err)
+	}
+	name, prop, found := lookupDatabaseProperty(db, propertyID, propertyName)
+	if !found {
+		return nil
+	}
+	if prop.GetType() == notionapi.PropertyConfigTypeTitle {
+		return fmt.Errorf("property %q is the database's title property and cannot be deleted; remove the resource from state with `terraform state rm` instead", name)
+	}
+
+	_, err = provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(databaseID), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
-			propertyName: nil,
+			propertyUpdateKey(types.StringValue(string(prop.GetID())), name): nil,
 		},
 	})
 	return err
 }
 
+// propertyUpdateKey returns the key of a property in a Database.Update
+// request. The API takes a property ID as well as a name, and keyed by ID
+// a request only ever changes that one property, even if another request
+// renamed it or gave its name to another property in the meantime. id is
+// unknown or empty before the property exists.
+func propertyUpdateKey(id types.String, name string) string {
+	if id.IsNull() || id.IsUnknown() || id.ValueString() == "" {
+		return name
+	}
+	return id.ValueString()
+}
+
+// modifyPropertyPlan fails the plan when a property resource is about to be
+// created with the name of the database's title property. Creating it would
+// retype the title column (which Notion rejects with an unhelpful error) and
+// destroying it later would try to delete the title column. Shared by the
+// ModifyPlan method of every property resource.
+func modifyPropertyPlan(ctx context.Context, client *notionapi.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Destroy plans, and validation before the provider is configured.
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+
+	var database NotionIDValue
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("database"), &database)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if database.IsUnknown() || database.IsNull() || name.IsUnknown() || name.IsNull() {
+		return
+	}
+
+	// Only a create (including a replacement) can collide; an existing
+	// property keeps the name it was created with.
+	if !req.State.Raw.IsNull() {
+		var stateName types.String
+		var stateDatabase NotionIDValue
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("database"), &stateDatabase)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateName.ValueString() == name.ValueString() && stateDatabase.ValueNotionID() == database.ValueNotionID() {
+			return
+		}
+	}
+
+	db, err := getDatabaseSchema(ctx, client, database.ValueNotionID())
+	if err != nil {
+		// The database may not exist yet or may be inaccessible; Create
+		// will report that properly.
+		return
+	}
+	if prop, ok := db.Properties[name.ValueString()]; ok && prop.GetType() == notionapi.PropertyConfigTypeTitle {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Property Name Collides With Title Property",
+			fmt.Sprintf("%q is the title property of database %s. Every Notion database has exactly one title property and it "+
+				"can't be retyped or deleted, so it can't be managed by a property resource. Choose a different name, or rename "+
+				"the title column with the title_column_title attribute of notion_database.", name.ValueString(), database.ValueNotionID()),
+		)
+	}
+}
+
+// allowOptionRemovalAttribute is the schema attribute shared by the select
+// and multi-select property resources.
+func allowOptionRemovalAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Allow removing options that entries still use. Notion clears the value on those entries, so by " +
+			"default such a plan fails; set this to true to proceed with a warning instead.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// checkOptionRemoval guards option removal on select and multi-select
+// properties. Dropping an option from the schema silently clears it on every
+// entry that uses it, so when the plan removes options still referenced by
+// entries it fails, or only warns if allow_option_removal is set. filterKind
+// is the property type ("select" or "multi_select") used to query entries.
+func checkOptionRemoval(ctx context.Context, client *notionapi.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, filterKind string) {
+	// Creates, destroys, and validation before the provider is configured.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || client == nil {
+		return
+	}
+
+	var planOptions, stateOptions types.Map
+	var planName, stateName types.String
+	var planDatabase, stateDatabase NotionIDValue
+	var allow types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("options"), &planOptions)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("options"), &stateOptions)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("database"), &planDatabase)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("database"), &stateDatabase)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_option_removal"), &allow)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planOptions.IsUnknown() || planOptions.IsNull() || stateOptions.IsNull() {
+		return
+	}
+	// A replacement deletes the whole property; the option check is moot.
+	if planName.ValueString() != stateName.ValueString() || planDatabase.ValueNotionID() != stateDatabase.ValueNotionID() {
+		return
+	}
+
+	removed := removedMapKeys(stateOptions, planOptions)
+	if len(removed) == 0 {
+		return
+	}
+
+	token, err := tokenForClient(client)
+	if err != nil {
+		return
+	}
+	var inUse []string
+	for _, option := range removed {
+		used, err := optionInUse(ctx, token, stateDatabase.ValueNotionID(), stateName.ValueString(), filterKind, option)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(path.Root("options"), "Could Not Check Option Usage",
+				fmt.Sprintf("Checking whether entries use option %q failed: %s", option, err))
+			continue
+		}
+		if used {
+			inUse = append(inUse, option)
+		}
+	}
+	if len(inUse) == 0 {
+		return
+	}
+
+	quoted := make([]string, len(inUse))
+	for i, option := range inUse {
+		quoted[i] = fmt.Sprintf("%q", option)
+	}
+	detail := fmt.Sprintf("Removing option(s) %s from property %q clears them on every entry that uses them, and the values can't be recovered by adding the option back.",
+		strings.Join(quoted, ", "), stateName.ValueString())
+	if allow.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("options"), "Removing Options In Use", detail)
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("options"), "Removing Options In Use",
+		detail+" Move those entries to another option first, or set allow_option_removal = true to remove them anyway.")
+}
+
+// removedMapKeys returns the keys of prior that are missing from next, sorted.
+func removedMapKeys(prior, next types.Map) []string {
+	nextElems := next.Elements()
+	var removed []string
+	for key := range prior.Elements() {
+		if _, ok := nextElems[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// optionInUse reports whether any entry of the database has option set on
+// the named select ("select") or multi-select ("multi_select") property. It
+// queries the API directly so entries with property types the SDK can't
+// decode don't break the check.
+func optionInUse(ctx context.Context, token, databaseID, propertyName, filterKind, option string) (bool, error) {
+	condition := map[string]string{"equals": option}
+	if filterKind == "multi_select" {
+		condition = map[string]string{"contains": option}
+	}
+	body, err := json.Marshal(map[string]any{
+		"filter": map[string]any{
+			"property": propertyName,
+			filterKind: condition,
+		},
+		"page_size": 1,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	reqURL := fmt.Sprintf("%s/databases/%s/query", notionAPIBaseURL, databaseID)
+	httpResp, err := doNotionRequestWithVersion(ctx, http.MethodPost, reqURL, token, notionSDKAPIVersion, body)
+	if err != nil {
+		return false, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return false, err
+	}
+	if httpResp.StatusCode >= 400 {
+		return false, fmt.Errorf("notion API %d querying databases/%s: %s", httpResp.StatusCode, databaseID, string(respBody))
+	}
+
+	var result struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return false, err
+	}
+	return len(result.Results) > 0, nil
+}
+
 // parseCompositeID splits a composite ID of the form "database_id/property_name".
 func parseCompositeID(id string) (string, string, error) {
 	parts := strings.SplitN(id, "/", 2)
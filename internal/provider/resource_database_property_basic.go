@@ -14,6 +14,7 @@ import (
 var (
 	_ resource.Resource                = &DatabasePropertyBasicResource{}
 	_ resource.ResourceWithImportState = &DatabasePropertyBasicResource{}
+	_ resource.ResourceWithModifyPlan  = &DatabasePropertyBasicResource{}
 )
 
 // DatabasePropertyBasicResource handles the 10 simple property types that have no extra attributes.
@@ -21,6 +22,7 @@ type DatabasePropertyBasicResource struct {
 	client       *notionapi.Client
 	typeName     string
 	propertyType notionapi.PropertyConfigType
+	provider     *providerData
 }
 
 // newDatabasePropertyBasicResource creates a factory function for a basic property resource.
@@ -48,13 +50,18 @@ func (r *DatabasePropertyBasicResource) Configure(_ context.Context, req resourc
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertyBasicResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPropertyPlan(ctx, r.client, req, resp)
 }
 
 func (r *DatabasePropertyBasicResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -66,7 +73,7 @@ func (r *DatabasePropertyBasicResource) Create(ctx context.Context, req resource
 
 	propConfig := r.buildPropertyConfig()
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
 			plan.Name.ValueString(): propConfig,
 		},
@@ -78,6 +85,7 @@ func (r *DatabasePropertyBasicResource) Create(ctx context.Context, req resource
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -90,12 +98,21 @@ func (r *DatabasePropertyBasicResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	propID, propName, err := readPropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString(), state.ID.ValueString())
+	trackedID, diags := trackedPropertyID(ctx, req.Private, state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	propID, propName, err := readPropertyFromDatabase(ctx, r.client, state.Database.ValueNotionID(), state.Name.ValueString(), trackedID)
 	if err != nil {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	warnIfPropertyRenamed(&resp.Diagnostics, propID, state.Name.ValueString(), propName)
+	resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, propID)...)
+
 	state.ID = types.StringValue(propID)
 	state.Name = types.StringValue(propName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -119,7 +136,7 @@ func (r *DatabasePropertyBasicResource) Delete(ctx context.Context, req resource
 		return
 	}
 
-	err := deletePropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	err := deletePropertyFromDatabase(ctx, r.provider, state.Database.ValueNotionID(), state.ID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting property", err.Error())
 		return
@@ -133,32 +150,38 @@ func (r *DatabasePropertyBasicResource) ImportState(ctx context.Context, req res
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("database"), types.StringValue(databaseID))
+	resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(databaseID))
 	resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(propName))
 }
 
 func (r *DatabasePropertyBasicResource) buildPropertyConfig() notionapi.PropertyConfig {
-	switch r.propertyType {
+	return basicPropertyConfig(r.propertyType)
+}
+
+// basicPropertyConfig returns the configuration of a property type that has
+// no settings of its own, or nil for any other type.
+func basicPropertyConfig(propertyType notionapi.PropertyConfigType) notionapi.PropertyConfig {
+	switch propertyType {
 	case notionapi.PropertyConfigTypeRichText:
-		return notionapi.RichTextPropertyConfig{Type: r.propertyType, RichText: struct{}{}}
+		return notionapi.RichTextPropertyConfig{Type: propertyType, RichText: struct{}{}}
 	case notionapi.PropertyConfigTypeDate:
-		return notionapi.DatePropertyConfig{Type: r.propertyType, Date: struct{}{}}
+		return notionapi.DatePropertyConfig{Type: propertyType, Date: struct{}{}}
 	case notionapi.PropertyConfigTypePeople:
-		return notionapi.PeoplePropertyConfig{Type: r.propertyType, People: struct{}{}}
+		return notionapi.PeoplePropertyConfig{Type: propertyType, People: struct{}{}}
 	case notionapi.PropertyConfigTypeCheckbox:
-		return notionapi.CheckboxPropertyConfig{Type: r.propertyType, Checkbox: struct{}{}}
+		return notionapi.CheckboxPropertyConfig{Type: propertyType, Checkbox: struct{}{}}
 	case notionapi.PropertyConfigTypeURL:
-		return notionapi.URLPropertyConfig{Type: r.propertyType, URL: struct{}{}}
+		return notionapi.URLPropertyConfig{Type: propertyType, URL: struct{}{}}
 	case notionapi.PropertyConfigTypeEmail:
-		return notionapi.EmailPropertyConfig{Type: r.propertyType, Email: struct{}{}}
+		return notionapi.EmailPropertyConfig{Type: propertyType, Email: struct{}{}}
 	case notionapi.PropertyConfigCreatedTime:
-		return notionapi.CreatedTimePropertyConfig{Type: r.propertyType, CreatedTime: struct{}{}}
+		return notionapi.CreatedTimePropertyConfig{Type: propertyType, CreatedTime: struct{}{}}
 	case notionapi.PropertyConfigCreatedBy:
-		return notionapi.CreatedByPropertyConfig{Type: r.propertyType, CreatedBy: struct{}{}}
+		return notionapi.CreatedByPropertyConfig{Type: propertyType, CreatedBy: struct{}{}}
 	case notionapi.PropertyConfigLastEditedTime:
-		return notionapi.LastEditedTimePropertyConfig{Type: r.propertyType, LastEditedTime: struct{}{}}
+		return notionapi.LastEditedTimePropertyConfig{Type: propertyType, LastEditedTime: struct{}{}}
 	case notionapi.PropertyConfigLastEditedBy:
-		return notionapi.LastEditedByPropertyConfig{Type: r.propertyType, LastEditedBy: struct{}{}}
+		return notionapi.LastEditedByPropertyConfig{Type: propertyType, LastEditedBy: struct{}{}}
 	default:
 		return nil
 	}
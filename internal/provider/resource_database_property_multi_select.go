@@ -14,19 +14,24 @@ import (
 )
 
 var (
-	_ resource.Resource                = &DatabasePropertyMultiSelectResource{}
-	_ resource.ResourceWithImportState = &DatabasePropertyMultiSelectResource{}
+	_ resource.Resource                 = &DatabasePropertyMultiSelectResource{}
+	_ resource.ResourceWithImportState  = &DatabasePropertyMultiSelectResource{}
+	_ resource.ResourceWithModifyPlan   = &DatabasePropertyMultiSelectResource{}
+	_ resource.ResourceWithUpgradeState = &DatabasePropertyMultiSelectResource{}
 )
 
 type DatabasePropertyMultiSelectResource struct {
-	client *notionapi.Client
+	client   *notionapi.Client
+	provider *providerData
 }
 
 type DatabasePropertyMultiSelectModel struct {
-	ID       types.String `tfsdk:"id"`
-	Database types.String `tfsdk:"database"`
-	Name     types.String `tfsdk:"name"`
-	Options  types.Map    `tfsdk:"options"`
+	ID       types.String  `tfsdk:"id"`
+	Database NotionIDValue `tfsdk:"database"`
+	Name     types.String  `tfsdk:"name"`
+	Options  types.Map     `tfsdk:"options"`
+
+	AllowOptionRemoval types.Bool `tfsdk:"allow_option_removal"`
 }
 
 func NewDatabasePropertyMultiSelectResource() resource.Resource {
@@ -39,6 +44,7 @@ func (r *DatabasePropertyMultiSelectResource) Metadata(_ context.Context, req re
 
 func (r *DatabasePropertyMultiSelectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion(databasePropertyMultiSelectStateMigrations),
 		Description: "Manages a multi-select property on a Notion database.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -50,9 +56,10 @@ func (r *DatabasePropertyMultiSelectResource) Schema(_ context.Context, _ resour
 			},
 			"database": schema.StringAttribute{
 				Description: "The ID of the parent database.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -67,21 +74,32 @@ func (r *DatabasePropertyMultiSelectResource) Schema(_ context.Context, _ resour
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"allow_option_removal": allowOptionRemovalAttribute(),
 		},
 	}
 }
 
+func (r *DatabasePropertyMultiSelectResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return migratedStateUpgraders(databasePropertyMultiSelectStateMigrations)
+}
+
 func (r *DatabasePropertyMultiSelectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertyMultiSelectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPropertyPlan(ctx, r.client, req, resp)
+	checkOptionRemoval(ctx, r.client, req, resp, "multi_select")
 }
 
 func (r *DatabasePropertyMultiSelectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -97,7 +115,7 @@ func (r *DatabasePropertyMultiSelectResource) Create(ctx context.Context, req re
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
 			plan.Name.ValueString(): notionapi.MultiSelectPropertyConfig{
 				Type:        notionapi.PropertyConfigTypeMultiSelect,
@@ -112,6 +130,7 @@ func (r *DatabasePropertyMultiSelectResource) Create(ctx context.Context, req re
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -124,36 +143,43 @@ func (r *DatabasePropertyMultiSelectResource) Read(ctx context.Context, req reso
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.Database.ValueString()))
+	db, err := getDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
 
-	found := false
-	for name, prop := range db.Properties {
-		if string(prop.GetID()) == state.ID.ValueString() || name == state.Name.ValueString() {
-			state.ID = types.StringValue(string(prop.GetID()))
-			state.Name = types.StringValue(name)
-
-			if msProp, ok := prop.(*notionapi.MultiSelectPropertyConfig); ok {
-				optionsMap := make(map[string]string)
-				for _, opt := range msProp.MultiSelect.Options {
-					optionsMap[opt.Name] = string(opt.Color)
-				}
-				mapVal, diags := types.MapValueFrom(ctx, types.StringType, optionsMap)
-				resp.Diagnostics.Append(diags...)
-				state.Options = mapVal
+	propertyID, diags := trackedPropertyID(ctx, req.Private, state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, prop, found := lookupDatabaseProperty(db, propertyID, state.Name.ValueString())
+	if found {
+		warnIfPropertyRenamed(&resp.Diagnostics, string(prop.GetID()), state.Name.ValueString(), name)
+		state.ID = types.StringValue(string(prop.GetID()))
+		state.Name = types.StringValue(name)
+
+		if msProp, ok := prop.(*notionapi.MultiSelectPropertyConfig); ok {
+			optionsMap := make(map[string]string)
+			for _, opt := range msProp.MultiSelect.Options {
+				optionsMap[opt.Name] = string(opt.Color)
 			}
-			found = true
-			break
+			mapVal, diags := types.MapValueFrom(ctx, types.StringType, optionsMap)
+			resp.Diagnostics.Append(diags...)
+			state.Options = mapVal
 		}
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, string(prop.GetID()))...)
 	}
 
 	if !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if state.AllowOptionRemoval.IsNull() {
+		state.AllowOptionRemoval = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -171,9 +197,9 @@ func (r *DatabasePropertyMultiSelectResource) Update(ctx context.Context, req re
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
-			plan.Name.ValueString(): notionapi.MultiSelectPropertyConfig{
+			propertyUpdateKey(plan.ID, plan.Name.ValueString()): notionapi.MultiSelectPropertyConfig{
 				Type:        notionapi.PropertyConfigTypeMultiSelect,
 				MultiSelect: notionapi.Select{Options: options},
 			},
@@ -186,6 +212,7 @@ func (r *DatabasePropertyMultiSelectResource) Update(ctx context.Context, req re
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -198,7 +225,7 @@ func (r *DatabasePropertyMultiSelectResource) Delete(ctx context.Context, req re
 		return
 	}
 
-	err := deletePropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	err := deletePropertyFromDatabase(ctx, r.provider, state.Database.ValueNotionID(), state.ID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting multi-select property", err.Error())
 		return
@@ -212,6 +239,6 @@ func (r *DatabasePropertyMultiSelectResource) ImportState(ctx context.Context, r
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("database"), types.StringValue(databaseID))
+	resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(databaseID))
 	resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(propName))
 }
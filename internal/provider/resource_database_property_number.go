@@ -17,17 +17,19 @@ import (
 var (
 	_ resource.Resource                = &DatabasePropertyNumberResource{}
 	_ resource.ResourceWithImportState = &DatabasePropertyNumberResource{}
+	_ resource.ResourceWithModifyPlan  = &DatabasePropertyNumberResource{}
 )
 
 type DatabasePropertyNumberResource struct {
-	client *notionapi.Client
+	client   *notionapi.Client
+	provider *providerData
 }
 
 type DatabasePropertyNumberModel struct {
-	ID       types.String `tfsdk:"id"`
-	Database types.String `tfsdk:"database"`
-	Name     types.String `tfsdk:"name"`
-	Format   types.String `tfsdk:"format"`
+	ID       types.String  `tfsdk:"id"`
+	Database NotionIDValue `tfsdk:"database"`
+	Name     types.String  `tfsdk:"name"`
+	Format   types.String  `tfsdk:"format"`
 }
 
 func NewDatabasePropertyNumberResource() resource.Resource {
@@ -51,9 +53,10 @@ func (r *DatabasePropertyNumberResource) Schema(_ context.Context, _ resource.Sc
 			},
 			"database": schema.StringAttribute{
 				Description: "The ID of the parent database.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -78,13 +81,18 @@ func (r *DatabasePropertyNumberResource) Configure(_ context.Context, req resour
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertyNumberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPropertyPlan(ctx, r.client, req, resp)
 }
 
 func (r *DatabasePropertyNumberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -94,7 +102,7 @@ func (r *DatabasePropertyNumberResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
 			plan.Name.ValueString(): notionapi.NumberPropertyConfig{
 				Type: notionapi.PropertyConfigTypeNumber,
@@ -111,6 +119,7 @@ func (r *DatabasePropertyNumberResource) Create(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -123,24 +132,28 @@ func (r *DatabasePropertyNumberResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.Database.ValueString()))
+	db, err := getDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
 
-	found := false
-	for name, prop := range db.Properties {
-		if string(prop.GetID()) == state.ID.ValueString() || name == state.Name.ValueString() {
-			state.ID = types.StringValue(string(prop.GetID()))
-			state.Name = types.StringValue(name)
+	propertyID, diags := trackedPropertyID(ctx, req.Private, state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, prop, found := lookupDatabaseProperty(db, propertyID, state.Name.ValueString())
+	if found {
+		warnIfPropertyRenamed(&resp.Diagnostics, string(prop.GetID()), state.Name.ValueString(), name)
+		state.ID = types.StringValue(string(prop.GetID()))
+		state.Name = types.StringValue(name)
 
-			if numProp, ok := prop.(*notionapi.NumberPropertyConfig); ok {
-				state.Format = types.StringValue(string(numProp.Number.Format))
-			}
-			found = true
-			break
+		if numProp, ok := prop.(*notionapi.NumberPropertyConfig); ok {
+			state.Format = types.StringValue(string(numProp.Number.Format))
 		}
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, string(prop.GetID()))...)
 	}
 
 	if !found {
@@ -158,9 +171,9 @@ func (r *DatabasePropertyNumberResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
-			plan.Name.ValueString(): notionapi.NumberPropertyConfig{
+			propertyUpdateKey(plan.ID, plan.Name.ValueString()): notionapi.NumberPropertyConfig{
 				Type: notionapi.PropertyConfigTypeNumber,
 				Number: notionapi.NumberFormat{
 					Format: notionapi.FormatType(plan.Format.ValueString()),
@@ -175,6 +188,7 @@ func (r *DatabasePropertyNumberResource) Update(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -187,7 +201,7 @@ func (r *DatabasePropertyNumberResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	err := deletePropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	err := deletePropertyFromDatabase(ctx, r.provider, state.Database.ValueNotionID(), state.ID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting number property", err.Error())
 		return
@@ -201,6 +215,6 @@ func (r *DatabasePropertyNumberResource) ImportState(ctx context.Context, req re
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("database"), types.StringValue(databaseID))
+	resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(databaseID))
 	resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(propName))
 }
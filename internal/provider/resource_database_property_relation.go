@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,17 +18,21 @@ import (
 var (
 	_ resource.Resource                = &DatabasePropertyRelationResource{}
 	_ resource.ResourceWithImportState = &DatabasePropertyRelationResource{}
+	_ resource.ResourceWithModifyPlan  = &DatabasePropertyRelationResource{}
 )
 
 type DatabasePropertyRelationResource struct {
-	client *notionapi.Client
+	client   *notionapi.Client
+	provider *providerData
 }
 
 type DatabasePropertyRelationModel struct {
-	ID              types.String `tfsdk:"id"`
-	Database        types.String `tfsdk:"database"`
-	Name            types.String `tfsdk:"name"`
-	RelatedDatabase types.String `tfsdk:"related_database"`
+	ID              types.String  `tfsdk:"id"`
+	Database        NotionIDValue `tfsdk:"database"`
+	Name            types.String  `tfsdk:"name"`
+	RelatedDatabase NotionIDValue `tfsdk:"related_database"`
+	SyncedName      types.String  `tfsdk:"synced_property_name"`
+	SyncedID        types.String  `tfsdk:"synced_property_id"`
 }
 
 func NewDatabasePropertyRelationResource() resource.Resource {
@@ -50,9 +56,10 @@ func (r *DatabasePropertyRelationResource) Schema(_ context.Context, _ resource.
 			},
 			"database": schema.StringAttribute{
 				Description: "The ID of the parent database.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -63,9 +70,30 @@ func (r *DatabasePropertyRelationResource) Schema(_ context.Context, _ resource.
 				},
 			},
 			"related_database": schema.StringAttribute{
-				Description: "The ID of the related database.",
+				Description: "The ID of the related database. It may be the database itself, e.g. for a parent task relation.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 			},
+			"synced_property_name": schema.StringAttribute{
+				Description: "Make the relation two-way: the name of the property Notion creates in the related database to " +
+					"show the relation from the other side. For a relation to the database itself, it is created in the same " +
+					"database, e.g. \"Sub-tasks\" for a \"Parent task\" relation. Changing it renames that property; setting or " +
+					"removing it forces a new resource.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+					}, "Switching between a one-way and a two-way relation forces a new resource.",
+						"Switching between a one-way and a two-way relation forces a new resource."),
+				},
+			},
+			"synced_property_id": schema.StringAttribute{
+				Description: "The ID of the property named by synced_property_name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -74,13 +102,34 @@ func (r *DatabasePropertyRelationResource) Configure(_ context.Context, req reso
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertyRelationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPropertyPlan(ctx, r.client, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DatabasePropertyRelationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.SyncedName.IsNull() || plan.SyncedName.IsUnknown() ||
+		plan.Name.IsUnknown() || plan.Database.IsUnknown() || plan.RelatedDatabase.IsUnknown() {
 		return
 	}
-	r.client = client
+	if plan.SyncedName.ValueString() == plan.Name.ValueString() &&
+		canonicalNotionID(plan.Database.ValueNotionID()) == canonicalNotionID(plan.RelatedDatabase.ValueNotionID()) {
+		resp.Diagnostics.AddAttributeError(path.Root("synced_property_name"), "Conflicting Synced Property Name",
+			fmt.Sprintf("The relation relates database %s to itself, so its synced property is created next to it and needs a "+
+				"name other than %q.", plan.Database.ValueNotionID(), plan.Name.ValueString()))
+	}
 }
 
 func (r *DatabasePropertyRelationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -90,16 +139,9 @@ func (r *DatabasePropertyRelationResource) Create(ctx context.Context, req resou
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
-			plan.Name.ValueString(): notionapi.RelationPropertyConfig{
-				Type: notionapi.PropertyConfigTypeRelation,
-				Relation: notionapi.RelationConfig{
-					DatabaseID:     notionapi.DatabaseID(plan.RelatedDatabase.ValueString()),
-					Type:           notionapi.RelationSingleProperty,
-					SingleProperty: &notionapi.SingleProperty{},
-				},
-			},
+			plan.Name.ValueString(): newRelationPropertyConfig(&plan),
 		},
 	})
 	if err != nil {
@@ -109,7 +151,9 @@ func (r *DatabasePropertyRelationResource) Create(ctx context.Context, req resou
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
+	resp.Diagnostics.Append(r.readSyncedProperty(ctx, &plan)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -121,24 +165,30 @@ func (r *DatabasePropertyRelationResource) Read(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.Database.ValueString()))
+	full, err := getFullDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
+	db := &full.Database
+
+	propertyID, diags := trackedPropertyID(ctx, req.Private, state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	found := false
-	for name, prop := range db.Properties {
-		if string(prop.GetID()) == state.ID.ValueString() || name == state.Name.ValueString() {
-			state.ID = types.StringValue(string(prop.GetID()))
-			state.Name = types.StringValue(name)
+	name, prop, found := lookupDatabaseProperty(db, propertyID, state.Name.ValueString())
+	if found {
+		warnIfPropertyRenamed(&resp.Diagnostics, string(prop.GetID()), state.Name.ValueString(), name)
+		state.ID = types.StringValue(string(prop.GetID()))
+		state.Name = types.StringValue(name)
 
-			if relProp, ok := prop.(*notionapi.RelationPropertyConfig); ok {
-				state.RelatedDatabase = types.StringValue(normalizeID(string(relProp.Relation.DatabaseID)))
-			}
-			found = true
-			break
+		if relProp, ok := prop.(*notionapi.RelationPropertyConfig); ok {
+			state.RelatedDatabase = NewNotionIDValue(normalizeID(string(relProp.Relation.DatabaseID)))
 		}
+		state.SyncedName, state.SyncedID = syncedProperty(full.RawProperties[name])
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, string(prop.GetID()))...)
 	}
 
 	if !found {
@@ -156,16 +206,34 @@ func (r *DatabasePropertyRelationResource) Update(ctx context.Context, req resou
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
-		Properties: notionapi.PropertyConfigs{
-			plan.Name.ValueString(): notionapi.RelationPropertyConfig{
-				Type: notionapi.PropertyConfigTypeRelation,
-				Relation: notionapi.RelationConfig{
-					DatabaseID:     notionapi.DatabaseID(plan.RelatedDatabase.ValueString()),
-					Type:           notionapi.RelationSingleProperty,
-					SingleProperty: &notionapi.SingleProperty{},
-				},
+	var state DatabasePropertyRelationModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The synced property is renamed through its own database, keyed by
+	// its ID; the SDK's property configs have no "name" field.
+	if !plan.SyncedName.IsNull() && !state.SyncedName.IsNull() && !plan.SyncedName.Equal(state.SyncedName) {
+		token, err := tokenForClient(r.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Error renaming synced relation property", err.Error())
+			return
+		}
+		err = patchDatabase(ctx, token, state.RelatedDatabase.ValueNotionID(), map[string]interface{}{
+			"properties": map[string]interface{}{
+				state.SyncedID.ValueString(): map[string]string{"name": plan.SyncedName.ValueString()},
 			},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error renaming synced relation property", err.Error())
+			return
+		}
+	}
+
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
+		Properties: notionapi.PropertyConfigs{
+			propertyUpdateKey(plan.ID, plan.Name.ValueString()): newRelationPropertyConfig(&plan),
 		},
 	})
 	if err != nil {
@@ -175,7 +243,9 @@ func (r *DatabasePropertyRelationResource) Update(ctx context.Context, req resou
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
+	resp.Diagnostics.Append(r.readSyncedProperty(ctx, &plan)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -187,11 +257,23 @@ func (r *DatabasePropertyRelationResource) Delete(ctx context.Context, req resou
 		return
 	}
 
-	err := deletePropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	err := deletePropertyFromDatabase(ctx, r.provider, state.Database.ValueNotionID(), state.ID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting relation property", err.Error())
 		return
 	}
+
+	// The synced property of a two-way relation was created along with
+	// it. Notion usually removes it too, but one left behind, which for a
+	// relation to the database itself sits next to the deleted one, would
+	// block recreating the relation under the same names.
+	if state.SyncedID.IsNull() || state.SyncedID.ValueString() == "" {
+		return
+	}
+	err = deletePropertyFromDatabase(ctx, r.provider, state.RelatedDatabase.ValueNotionID(), state.SyncedID.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting synced relation property", err.Error())
+	}
 }
 
 func (r *DatabasePropertyRelationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -201,6 +283,81 @@ func (r *DatabasePropertyRelationResource) ImportState(ctx context.Context, req
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("database"), types.StringValue(databaseID))
+	resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(databaseID))
 	resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(propName))
 }
+
+// readSyncedProperty records the name and ID of the synced property of a
+// two-way relation in m, reading them from the raw schema since the SDK
+// doesn't decode them.
+func (r *DatabasePropertyRelationResource) readSyncedProperty(ctx context.Context, m *DatabasePropertyRelationModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if m.SyncedName.IsNull() {
+		m.SyncedID = types.StringNull()
+		return diags
+	}
+	full, err := getFullDatabaseSchema(ctx, r.client, m.Database.ValueNotionID())
+	if err != nil {
+		diags.AddError("Error reading database", err.Error())
+		return diags
+	}
+	name, _, found := lookupDatabaseProperty(&full.Database, m.ID.ValueString(), m.Name.ValueString())
+	if !found {
+		diags.AddError("Error reading relation property", fmt.Sprintf("Property %q is missing after the update.", m.Name.ValueString()))
+		return diags
+	}
+	m.SyncedName, m.SyncedID = syncedProperty(full.RawProperties[name])
+	return diags
+}
+
+// relationPropertyConfig is notionapi.RelationPropertyConfig with the
+// dual_property settings the SDK can't send: it has no field for the name
+// of the synced property.
+type relationPropertyConfig struct {
+	Type     notionapi.PropertyConfigType `json:"type"`
+	Relation relationConfig               `json:"relation"`
+}
+
+type relationConfig struct {
+	DatabaseID     string              `json:"database_id"`
+	Type           string              `json:"type"`
+	SingleProperty *struct{}           `json:"single_property,omitempty"`
+	DualProperty   *dualPropertyConfig `json:"dual_property,omitempty"`
+}
+
+type dualPropertyConfig struct {
+	SyncedPropertyID   string `json:"synced_property_id,omitempty"`
+	SyncedPropertyName string `json:"synced_property_name,omitempty"`
+}
+
+func (c relationPropertyConfig) GetType() notionapi.PropertyConfigType { return c.Type }
+func (c relationPropertyConfig) GetID() notionapi.PropertyID           { return "" }
+
+// newRelationPropertyConfig returns the configuration of the relation m
+// describes: two-way when it has a synced_property_name, one-way
+// otherwise.
+func newRelationPropertyConfig(m *DatabasePropertyRelationModel) relationPropertyConfig {
+	config := relationPropertyConfig{
+		Type:     notionapi.PropertyConfigTypeRelation,
+		Relation: relationConfig{DatabaseID: m.RelatedDatabase.ValueNotionID()},
+	}
+	if m.SyncedName.IsNull() || m.SyncedName.IsUnknown() {
+		config.Relation.Type = string(notionapi.RelationSingleProperty)
+		config.Relation.SingleProperty = &struct{}{}
+		return config
+	}
+	config.Relation.Type = string(notionapi.RelationDualProperty)
+	config.Relation.DualProperty = &dualPropertyConfig{SyncedPropertyName: m.SyncedName.ValueString()}
+	return config
+}
+
+// syncedProperty returns the name and ID of the synced property of a raw
+// relation property configuration, or nulls for a one-way relation.
+func syncedProperty(raw json.RawMessage) (types.String, types.String) {
+	var config relationPropertyConfig
+	if err := json.Unmarshal(raw, &config); err != nil || config.Relation.DualProperty == nil {
+		return types.StringNull(), types.StringNull()
+	}
+	dual := config.Relation.DualProperty
+	return types.StringValue(dual.SyncedPropertyName), types.StringValue(dual.SyncedPropertyID)
+}
@@ -17,19 +17,21 @@ import (
 var (
 	_ resource.Resource                = &DatabasePropertyRollupResource{}
 	_ resource.ResourceWithImportState = &DatabasePropertyRollupResource{}
+	_ resource.ResourceWithModifyPlan  = &DatabasePropertyRollupResource{}
 )
 
 type DatabasePropertyRollupResource struct {
-	client *notionapi.Client
+	client   *notionapi.Client
+	provider *providerData
 }
 
 type DatabasePropertyRollupModel struct {
-	ID               types.String `tfsdk:"id"`
-	Database         types.String `tfsdk:"database"`
-	Name             types.String `tfsdk:"name"`
-	Function         types.String `tfsdk:"function"`
-	RelationProperty types.String `tfsdk:"relation_property"`
-	RollupProperty   types.String `tfsdk:"rollup_property"`
+	ID               types.String  `tfsdk:"id"`
+	Database         NotionIDValue `tfsdk:"database"`
+	Name             types.String  `tfsdk:"name"`
+	Function         types.String  `tfsdk:"function"`
+	RelationProperty types.String  `tfsdk:"relation_property"`
+	RollupProperty   types.String  `tfsdk:"rollup_property"`
 }
 
 func NewDatabasePropertyRollupResource() resource.Resource {
@@ -53,9 +55,10 @@ func (r *DatabasePropertyRollupResource) Schema(_ context.Context, _ resource.Sc
 			},
 			"database": schema.StringAttribute{
 				Description: "The ID of the parent database.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -88,13 +91,18 @@ func (r *DatabasePropertyRollupResource) Configure(_ context.Context, req resour
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertyRollupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPropertyPlan(ctx, r.client, req, resp)
 }
 
 func (r *DatabasePropertyRollupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -104,7 +112,7 @@ func (r *DatabasePropertyRollupResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
 			plan.Name.ValueString(): notionapi.RollupPropertyConfig{
 				Type: notionapi.PropertyConfigTypeRollup,
@@ -123,6 +131,7 @@ func (r *DatabasePropertyRollupResource) Create(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -135,26 +144,30 @@ func (r *DatabasePropertyRollupResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.Database.ValueString()))
+	db, err := getDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
 
-	found := false
-	for name, prop := range db.Properties {
-		if string(prop.GetID()) == state.ID.ValueString() || name == state.Name.ValueString() {
-			state.ID = types.StringValue(string(prop.GetID()))
-			state.Name = types.StringValue(name)
+	propertyID, diags := trackedPropertyID(ctx, req.Private, state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, prop, found := lookupDatabaseProperty(db, propertyID, state.Name.ValueString())
+	if found {
+		warnIfPropertyRenamed(&resp.Diagnostics, string(prop.GetID()), state.Name.ValueString(), name)
+		state.ID = types.StringValue(string(prop.GetID()))
+		state.Name = types.StringValue(name)
 
-			if rollupProp, ok := prop.(*notionapi.RollupPropertyConfig); ok {
-				state.Function = types.StringValue(string(rollupProp.Rollup.Function))
-				state.RelationProperty = types.StringValue(rollupProp.Rollup.RelationPropertyName)
-				state.RollupProperty = types.StringValue(rollupProp.Rollup.RollupPropertyName)
-			}
-			found = true
-			break
+		if rollupProp, ok := prop.(*notionapi.RollupPropertyConfig); ok {
+			state.Function = types.StringValue(string(rollupProp.Rollup.Function))
+			state.RelationProperty = types.StringValue(rollupProp.Rollup.RelationPropertyName)
+			state.RollupProperty = types.StringValue(rollupProp.Rollup.RollupPropertyName)
 		}
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, string(prop.GetID()))...)
 	}
 
 	if !found {
@@ -172,9 +185,9 @@ func (r *DatabasePropertyRollupResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
-			plan.Name.ValueString(): notionapi.RollupPropertyConfig{
+			propertyUpdateKey(plan.ID, plan.Name.ValueString()): notionapi.RollupPropertyConfig{
 				Type: notionapi.PropertyConfigTypeRollup,
 				Rollup: notionapi.RollupConfig{
 					RelationPropertyName: plan.RelationProperty.ValueString(),
@@ -191,6 +204,7 @@ func (r *DatabasePropertyRollupResource) Update(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -203,7 +217,7 @@ func (r *DatabasePropertyRollupResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	err := deletePropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	err := deletePropertyFromDatabase(ctx, r.provider, state.Database.ValueNotionID(), state.ID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting rollup property", err.Error())
 		return
@@ -217,6 +231,6 @@ func (r *DatabasePropertyRollupResource) ImportState(ctx context.Context, req re
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("database"), types.StringValue(databaseID))
+	resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(databaseID))
 	resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(propName))
 }
@@ -15,19 +15,24 @@ import (
 )
 
 var (
-	_ resource.Resource                = &DatabasePropertySelectResource{}
-	_ resource.ResourceWithImportState = &DatabasePropertySelectResource{}
+	_ resource.Resource                 = &DatabasePropertySelectResource{}
+	_ resource.ResourceWithImportState  = &DatabasePropertySelectResource{}
+	_ resource.ResourceWithModifyPlan   = &DatabasePropertySelectResource{}
+	_ resource.ResourceWithUpgradeState = &DatabasePropertySelectResource{}
 )
 
 type DatabasePropertySelectResource struct {
-	client *notionapi.Client
+	client   *notionapi.Client
+	provider *providerData
 }
 
 type DatabasePropertySelectModel struct {
-	ID       types.String `tfsdk:"id"`
-	Database types.String `tfsdk:"database"`
-	Name     types.String `tfsdk:"name"`
-	Options  types.Map    `tfsdk:"options"`
+	ID       types.String  `tfsdk:"id"`
+	Database NotionIDValue `tfsdk:"database"`
+	Name     types.String  `tfsdk:"name"`
+	Options  types.Map     `tfsdk:"options"`
+
+	AllowOptionRemoval types.Bool `tfsdk:"allow_option_removal"`
 }
 
 func NewDatabasePropertySelectResource() resource.Resource {
@@ -40,6 +45,7 @@ func (r *DatabasePropertySelectResource) Metadata(_ context.Context, req resourc
 
 func (r *DatabasePropertySelectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion(databasePropertySelectStateMigrations),
 		Description: "Manages a select property on a Notion database.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -51,9 +57,10 @@ func (r *DatabasePropertySelectResource) Schema(_ context.Context, _ resource.Sc
 			},
 			"database": schema.StringAttribute{
 				Description: "The ID of the parent database.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -68,21 +75,32 @@ func (r *DatabasePropertySelectResource) Schema(_ context.Context, _ resource.Sc
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"allow_option_removal": allowOptionRemovalAttribute(),
 		},
 	}
 }
 
+func (r *DatabasePropertySelectResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return migratedStateUpgraders(databasePropertySelectStateMigrations)
+}
+
 func (r *DatabasePropertySelectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertySelectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPropertyPlan(ctx, r.client, req, resp)
+	checkOptionRemoval(ctx, r.client, req, resp, "select")
 }
 
 func (r *DatabasePropertySelectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -98,7 +116,7 @@ func (r *DatabasePropertySelectResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
 			plan.Name.ValueString(): notionapi.SelectPropertyConfig{
 				Type:   notionapi.PropertyConfigTypeSelect,
@@ -113,6 +131,7 @@ func (r *DatabasePropertySelectResource) Create(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -125,36 +144,43 @@ func (r *DatabasePropertySelectResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.Database.ValueString()))
+	db, err := getDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
 
-	found := false
-	for name, prop := range db.Properties {
-		if string(prop.GetID()) == state.ID.ValueString() || name == state.Name.ValueString() {
-			state.ID = types.StringValue(string(prop.GetID()))
-			state.Name = types.StringValue(name)
-
-			if selectProp, ok := prop.(*notionapi.SelectPropertyConfig); ok {
-				optionsMap := make(map[string]string)
-				for _, opt := range selectProp.Select.Options {
-					optionsMap[opt.Name] = string(opt.Color)
-				}
-				mapVal, diags := types.MapValueFrom(ctx, types.StringType, optionsMap)
-				resp.Diagnostics.Append(diags...)
-				state.Options = mapVal
+	propertyID, diags := trackedPropertyID(ctx, req.Private, state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, prop, found := lookupDatabaseProperty(db, propertyID, state.Name.ValueString())
+	if found {
+		warnIfPropertyRenamed(&resp.Diagnostics, string(prop.GetID()), state.Name.ValueString(), name)
+		state.ID = types.StringValue(string(prop.GetID()))
+		state.Name = types.StringValue(name)
+
+		if selectProp, ok := prop.(*notionapi.SelectPropertyConfig); ok {
+			optionsMap := make(map[string]string)
+			for _, opt := range selectProp.Select.Options {
+				optionsMap[opt.Name] = string(opt.Color)
 			}
-			found = true
-			break
+			mapVal, diags := types.MapValueFrom(ctx, types.StringType, optionsMap)
+			resp.Diagnostics.Append(diags...)
+			state.Options = mapVal
 		}
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, string(prop.GetID()))...)
 	}
 
 	if !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if state.AllowOptionRemoval.IsNull() {
+		state.AllowOptionRemoval = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -172,9 +198,9 @@ func (r *DatabasePropertySelectResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
-			plan.Name.ValueString(): notionapi.SelectPropertyConfig{
+			propertyUpdateKey(plan.ID, plan.Name.ValueString()): notionapi.SelectPropertyConfig{
 				Type:   notionapi.PropertyConfigTypeSelect,
 				Select: notionapi.Select{Options: options},
 			},
@@ -187,6 +213,7 @@ func (r *DatabasePropertySelectResource) Update(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -199,7 +226,7 @@ func (r *DatabasePropertySelectResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	err := deletePropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	err := deletePropertyFromDatabase(ctx, r.provider, state.Database.ValueNotionID(), state.ID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting select property", err.Error())
 		return
@@ -213,7 +240,7 @@ func (r *DatabasePropertySelectResource) ImportState(ctx context.Context, req re
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("database"), types.StringValue(databaseID))
+	resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(databaseID))
 	resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(propName))
 }
 
@@ -20,19 +20,22 @@ import (
 // label-to-color map so users don't have to model group membership.
 
 var (
-	_ resource.Resource                = &DatabasePropertyStatusResource{}
-	_ resource.ResourceWithImportState = &DatabasePropertyStatusResource{}
+	_ resource.Resource                 = &DatabasePropertyStatusResource{}
+	_ resource.ResourceWithImportState  = &DatabasePropertyStatusResource{}
+	_ resource.ResourceWithModifyPlan   = &DatabasePropertyStatusResource{}
+	_ resource.ResourceWithUpgradeState = &DatabasePropertyStatusResource{}
 )
 
 type DatabasePropertyStatusResource struct {
-	client *notionapi.Client
+	client   *notionapi.Client
+	provider *providerData
 }
 
 type DatabasePropertyStatusModel struct {
-	ID       types.String `tfsdk:"id"`
-	Database types.String `tfsdk:"database"`
-	Name     types.String `tfsdk:"name"`
-	Options  types.Map    `tfsdk:"options"`
+	ID       types.String  `tfsdk:"id"`
+	Database NotionIDValue `tfsdk:"database"`
+	Name     types.String  `tfsdk:"name"`
+	Options  types.Map     `tfsdk:"options"`
 }
 
 func NewDatabasePropertyStatusResource() resource.Resource {
@@ -45,6 +48,7 @@ func (r *DatabasePropertyStatusResource) Metadata(_ context.Context, req resourc
 
 func (r *DatabasePropertyStatusResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: schemaVersion(databasePropertyStatusStateMigrations),
 		Description: "Manages a status property on a Notion database. " +
 			"Status properties became writable via the API in the 2026-03-19 change; " +
 			"prior to that they could only be created in the Notion UI.",
@@ -58,9 +62,10 @@ func (r *DatabasePropertyStatusResource) Schema(_ context.Context, _ resource.Sc
 			},
 			"database": schema.StringAttribute{
 				Description: "The ID of the parent database.",
+				CustomType:  NotionIDType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -80,17 +85,26 @@ func (r *DatabasePropertyStatusResource) Schema(_ context.Context, _ resource.Sc
 	}
 }
 
+func (r *DatabasePropertyStatusResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return migratedStateUpgraders(databasePropertyStatusStateMigrations)
+}
+
 func (r *DatabasePropertyStatusResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.provider = data
+}
+
+func (r *DatabasePropertyStatusResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPropertyPlan(ctx, r.client, req, resp)
 }
 
 func (r *DatabasePropertyStatusResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -106,7 +120,7 @@ func (r *DatabasePropertyStatusResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
 			plan.Name.ValueString(): notionapi.StatusPropertyConfig{
 				Type:   notionapi.PropertyConfigStatus,
@@ -121,6 +135,7 @@ func (r *DatabasePropertyStatusResource) Create(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -133,30 +148,34 @@ func (r *DatabasePropertyStatusResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.Database.ValueString()))
+	db, err := getDatabaseSchema(ctx, r.client, state.Database.ValueNotionID())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading database", err.Error())
 		return
 	}
 
-	found := false
-	for name, prop := range db.Properties {
-		if string(prop.GetID()) == state.ID.ValueString() || name == state.Name.ValueString() {
-			state.ID = types.StringValue(string(prop.GetID()))
-			state.Name = types.StringValue(name)
-
-			if statusProp, ok := prop.(*notionapi.StatusPropertyConfig); ok {
-				optionsMap := make(map[string]string)
-				for _, opt := range statusProp.Status.Options {
-					optionsMap[opt.Name] = string(opt.Color)
-				}
-				mapVal, diags := types.MapValueFrom(ctx, types.StringType, optionsMap)
-				resp.Diagnostics.Append(diags...)
-				state.Options = mapVal
+	propertyID, diags := trackedPropertyID(ctx, req.Private, state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, prop, found := lookupDatabaseProperty(db, propertyID, state.Name.ValueString())
+	if found {
+		warnIfPropertyRenamed(&resp.Diagnostics, string(prop.GetID()), state.Name.ValueString(), name)
+		state.ID = types.StringValue(string(prop.GetID()))
+		state.Name = types.StringValue(name)
+
+		if statusProp, ok := prop.(*notionapi.StatusPropertyConfig); ok {
+			optionsMap := make(map[string]string)
+			for _, opt := range statusProp.Status.Options {
+				optionsMap[opt.Name] = string(opt.Color)
 			}
-			found = true
-			break
+			mapVal, diags := types.MapValueFrom(ctx, types.StringType, optionsMap)
+			resp.Diagnostics.Append(diags...)
+			state.Options = mapVal
 		}
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, string(prop.GetID()))...)
 	}
 
 	if !found {
@@ -180,9 +199,9 @@ func (r *DatabasePropertyStatusResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	db, err := r.client.Database.Update(ctx, notionapi.DatabaseID(plan.Database.ValueString()), &notionapi.DatabaseUpdateRequest{
+	db, err := r.provider.batchedDatabaseUpdate(ctx, notionapi.DatabaseID(plan.Database.ValueNotionID()), &notionapi.DatabaseUpdateRequest{
 		Properties: notionapi.PropertyConfigs{
-			plan.Name.ValueString(): notionapi.StatusPropertyConfig{
+			propertyUpdateKey(plan.ID, plan.Name.ValueString()): notionapi.StatusPropertyConfig{
 				Type:   notionapi.PropertyConfigStatus,
 				Status: notionapi.StatusConfig{Options: options},
 			},
@@ -195,6 +214,7 @@ func (r *DatabasePropertyStatusResource) Update(ctx context.Context, req resourc
 
 	if prop, ok := db.Properties[plan.Name.ValueString()]; ok {
 		plan.ID = types.StringValue(string(prop.GetID()))
+		resp.Diagnostics.Append(trackPropertyID(ctx, resp.Private, plan.ID.ValueString())...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -207,7 +227,7 @@ func (r *DatabasePropertyStatusResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	err := deletePropertyFromDatabase(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	err := deletePropertyFromDatabase(ctx, r.provider, state.Database.ValueNotionID(), state.ID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting status property", err.Error())
 		return
@@ -221,6 +241,6 @@ func (r *DatabasePropertyStatusResource) ImportState(ctx context.Context, req re
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("database"), types.StringValue(databaseID))
+	resp.State.SetAttribute(ctx, path.Root("database"), NewNotionIDValue(databaseID))
 	resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(propName))
 }
@@ -1,11 +1,15 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
 )
 
 func TestAccDatabasePropertyRichTextResource(t *testing.T) {
@@ -181,3 +185,154 @@ resource "notion_database_property_status" "test" {
 }
 `, parentPageID, optionsBody)
 }
+
+// TestAccDatabasePropertyRelationSelfResource relates a database to itself
+// with a two-way relation, whose synced property Notion creates in the same
+// database, then renames the synced property.
+func TestAccDatabasePropertyRelationSelfResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabasePropertyRelationSelfConfig(parentPageID, "Sub-tasks"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("notion_database_property_relation.parent", "id"),
+					resource.TestCheckResourceAttrPair("notion_database_property_relation.parent", "related_database",
+						"notion_database.self_test", "id"),
+					resource.TestCheckResourceAttr("notion_database_property_relation.parent", "synced_property_name", "Sub-tasks"),
+					resource.TestCheckResourceAttrSet("notion_database_property_relation.parent", "synced_property_id"),
+				),
+			},
+			{
+				Config: testAccDatabasePropertyRelationSelfConfig(parentPageID, "Children"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database_property_relation.parent", "synced_property_name", "Children"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabasePropertyRelationSelfConfig(parentPageID, syncedName string) string {
+	return fmt.Sprintf(`
+resource "notion_database" "self_test" {
+  parent             = %q
+  title              = "Self Relation Test DB"
+  title_column_title = "Name"
+}
+
+resource "notion_database_property_relation" "parent" {
+  database             = notion_database.self_test.id
+  name                 = "Parent task"
+  related_database     = notion_database.self_test.id
+  synced_property_name = %q
+}
+`, parentPageID, syncedName)
+}
+
+func TestLookupDatabaseProperty(t *testing.T) {
+	db := &notionapi.Database{
+		Properties: notionapi.PropertyConfigs{
+			// "Stage" was renamed to "Phase" in the UI, and a new column
+			// then took the old name.
+			"Phase": &notionapi.SelectPropertyConfig{ID: "abc", Type: notionapi.PropertyConfigTypeSelect},
+			"Stage": &notionapi.SelectPropertyConfig{ID: "xyz", Type: notionapi.PropertyConfigTypeSelect},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		id       string
+		propName string
+		wantName string
+		wantOK   bool
+	}{
+		{"id wins over name", "abc", "Stage", "Phase", true},
+		{"no id falls back to name", "", "Stage", "Stage", true},
+		{"unknown id does not adopt name match", "gone", "Stage", "", false},
+		{"nothing matches", "", "Missing", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, _, ok := lookupDatabaseProperty(db, tc.id, tc.propName)
+			if ok != tc.wantOK || name != tc.wantName {
+				t.Errorf("got (%q, %t), want (%q, %t)", name, ok, tc.wantName, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestRemovedMapKeys(t *testing.T) {
+	prior := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"To Do": types.StringValue("red"),
+		"Doing": types.StringValue("yellow"),
+		"Done":  types.StringValue("green"),
+	})
+	next := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"To Do":       types.StringValue("blue"),
+		"In Progress": types.StringValue("yellow"),
+	})
+
+	got := removedMapKeys(prior, next)
+	want := []string{"Doing", "Done"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("removedMapKeys() = %v, want %v", got, want)
+	}
+	if got := removedMapKeys(next, next); len(got) != 0 {
+		t.Errorf("removedMapKeys() of identical maps = %v, want none", got)
+	}
+}
+
+func TestRelationPropertyConfig(t *testing.T) {
+	m := &DatabasePropertyRelationModel{
+		RelatedDatabase: NewNotionIDValue("0123456789abcdef0123456789abcdef"),
+		SyncedName:      types.StringNull(),
+	}
+	single, err := json.Marshal(newRelationPropertyConfig(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"relation","relation":{"database_id":"0123456789abcdef0123456789abcdef","type":"single_property","single_property":{}}}`
+	if string(single) != want {
+		t.Errorf("got %s, want %s", single, want)
+	}
+
+	m.SyncedName = types.StringValue("Sub-tasks")
+	dual, err := json.Marshal(newRelationPropertyConfig(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"type":"relation","relation":{"database_id":"0123456789abcdef0123456789abcdef","type":"dual_property","dual_property":{"synced_property_name":"Sub-tasks"}}}`
+	if string(dual) != want {
+		t.Errorf("got %s, want %s", dual, want)
+	}
+}
+
+func TestSyncedProperty(t *testing.T) {
+	name, id := syncedProperty(json.RawMessage(`{"id":"a%3Bb","name":"Parent task","type":"relation","relation":{` +
+		`"database_id":"db","type":"dual_property","dual_property":{"synced_property_name":"Sub-tasks","synced_property_id":"x%3Fy"}}}`))
+	if name.ValueString() != "Sub-tasks" || id.ValueString() != "x%3Fy" {
+		t.Errorf("got (%s, %s), want the synced property of a two-way relation", name, id)
+	}
+
+	name, id = syncedProperty(json.RawMessage(`{"type":"relation","relation":{"database_id":"db","type":"single_property","single_property":{}}}`))
+	if !name.IsNull() || !id.IsNull() {
+		t.Errorf("got (%s, %s), want nulls for a one-way relation", name, id)
+	}
+}
+
+func TestPropertyUpdateKey(t *testing.T) {
+	if got := propertyUpdateKey(types.StringValue("a%3Bb"), "Stage"); got != "a%3Bb" {
+		t.Errorf("expected a known ID to be the key, got %q", got)
+	}
+	for _, id := range []types.String{types.StringUnknown(), types.StringNull(), types.StringValue("")} {
+		if got := propertyUpdateKey(id, "Stage"); got != "Stage" {
+			t.Errorf("expected the name to be the key for ID %s, got %q", id, got)
+		}
+	}
+}
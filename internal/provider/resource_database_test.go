@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
 )
 
 func TestAccDatabaseResource(t *testing.T) {
@@ -24,6 +30,8 @@ func TestAccDatabaseResource(t *testing.T) {
 					resource.TestCheckResourceAttr("notion_database.test", "title", "Test DB"),
 					resource.TestCheckResourceAttr("notion_database.test", "title_column_title", "Name"),
 					resource.TestCheckResourceAttrSet("notion_database.test", "url"),
+					resource.TestCheckResourceAttr("notion_database.test", "property_ids.Name", "title"),
+					resource.TestCheckResourceAttr("notion_database.test", "parent_type", "page_id"),
 				),
 			},
 			{
@@ -37,6 +45,13 @@ func TestAccDatabaseResource(t *testing.T) {
 					resource.TestCheckResourceAttr("notion_database.test", "title", "Test DB Updated"),
 				),
 			},
+			{
+				Config: testAccDatabaseResourceConfig(parentPageID, "Test DB Updated", "Task"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database.test", "title_column_title", "Task"),
+					resource.TestCheckResourceAttr("notion_database.test", "title_column_id", "title"),
+				),
+			},
 		},
 	})
 }
@@ -50,3 +65,132 @@ resource "notion_database" "test" {
 }
 `, parentPageID, title, titleColumnTitle)
 }
+
+func TestAccDatabaseResourceInitialEntries(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Code is added after the database, so the entries wait for
+				// the next apply.
+				Config:             testAccDatabaseInitialEntriesConfig(parentPageID),
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("notion_database.test_seeded", "initial_entry_ids.#"),
+				),
+			},
+			{
+				Config: testAccDatabaseInitialEntriesConfig(parentPageID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database.test_seeded", "initial_entry_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabaseInitialEntriesConfig(parentPageID string) string {
+	return fmt.Sprintf(`
+resource "notion_database" "test_seeded" {
+  parent             = %q
+  title              = "Seeded Test DB"
+  title_column_title = "Name"
+
+  initial_entries = [
+    { title = "Backlog" },
+    { title = "Doing", properties = { Code = "B" } },
+  ]
+}
+
+resource "notion_database_property_rich_text" "code" {
+  database = notion_database.test_seeded.id
+  name     = "Code"
+}
+`, parentPageID)
+}
+
+func TestAccDatabaseResourcePreventDestroyIfNonempty(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabasePreventDestroyConfig(parentPageID, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_database.test_guarded", "prevent_destroy_if_nonempty", "true"),
+					resource.TestCheckResourceAttr("notion_database.test_guarded", "initial_entry_ids.#", "1"),
+				),
+			},
+			{
+				Config:      testAccDatabasePreventDestroyConfig(parentPageID, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Database has entries"),
+			},
+			{
+				// Turning the guard off lets the test clean up.
+				Config: testAccDatabasePreventDestroyConfig(parentPageID, false),
+			},
+		},
+	})
+}
+
+func testAccDatabasePreventDestroyConfig(parentPageID string, prevent bool) string {
+	return fmt.Sprintf(`
+resource "notion_database" "test_guarded" {
+  parent                      = %q
+  title                       = "Guarded Test DB"
+  title_column_title          = "Name"
+  prevent_destroy_if_nonempty = %t
+  initial_entries             = [{ title = "Keep me" }]
+}
+`, parentPageID, prevent)
+}
+
+func TestDatabaseHasEntries(t *testing.T) {
+	// An archived row doesn't count; the live one after it ends the search
+	// without reading the rest.
+	pages := []string{
+		`{"object":"list","results":[{"object":"page","id":"a","archived":true}],"has_more":true,"next_cursor":"c1"}`,
+		`{"object":"list","results":[{"object":"page","id":"b"}],"has_more":true,"next_cursor":"c2"}`,
+	}
+	var requests []string
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			requests = append(requests, string(body))
+			page := pages[len(requests)-1]
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(page)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+
+	nonempty, err := databaseHasEntries(context.Background(), client, "db")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !nonempty {
+		t.Error("databaseHasEntries() = false, want true")
+	}
+	if len(requests) != 2 || !strings.Contains(requests[0], `"page_size":1`) || !strings.Contains(requests[1], `"start_cursor":"c1"`) {
+		t.Errorf("requests = %q, want two of one row, the second starting at c1", requests)
+	}
+
+	pages = []string{`{"object":"list","results":[],"has_more":false}`}
+	requests = nil
+	if nonempty, err := databaseHasEntries(context.Background(), client, "db"); err != nil || nonempty {
+		t.Errorf("databaseHasEntries() = %t, %v for an empty database, want false", nonempty, err)
+	}
+}
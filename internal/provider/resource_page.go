@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -19,23 +20,30 @@ import (
 var (
 	_ resource.Resource                = &PageResource{}
 	_ resource.ResourceWithImportState = &PageResource{}
+	_ resource.ResourceWithModifyPlan  = &PageResource{}
 )
 
 type PageResource struct {
 	client   *notionapi.Client
 	mdClient *markdownClient
+	provider *providerData
 }
 
 type PageResourceModel struct {
 	ID             types.String         `tfsdk:"id"`
-	ParentPageID   types.String         `tfsdk:"parent_page_id"`
-	Title          types.String         `tfsdk:"title"`
+	ParentPageID   NotionIDValue        `tfsdk:"parent_page_id"`
+	ParentType     types.String         `tfsdk:"parent_type"`
+	Title          RichTextStringValue  `tfsdk:"title"`
 	URL            types.String         `tfsdk:"url"`
+	PublicURL      types.String         `tfsdk:"public_url"`
+	Archived       types.Bool           `tfsdk:"archived"`
+	InTrash        types.Bool           `tfsdk:"in_trash"`
 	Icon           types.String         `tfsdk:"icon"`
 	Markdown       types.String         `tfsdk:"markdown"`
 	MarkdownInsert *MarkdownInsertModel `tfsdk:"markdown_insert"`
-	TemplateID     types.String         `tfsdk:"template_id"`
+	TemplateID     NotionIDValue        `tfsdk:"template_id"`
 	TemplateTimezone types.String       `tfsdk:"template_timezone"`
+	ManagedBanner  types.String         `tfsdk:"managed_banner"`
 }
 
 // MarkdownInsertModel represents a one-shot markdown insertion at the start or
@@ -68,12 +76,23 @@ func (r *PageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"parent_page_id": schema.StringAttribute{
 				Description: "The ID of the parent page. Changes are applied via the 2026-01-15 " +
-					"`POST /v1/pages/{id}/move` endpoint rather than recreating the resource.",
-				Required: true,
+					"`POST /v1/pages/{id}/move` endpoint rather than recreating the resource. " +
+					"Leave unset only for an imported page at the top level of the workspace.",
+				CustomType: NotionIDType{},
+				Optional:   true,
+			},
+			"parent_type": schema.StringAttribute{
+				Description: "The kind of parent Notion reports for the page, such as page_id, database_id or workspace.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"title": schema.StringAttribute{
-				Description: "The title of the page.",
-				Required:    true,
+				Description: "The title of the page. Supports inline markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and `code`. " +
+					"Compared semantically, so Notion's re-serialization of the title does not produce a diff.",
+				CustomType: RichTextStringType{},
+				Required:   true,
 			},
 			"url": schema.StringAttribute{
 				Description: "The URL of the page.",
@@ -82,12 +101,39 @@ func (r *PageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"public_url": schema.StringAttribute{
+				Description: "The public URL of the page when it is published to the web, either directly or through a published parent, and empty otherwise.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"archived": schema.BoolAttribute{
+				Description: "Whether the page is archived. An archived page is removed from state on refresh.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"in_trash": schema.BoolAttribute{
+				Description: "Whether the page is in the trash, including through a trashed ancestor page.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"icon": schema.StringAttribute{
-				Description: "Emoji icon for the page.",
+				Description: "Icon for the page: an emoji, or an http(s) URL of an image. Icons uploaded to Notion or set to a workspace custom emoji are left as they are.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
 			},
+			"managed_banner": schema.StringAttribute{
+				Description: "Text of a callout kept as the first block of the page, e.g. \"This page is managed by Terraform. " +
+					"Edit it in github.com/acme/docs.\" If the banner is removed or something is added above it, the next apply " +
+					"puts it back at the top. Supports inline markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and `code`.",
+				Optional: true,
+			},
 			"markdown": schema.StringAttribute{
 				Description: "Page content as enhanced markdown. Mutually exclusive with managing content via notion_block resources. " +
 					"Note: Notion may normalize the markdown content, so the stored value may differ slightly from what was submitted.",
@@ -97,9 +143,10 @@ func (r *PageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "Optional Notion template page ID to apply at creation (2026-01-15 API addition). " +
 					"Once set, the template is applied asynchronously by Notion; the page is initially returned blank. " +
 					"Changing this forces a new resource since templates are a creation-time concept.",
-				Optional: true,
+				CustomType: NotionIDType{},
+				Optional:   true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"template_timezone": schema.StringAttribute{
@@ -137,14 +184,20 @@ func (r *PageResource) Configure(_ context.Context, req resource.ConfigureReques
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
-	r.mdClient = newMarkdownClient(client)
+	r.client = data.client
+	r.provider = data
+	r.mdClient = newMarkdownClient(data.client)
+}
+
+func (r *PageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planParent(ctx, req, resp, "parent_page_id", "page")
+	logAPICallEstimate(ctx, r.client, "notion_page", req, resp, singleObjectCallEstimate)
 }
 
 func (r *PageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -173,6 +226,12 @@ func (r *PageResource) Create(ctx context.Context, req resource.CreateRequest, r
 	default:
 		r.createWithoutMarkdown(ctx, &plan, resp)
 	}
+
+	// The banner goes in last so content inserted at the start of the page
+	// above doesn't push it down.
+	if !resp.Diagnostics.HasError() && plan.ManagedBanner.ValueString() != "" {
+		resp.Diagnostics.Append(r.pinManagedBanner(ctx, plan.ID.ValueString(), plan.ManagedBanner.ValueString(), "", resp.Private)...)
+	}
 }
 
 func (r *PageResource) createWithTemplate(ctx context.Context, plan *PageResourceModel, resp *resource.CreateResponse) {
@@ -182,12 +241,12 @@ func (r *PageResource) createWithTemplate(ctx context.Context, plan *PageResourc
 		return
 	}
 
-	pageID, pageURL, err := createPageWithTemplate(
+	created, err := createPageWithTemplate(
 		ctx,
 		token,
-		plan.ParentPageID.ValueString(),
+		plan.ParentPageID.ValueNotionID(),
 		plan.Title.ValueString(),
-		plan.TemplateID.ValueString(),
+		plan.TemplateID.ValueNotionID(),
 		plan.TemplateTimezone.ValueString(),
 	)
 	if err != nil {
@@ -195,8 +254,11 @@ func (r *PageResource) createWithTemplate(ctx context.Context, plan *PageResourc
 		return
 	}
 
-	plan.ID = types.StringValue(normalizeID(pageID))
-	plan.URL = types.StringValue(pageURL)
+	plan.ID = types.StringValue(normalizeID(created.ID))
+	plan.URL = types.StringValue(created.URL)
+	plan.PublicURL = types.StringValue(created.PublicURL)
+	plan.Archived = types.BoolValue(false)
+	plan.InTrash = types.BoolValue(false)
 
 	// Notion returns the page blank initially and applies the template
 	// asynchronously, so we can't trust the response for icon/title round-trip.
@@ -208,6 +270,7 @@ func (r *PageResource) createWithTemplate(ctx context.Context, plan *PageResourc
 	if diags := r.applyMarkdownInsert(ctx, plan); diags != nil {
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
+			savePartialPage(ctx, plan, resp)
 			return
 		}
 	}
@@ -216,9 +279,9 @@ func (r *PageResource) createWithTemplate(ctx context.Context, plan *PageResourc
 }
 
 func (r *PageResource) createWithMarkdown(ctx context.Context, plan *PageResourceModel, resp *resource.CreateResponse) {
-	pageID, pageURL, err := r.mdClient.CreatePageWithMarkdownAndTitle(
+	created, err := r.mdClient.CreatePageWithMarkdownAndTitle(
 		ctx,
-		plan.ParentPageID.ValueString(),
+		plan.ParentPageID.ValueNotionID(),
 		plan.Title.ValueString(),
 		plan.Markdown.ValueString(),
 	)
@@ -227,33 +290,32 @@ func (r *PageResource) createWithMarkdown(ctx context.Context, plan *PageResourc
 		return
 	}
 
-	plan.ID = types.StringValue(normalizeID(pageID))
-	plan.URL = types.StringValue(pageURL)
+	plan.ID = types.StringValue(normalizeID(created.ID))
+	plan.URL = types.StringValue(created.URL)
+	plan.PublicURL = types.StringValue(created.PublicURL)
+	plan.Archived = types.BoolValue(false)
+	plan.InTrash = types.BoolValue(false)
 
 	if diags := r.applyMarkdownInsert(ctx, plan); diags != nil {
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
+			savePartialPage(ctx, plan, resp)
 			return
 		}
 	}
 
 	// Set icon if provided via a separate update since markdown create doesn't support it
 	if plan.Icon.ValueString() != "" {
-		emoji := notionapi.Emoji(plan.Icon.ValueString())
-		page, err := r.client.Page.Update(ctx, notionapi.PageID(pageID), &notionapi.PageUpdateRequest{
-			Icon: &notionapi.Icon{
-				Type:  "emoji",
-				Emoji: &emoji,
-			},
+		page, err := r.client.Page.Update(ctx, notionapi.PageID(plan.ID.ValueString()), &notionapi.PageUpdateRequest{
+			Icon:       iconFromConfig(plan.Icon.ValueString()),
 			Properties: notionapi.Properties{},
 		})
 		if err != nil {
 			resp.Diagnostics.AddError("Error setting page icon", err.Error())
+			savePartialPage(ctx, plan, resp)
 			return
 		}
-		if page.Icon != nil && page.Icon.Emoji != nil {
-			plan.Icon = types.StringValue(string(*page.Icon.Emoji))
-		}
+		plan.Icon = iconToState(page.Icon, plan.Icon)
 	} else {
 		plan.Icon = types.StringValue("")
 	}
@@ -261,11 +323,18 @@ func (r *PageResource) createWithMarkdown(ctx context.Context, plan *PageResourc
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+// savePartialPage saves a page created before a later step of Create
+// failed. Terraform taints it, so the next apply replaces the page instead
+// of creating a second one beside it.
+func savePartialPage(ctx context.Context, plan *PageResourceModel, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 func (r *PageResource) createWithoutMarkdown(ctx context.Context, plan *PageResourceModel, resp *resource.CreateResponse) {
 	params := &notionapi.PageCreateRequest{
 		Parent: notionapi.Parent{
 			Type:   notionapi.ParentTypePageID,
-			PageID: notionapi.PageID(plan.ParentPageID.ValueString()),
+			PageID: notionapi.PageID(plan.ParentPageID.ValueNotionID()),
 		},
 		Properties: notionapi.Properties{
 			"title": notionapi.TitleProperty{
@@ -275,13 +344,7 @@ func (r *PageResource) createWithoutMarkdown(ctx context.Context, plan *PageReso
 		},
 	}
 
-	if plan.Icon.ValueString() != "" {
-		emoji := notionapi.Emoji(plan.Icon.ValueString())
-		params.Icon = &notionapi.Icon{
-			Type:  "emoji",
-			Emoji: &emoji,
-		}
-	}
+	params.Icon = iconFromConfig(plan.Icon.ValueString())
 
 	page, err := r.client.Page.Create(ctx, params)
 	if err != nil {
@@ -291,15 +354,15 @@ func (r *PageResource) createWithoutMarkdown(ctx context.Context, plan *PageReso
 
 	plan.ID = types.StringValue(normalizeID(string(page.ID)))
 	plan.URL = types.StringValue(page.URL)
-	if page.Icon != nil && page.Icon.Emoji != nil {
-		plan.Icon = types.StringValue(string(*page.Icon.Emoji))
-	} else {
-		plan.Icon = types.StringValue("")
-	}
+	plan.PublicURL = types.StringValue(page.PublicURL)
+	plan.Archived = types.BoolValue(page.Archived)
+	plan.InTrash = types.BoolValue(false)
+	plan.Icon = iconToState(page.Icon, plan.Icon)
 
 	if diags := r.applyMarkdownInsert(ctx, plan); diags != nil {
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
+			savePartialPage(ctx, plan, resp)
 			return
 		}
 	}
@@ -314,7 +377,12 @@ func (r *PageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	page, err := r.client.Page.Get(ctx, notionapi.PageID(state.ID.ValueString()))
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading page", err.Error())
+		return
+	}
+	page, raw, err := getPageWithRaw(ctx, token, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading page", err.Error())
 		return
@@ -327,10 +395,18 @@ func (r *PageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	state.ID = types.StringValue(normalizeID(string(page.ID)))
 	state.URL = types.StringValue(page.URL)
-
-	if page.Parent.Type == notionapi.ParentTypePageID {
-		state.ParentPageID = types.StringValue(normalizeID(string(page.Parent.PageID)))
-	} else {
+	state.PublicURL = types.StringValue(page.PublicURL)
+	state.Archived = types.BoolValue(raw.Archived)
+	state.InTrash = types.BoolValue(raw.InTrash)
+
+	state.ParentType = types.StringValue(string(page.Parent.Type))
+	switch page.Parent.Type {
+	case notionapi.ParentTypePageID:
+		state.ParentPageID = NewNotionIDValue(normalizeID(string(page.Parent.PageID)))
+	case notionapi.ParentTypeWorkspace:
+		// A top-level page, e.g. an imported one. There is no parent ID.
+		state.ParentPageID = NewNotionIDNull()
+	default:
 		// 2026-05-11: pages can now be parented by an agent ({"type": "agent_id"}).
 		// The SDK is pinned to an older Notion-Version and doesn't model that
 		// type, so anything other than page_id falls through here. Surface a
@@ -348,14 +424,31 @@ func (r *PageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	if titleProp, ok := page.Properties["title"]; ok {
 		if tp, ok := titleProp.(*notionapi.TitleProperty); ok {
-			state.Title = types.StringValue(richTextToPlain(tp.Title))
+			state.Title = NewRichTextStringValue(richTextToPlain(tp.Title))
 		}
 	}
 
-	if page.Icon != nil && page.Icon.Emoji != nil {
-		state.Icon = types.StringValue(string(*page.Icon.Emoji))
-	} else {
-		state.Icon = types.StringValue("")
+	state.Icon = iconToState(page.Icon, state.Icon)
+
+	// A banner that is gone or no longer the first block reads as empty, so
+	// the next apply pins it again.
+	if state.ManagedBanner.ValueString() != "" {
+		bannerID, diags := trackedManagedBanner(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		text, pinned, err := pinnedManagedBanner(ctx, r.client, state.ID.ValueString(), bannerID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading managed banner", err.Error())
+			return
+		}
+		switch {
+		case !pinned:
+			state.ManagedBanner = types.StringValue("")
+		case normalizeRichTextPlain(text) != normalizeRichTextPlain(state.ManagedBanner.ValueString()):
+			state.ManagedBanner = types.StringValue(text)
+		}
 	}
 
 	// Markdown is managed by the user's config; we don't read it back from the
@@ -380,18 +473,48 @@ func (r *PageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	// If the parent_page_id changed, move the page first (2026-01-15 move endpoint).
 	// Done before the title/icon Update so the rest of the update lands on the
 	// page already at its new location.
-	if plan.ParentPageID.ValueString() != state.ParentPageID.ValueString() {
+	if plan.ParentPageID.ValueNotionID() != state.ParentPageID.ValueNotionID() {
 		token, err := tokenForClient(r.client)
 		if err != nil {
 			resp.Diagnostics.AddError("Error moving page", err.Error())
 			return
 		}
-		if err := movePage(ctx, token, plan.ID.ValueString(), plan.ParentPageID.ValueString()); err != nil {
+		if err := movePage(ctx, token, plan.ID.ValueString(), plan.ParentPageID.ValueNotionID()); err != nil {
 			resp.Diagnostics.AddError("Error moving page", err.Error())
 			return
 		}
 	}
 
+	resp.Diagnostics.Append(r.applyPageContent(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Replacing the markdown removes the banner and inserting content at
+	// the start pushes it down, so either pins it again.
+	contentChanged := (!plan.Markdown.IsNull() && !plan.Markdown.IsUnknown()) ||
+		(plan.MarkdownInsert != nil && plan.MarkdownInsert.Position.ValueString() == "start")
+	if !plan.ManagedBanner.Equal(state.ManagedBanner) || (contentChanged && plan.ManagedBanner.ValueString() != "") {
+		priorBanner, diags := trackedManagedBanner(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.pinManagedBanner(ctx, plan.ID.ValueString(), plan.ManagedBanner.ValueString(), priorBanner, resp.Private)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// applyPageContent writes the planned title, icon, markdown and
+// markdown_insert to an existing page, updating plan with what Notion
+// returns.
+func (r *PageResource) applyPageContent(ctx context.Context, plan *PageResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	// Update page properties (title, icon)
 	params := &notionapi.PageUpdateRequest{
 		Properties: notionapi.Properties{
@@ -402,45 +525,31 @@ func (r *PageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		},
 	}
 
-	if plan.Icon.ValueString() != "" {
-		emoji := notionapi.Emoji(plan.Icon.ValueString())
-		params.Icon = &notionapi.Icon{
-			Type:  "emoji",
-			Emoji: &emoji,
-		}
-	}
+	params.Icon = iconFromConfig(plan.Icon.ValueString())
 
 	page, err := r.client.Page.Update(ctx, notionapi.PageID(plan.ID.ValueString()), params)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating page", err.Error())
-		return
+		diags.AddError("Error updating page", err.Error())
+		return diags
 	}
 
 	plan.URL = types.StringValue(page.URL)
-	if page.Icon != nil && page.Icon.Emoji != nil {
-		plan.Icon = types.StringValue(string(*page.Icon.Emoji))
-	} else {
-		plan.Icon = types.StringValue("")
-	}
+	plan.PublicURL = types.StringValue(page.PublicURL)
+	plan.Archived = types.BoolValue(page.Archived)
+	plan.Icon = iconToState(page.Icon, plan.Icon)
 
 	// Update markdown content if set
 	if !plan.Markdown.IsNull() && !plan.Markdown.IsUnknown() {
 		_, err = r.mdClient.ReplacePageMarkdown(ctx, plan.ID.ValueString(), plan.Markdown.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating page markdown", err.Error())
-			return
+			diags.AddError("Error updating page markdown", err.Error())
+			return diags
 		}
 		// Keep plan value in state rather than API response to avoid normalization diffs
 	}
 
-	if diags := r.applyMarkdownInsert(ctx, &plan); diags != nil {
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	diags.Append(r.applyMarkdownInsert(ctx, plan)...)
+	return diags
 }
 
 // applyMarkdownInsert performs an insert_content PATCH if a markdown_insert
@@ -0,0 +1,486 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                   = &PageHierarchyResource{}
+	_ resource.ResourceWithValidateConfig = &PageHierarchyResource{}
+)
+
+// PageHierarchyResource manages a tree of pages under one parent page from a
+// single map. Each page is keyed by a stable name and points at its parent
+// by that name, so a change of title renames the page and a change of
+// parent moves it, instead of replacing it and everything below it.
+type PageHierarchyResource struct {
+	client *notionapi.Client
+}
+
+type PageHierarchyResourceModel struct {
+	ID           types.String                      `tfsdk:"id"`
+	ParentPageID NotionIDValue                     `tfsdk:"parent_page_id"`
+	Pages        map[string]PageHierarchyPageModel `tfsdk:"pages"`
+}
+
+// PageHierarchyPageModel is one element of notion_page_hierarchy.pages.
+type PageHierarchyPageModel struct {
+	ID     types.String        `tfsdk:"id"`
+	Title  RichTextStringValue `tfsdk:"title"`
+	Parent types.String        `tfsdk:"parent"`
+	Icon   types.String        `tfsdk:"icon"`
+	URL    types.String        `tfsdk:"url"`
+}
+
+func NewPageHierarchyResource() resource.Resource {
+	return &PageHierarchyResource{}
+}
+
+func (r *PageHierarchyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_hierarchy"
+}
+
+func (r *PageHierarchyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a tree of pages under one parent page, declared as a single map.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the page the hierarchy was first created under.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_page_id": schema.StringAttribute{
+				Description: "The ID of the page the top-level pages of the hierarchy are created under. " +
+					"Changing it moves the top-level pages, with everything below them.",
+				CustomType: NotionIDType{},
+				Required:   true,
+			},
+			"pages": schema.MapNestedAttribute{
+				Description: "The pages of the hierarchy, keyed by a name that identifies the page across changes to its title and parent. " +
+					"Removing a key trashes the page along with any pages under it.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the page.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the page. Changing it renames the page. Supports inline markdown and is compared semantically.",
+							CustomType:  RichTextStringType{},
+							Required:    true,
+						},
+						"parent": schema.StringAttribute{
+							Description: "The key of the page this page is nested under. If omitted, the page is created " +
+								"directly under parent_page_id. Changing it moves the page.",
+							Optional: true,
+						},
+						"icon": schema.StringAttribute{
+							Description: "Icon for the page: an emoji, or an http(s) URL of an image.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+						},
+						"url": schema.StringAttribute{
+							Description: "The URL of the page.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that every parent names another page of the map and
+// that following parents always ends at the top of the hierarchy.
+func (r *PageHierarchyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var pages types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pages"), &pages)...)
+	if resp.Diagnostics.HasError() || pages.IsNull() || pages.IsUnknown() {
+		return
+	}
+	var config map[string]PageHierarchyPageModel
+	resp.Diagnostics.Append(pages.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(pageHierarchyDiagnostics(config)...)
+}
+
+// pageHierarchyDiagnostics reports parents that aren't keys of pages and
+// pages that are their own ancestor.
+func pageHierarchyDiagnostics(pages map[string]PageHierarchyPageModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	keys := make([]string, 0, len(pages))
+	for key := range pages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		page := pages[key]
+		if page.Parent.IsUnknown() {
+			return diags
+		}
+		if page.Parent.IsNull() {
+			continue
+		}
+		if _, ok := pages[page.Parent.ValueString()]; !ok {
+			diags.AddAttributeError(path.Root("pages").AtMapKey(key).AtName("parent"), "Unknown Parent Page",
+				fmt.Sprintf("parent %q is not a key of pages.", page.Parent.ValueString()))
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, key := range keys {
+		// A chain longer than the map must revisit a page.
+		current := key
+		for range len(pages) + 1 {
+			parent := pages[current].Parent
+			if parent.IsNull() || parent.IsUnknown() {
+				current = ""
+				break
+			}
+			current = parent.ValueString()
+		}
+		if current != "" {
+			diags.AddAttributeError(path.Root("pages").AtMapKey(key).AtName("parent"), "Page Hierarchy Cycle",
+				fmt.Sprintf("Page %q is nested under itself.", key))
+		}
+	}
+	return diags
+}
+
+// pageHierarchyOrder returns the keys of pages with every parent before its
+// children: by depth, then by key.
+func pageHierarchyOrder(pages map[string]PageHierarchyPageModel) []string {
+	depth := func(key string) int {
+		d := 0
+		for current := key; d <= len(pages); d++ {
+			parent := pages[current].Parent
+			if parent.IsNull() || parent.IsUnknown() {
+				break
+			}
+			current = parent.ValueString()
+		}
+		return d
+	}
+
+	keys := make([]string, 0, len(pages))
+	depths := make(map[string]int, len(pages))
+	for key := range pages {
+		keys = append(keys, key)
+		depths[key] = depth(key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if depths[keys[i]] != depths[keys[j]] {
+			return depths[keys[i]] < depths[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func (r *PageHierarchyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+// parentID returns the ID of the page that key's page belongs under.
+func (m *PageHierarchyResourceModel) parentID(key string) string {
+	parent := m.Pages[key].Parent
+	if parent.IsNull() {
+		return m.ParentPageID.ValueNotionID()
+	}
+	return m.Pages[parent.ValueString()].ID.ValueString()
+}
+
+func (r *PageHierarchyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PageHierarchyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ParentPageID.ValueNotionID())
+	for key, page := range plan.Pages {
+		page.ID = types.StringNull()
+		page.URL = types.StringNull()
+		plan.Pages[key] = page
+	}
+
+	// Pages created before a failure are saved to state; the next apply
+	// creates the rest.
+	resp.Diagnostics.Append(r.createPages(ctx, &plan, pageHierarchyOrder(plan.Pages))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// createPages creates, in order, the pages of plan under keys, recording
+// each one's ID and URL in plan as it goes.
+func (r *PageHierarchyResource) createPages(ctx context.Context, plan *PageHierarchyResourceModel, keys []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, key := range keys {
+		page := plan.Pages[key]
+		created, err := r.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+			Parent: notionapi.Parent{
+				Type:   notionapi.ParentTypePageID,
+				PageID: notionapi.PageID(plan.parentID(key)),
+			},
+			Properties: notionapi.Properties{
+				"title": notionapi.TitleProperty{
+					Type:  notionapi.PropertyTypeTitle,
+					Title: plainToRichText(page.Title.ValueString()),
+				},
+			},
+			Icon: iconFromConfig(page.Icon.ValueString()),
+		})
+		if err != nil {
+			diags.AddAttributeError(path.Root("pages").AtMapKey(key), "Error creating page", err.Error())
+			return diags
+		}
+		page.ID = types.StringValue(normalizeID(string(created.ID)))
+		page.URL = types.StringValue(created.URL)
+		page.Icon = iconToState(created.Icon, page.Icon)
+		plan.Pages[key] = page
+	}
+	return diags
+}
+
+func (r *PageHierarchyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PageHierarchyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyByID := make(map[string]string, len(state.Pages))
+	for key, page := range state.Pages {
+		if !page.ID.IsNull() {
+			keyByID[page.ID.ValueString()] = key
+		}
+	}
+
+	for key, page := range state.Pages {
+		if page.ID.IsNull() {
+			// Never created; the next apply creates it.
+			delete(state.Pages, key)
+			continue
+		}
+		notionPage, err := r.client.Page.Get(ctx, notionapi.PageID(page.ID.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading page", fmt.Sprintf("Page %q: %s", key, err))
+			return
+		}
+		if notionPage.Archived {
+			// Trashed outside Terraform; the next apply recreates it.
+			delete(state.Pages, key)
+			continue
+		}
+
+		if titleProp, ok := notionPage.Properties["title"].(*notionapi.TitleProperty); ok {
+			page.Title = NewRichTextStringValue(richTextToPlain(titleProp.Title))
+		}
+		page.Icon = iconToState(notionPage.Icon, page.Icon)
+		page.URL = types.StringValue(notionPage.URL)
+
+		// A page moved to another page of the hierarchy, or to the top,
+		// shows up as a change of parent. One moved anywhere else keeps
+		// its parent in state, and is warned about.
+		parentID := normalizeID(string(notionPage.Parent.PageID))
+		switch parentKey, ok := keyByID[parentID]; {
+		case notionPage.Parent.Type == notionapi.ParentTypePageID && parentID == state.ParentPageID.ValueNotionID():
+			page.Parent = types.StringNull()
+		case notionPage.Parent.Type == notionapi.ParentTypePageID && ok:
+			page.Parent = types.StringValue(parentKey)
+		default:
+			resp.Diagnostics.AddWarning("Page moved out of hierarchy",
+				fmt.Sprintf("Page %q (%s) is no longer under a page of the hierarchy. State keeps its configured parent; "+
+					"move it back in Notion, or change its parent in configuration to have Terraform move it.", key, page.ID.ValueString()))
+		}
+		state.Pages[key] = page
+	}
+
+	if len(state.Pages) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *PageHierarchyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state PageHierarchyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating page hierarchy", err.Error())
+		return
+	}
+
+	// New pages are created first, so existing pages can be moved under
+	// them; then existing pages are moved and renamed; and removed pages are
+	// trashed last, once the pages staying in the hierarchy are out from
+	// under them.
+	var toCreate []string
+	for _, key := range pageHierarchyOrder(plan.Pages) {
+		page := plan.Pages[key]
+		if prior, ok := state.Pages[key]; ok && !prior.ID.IsNull() {
+			page.ID = prior.ID
+			page.URL = prior.URL
+		} else {
+			page.ID = types.StringNull()
+			page.URL = types.StringNull()
+			toCreate = append(toCreate, key)
+		}
+		plan.Pages[key] = page
+	}
+
+	// From here on, state is saved even when a step fails, so pages
+	// already created aren't created again by the next apply.
+	resp.Diagnostics.Append(r.createPages(ctx, &plan, toCreate)...)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, r.partialState(&plan, &state))...)
+		return
+	}
+
+	for _, key := range pageHierarchyOrder(plan.Pages) {
+		prior, ok := state.Pages[key]
+		if !ok || prior.ID.IsNull() {
+			continue
+		}
+		page := plan.Pages[key]
+		at := path.Root("pages").AtMapKey(key)
+
+		if parentID := plan.parentID(key); parentID != state.parentID(key) {
+			if err := movePage(ctx, token, page.ID.ValueString(), parentID); err != nil {
+				resp.Diagnostics.AddAttributeError(at.AtName("parent"), "Error moving page", err.Error())
+				resp.Diagnostics.Append(resp.State.Set(ctx, r.partialState(&plan, &state))...)
+				return
+			}
+		}
+
+		if !page.Title.Equal(prior.Title) || !page.Icon.Equal(prior.Icon) {
+			// The URL, which has the title in it, is left to the next
+			// refresh: it was planned as the prior value.
+			updated, err := r.client.Page.Update(ctx, notionapi.PageID(page.ID.ValueString()), &notionapi.PageUpdateRequest{
+				Properties: notionapi.Properties{
+					"title": notionapi.TitleProperty{
+						Type:  notionapi.PropertyTypeTitle,
+						Title: plainToRichText(page.Title.ValueString()),
+					},
+				},
+				Icon: iconFromConfig(page.Icon.ValueString()),
+			})
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(at, "Error updating page", err.Error())
+				resp.Diagnostics.Append(resp.State.Set(ctx, r.partialState(&plan, &state))...)
+				return
+			}
+			page.Icon = iconToState(updated.Icon, page.Icon)
+			plan.Pages[key] = page
+		}
+	}
+
+	removed := map[string]PageHierarchyPageModel{}
+	for key, page := range state.Pages {
+		if _, ok := plan.Pages[key]; !ok && !page.ID.IsNull() {
+			removed[key] = page
+		}
+	}
+	resp.Diagnostics.Append(r.trashPages(ctx, token, removed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// partialState returns plan with the pages of state that plan drops added
+// back, for saving progress when Update fails partway: those pages still
+// exist and are trashed by the next apply.
+func (r *PageHierarchyResource) partialState(plan, state *PageHierarchyResourceModel) *PageHierarchyResourceModel {
+	partial := *plan
+	partial.Pages = make(map[string]PageHierarchyPageModel, len(plan.Pages))
+	for key, page := range plan.Pages {
+		if page.ID.IsNull() {
+			continue
+		}
+		partial.Pages[key] = page
+	}
+	for key, page := range state.Pages {
+		if _, ok := partial.Pages[key]; !ok && !page.ID.IsNull() {
+			partial.Pages[key] = page
+		}
+	}
+	return &partial
+}
+
+// trashPages trashes the pages of removed that aren't under another page of
+// removed; Notion trashes those along with their ancestor.
+func (r *PageHierarchyResource) trashPages(ctx context.Context, token string, removed map[string]PageHierarchyPageModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, key := range pageHierarchyOrder(removed) {
+		page := removed[key]
+		if _, ok := removed[page.Parent.ValueString()]; ok && !page.Parent.IsNull() {
+			continue
+		}
+		if err := trashObject(ctx, token, "pages", page.ID.ValueString()); err != nil {
+			diags.AddError("Error trashing page", fmt.Sprintf("Page %q: %s", key, err))
+		}
+	}
+	return diags
+}
+
+func (r *PageHierarchyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PageHierarchyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := tokenForClient(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error trashing page", err.Error())
+		return
+	}
+
+	pages := map[string]PageHierarchyPageModel{}
+	for key, page := range state.Pages {
+		if !page.ID.IsNull() {
+			pages[key] = page
+		}
+	}
+	resp.Diagnostics.Append(r.trashPages(ctx, token, pages)...)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPageHierarchyResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPageHierarchyConfig(parentPageID, "Runbooks", "engineering"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_page_hierarchy.test", "pages.%", "3"),
+					resource.TestCheckResourceAttrSet("notion_page_hierarchy.test", "pages.deploys.id"),
+				),
+			},
+			{
+				// Rename runbooks and move it, with deploys, to the top.
+				Config: testAccPageHierarchyConfig(parentPageID, "Operations", "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_page_hierarchy.test", "pages.runbooks.title", "Operations"),
+					resource.TestCheckNoResourceAttr("notion_page_hierarchy.test", "pages.runbooks.parent"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPageHierarchyConfig(parentPageID, runbooksTitle, runbooksParent string) string {
+	if runbooksParent != "null" {
+		runbooksParent = fmt.Sprintf("%q", runbooksParent)
+	}
+	return fmt.Sprintf(`
+resource "notion_page_hierarchy" "test" {
+  parent_page_id = %[1]q
+  pages = {
+    engineering = { title = "TF Acc Engineering" }
+    runbooks    = { title = %[2]q, parent = %[3]s }
+    deploys     = { title = "Deploys", parent = "runbooks" }
+  }
+}
+`, parentPageID, runbooksTitle, runbooksParent)
+}
+
+func hierarchyPage(parent string) PageHierarchyPageModel {
+	page := PageHierarchyPageModel{Parent: types.StringNull()}
+	if parent != "" {
+		page.Parent = types.StringValue(parent)
+	}
+	return page
+}
+
+func TestPageHierarchyOrder(t *testing.T) {
+	pages := map[string]PageHierarchyPageModel{
+		"deploys":     hierarchyPage("runbooks"),
+		"runbooks":    hierarchyPage("engineering"),
+		"onboarding":  hierarchyPage("engineering"),
+		"engineering": hierarchyPage(""),
+		"design":      hierarchyPage(""),
+	}
+	got := pageHierarchyOrder(pages)
+	want := []string{"design", "engineering", "onboarding", "runbooks", "deploys"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pageHierarchyOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestPageHierarchyDiagnostics(t *testing.T) {
+	tests := []struct {
+		name   string
+		pages  map[string]PageHierarchyPageModel
+		errors int
+	}{
+		{"valid", map[string]PageHierarchyPageModel{"a": hierarchyPage(""), "b": hierarchyPage("a")}, 0},
+		{"unknown parent", map[string]PageHierarchyPageModel{"a": hierarchyPage(""), "b": hierarchyPage("c")}, 1},
+		{"self", map[string]PageHierarchyPageModel{"a": hierarchyPage("a")}, 1},
+		{"cycle", map[string]PageHierarchyPageModel{"a": hierarchyPage("b"), "b": hierarchyPage("a"), "c": hierarchyPage("")}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pageHierarchyDiagnostics(tt.pages).ErrorsCount(); got != tt.errors {
+				t.Errorf("pageHierarchyDiagnostics() errors = %d, want %d", got, tt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,441 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource               = &PageIndexResource{}
+	_ resource.ResourceWithModifyPlan = &PageIndexResource{}
+)
+
+// PageIndexResource keeps a bulleted list of links to the pages under a
+// root page, a table of contents, in a target page or block. The pages
+// aren't managed by Terraform, so ModifyPlan lists them on every plan and
+// plans an update when they differ from the ones the index was written for.
+type PageIndexResource struct {
+	client *notionapi.Client
+}
+
+type PageIndexResourceModel struct {
+	ID         types.String  `tfsdk:"id"`
+	RootPageID NotionIDValue `tfsdk:"root_page_id"`
+	ParentID   NotionIDValue `tfsdk:"parent_id"`
+	Depth      types.Int64   `tfsdk:"depth"`
+	Pages      types.List    `tfsdk:"pages"`
+	BlockIDs   types.List    `tfsdk:"block_ids"`
+}
+
+// pageIndexPageType is the element type of pages, matching
+// PageTreePageModel.
+var pageIndexPageType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":        types.StringType,
+	"title":     types.StringType,
+	"parent_id": types.StringType,
+	"url":       types.StringType,
+	"depth":     types.Int64Type,
+}}
+
+func NewPageIndexResource() resource.Resource {
+	return &PageIndexResource{}
+}
+
+func (r *PageIndexResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_index"
+}
+
+func (r *PageIndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Keeps a bulleted list of links to the pages under a root page in a target page or block, " +
+			"rewritten whenever those pages change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the root page.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"root_page_id": schema.StringAttribute{
+				Description: "The ID of the page whose descendant pages are listed.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "The ID of the page or block the index is appended to.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"depth": schema.Int64Attribute{
+				Description: fmt.Sprintf("How many levels of pages to list: 1, the default, lists the child pages of the root, "+
+					"2 also their child pages as nested bullets, and so on, up to %d.", maxSnapshotDepth),
+				Optional: true,
+			},
+			"pages": schema.ListNestedAttribute{
+				Description: "The pages in the index, in order, as listed by the notion_page_tree data source.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The page ID.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The page title.",
+							Computed:    true,
+						},
+						"parent_id": schema.StringAttribute{
+							Description: "The ID of the page this page is a child of.",
+							Computed:    true,
+						},
+						"url": schema.StringAttribute{
+							Description: "The URL the page's bullet links to.",
+							Computed:    true,
+						},
+						"depth": schema.Int64Attribute{
+							Description: "The level of the page, and of its bullet.",
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"block_ids": schema.ListAttribute{
+				Description: "The IDs of the top-level bullets of the index, in order. Nested bullets are their children.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PageIndexResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+// ModifyPlan lists the pages under the root and, when they differ from the
+// ones in state, plans them as the new pages, which rewrites the index.
+// When they can't be listed yet, for example because the root is created
+// in the same apply, pages is left unknown and listed on apply.
+func (r *PageIndexResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan PageIndexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.RootPageID.IsUnknown() || plan.Depth.IsUnknown() {
+		return
+	}
+	depth, diags := pageIndexDepth(plan.Depth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pages, err := r.listPages(ctx, plan.RootPageID.ValueNotionID(), depth)
+	if err != nil {
+		if req.State.Raw.IsNull() {
+			return
+		}
+		resp.Diagnostics.AddError("Error listing pages", err.Error())
+		return
+	}
+	if pages.Equal(plan.Pages) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pages"), pages)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("block_ids"), types.ListUnknown(types.StringType))...)
+}
+
+// pageIndexDepth returns depth, or 1 when it is null, checking its range.
+func pageIndexDepth(depth types.Int64) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if depth.IsNull() {
+		return 1, diags
+	}
+	if d := depth.ValueInt64(); d < 1 || d > maxSnapshotDepth {
+		diags.AddAttributeError(path.Root("depth"), "Invalid depth",
+			fmt.Sprintf("depth must be between 1 and %d, got %d.", maxSnapshotDepth, d))
+	}
+	return int(depth.ValueInt64()), diags
+}
+
+// listPages returns the pages under rootID as a pages value.
+func (r *PageIndexResource) listPages(ctx context.Context, rootID string, depth int) (types.List, error) {
+	pages, err := pageTree(ctx, func(ctx context.Context, id string) ([]notionapi.Block, error) {
+		return listChildBlocks(ctx, r.client, id)
+	}, rootID, depth)
+	if err != nil {
+		return types.ListNull(pageIndexPageType), err
+	}
+	list, diags := types.ListValueFrom(ctx, pageIndexPageType, pages)
+	if diags.HasError() {
+		return types.ListNull(pageIndexPageType), fmt.Errorf("encoding pages: %v", diags)
+	}
+	return list, nil
+}
+
+func (r *PageIndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PageIndexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.RootPageID.ValueNotionID())
+	ids, diags := r.writeIndex(ctx, &plan, "")
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() && len(ids) == 0 {
+		return
+	}
+	// Bullets written before an error are recorded, so the next apply
+	// replaces them rather than leaving duplicates behind.
+	resp.Diagnostics.Append(setIndexState(ctx, &plan, ids, !diags.HasError(), &resp.State)...)
+}
+
+// writeIndex writes the bullets for plan.Pages, listing the pages first
+// when they are unknown, under plan.ParentID after the block after, or at
+// the end when after is empty. It returns the IDs of the top-level bullets
+// written, which are all of them unless it fails part way.
+func (r *PageIndexResource) writeIndex(ctx context.Context, plan *PageIndexResourceModel, after notionapi.BlockID) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.Pages.IsUnknown() {
+		depth, d := pageIndexDepth(plan.Depth)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		pages, err := r.listPages(ctx, plan.RootPageID.ValueNotionID(), depth)
+		if err != nil {
+			diags.AddError("Error listing pages", err.Error())
+			return nil, diags
+		}
+		plan.Pages = pages
+	}
+
+	var pages []PageTreePageModel
+	diags.Append(plan.Pages.ElementsAs(ctx, &pages, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	ids, err := appendPageIndex(ctx, r.client, notionapi.BlockID(plan.ParentID.ValueNotionID()), after, pageIndexTree(pages))
+	if err != nil {
+		diags.AddError("Error writing page index", err.Error())
+	}
+	return ids, diags
+}
+
+// setIndexState records the bullets written for plan in state. An index
+// written only in part records no pages, so the next plan rewrites it.
+func setIndexState(ctx context.Context, plan *PageIndexResourceModel, ids []string, complete bool, state stateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !complete {
+		plan.Pages = types.ListValueMust(pageIndexPageType, []attr.Value{})
+	}
+	list, d := types.ListValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	plan.BlockIDs = list
+	diags.Append(state.Set(ctx, plan)...)
+	return diags
+}
+
+// pageIndexNode is a page of the index with the pages nested under it.
+type pageIndexNode struct {
+	page     PageTreePageModel
+	children []*pageIndexNode
+}
+
+// pageIndexTree nests pages, which pageTree lists each before its
+// descendants, under their parents. Pages whose parent isn't listed, the
+// child pages of the root, are returned.
+func pageIndexTree(pages []PageTreePageModel) []*pageIndexNode {
+	byID := make(map[string]*pageIndexNode, len(pages))
+	var top []*pageIndexNode
+	for _, p := range pages {
+		node := &pageIndexNode{page: p}
+		byID[p.ID.ValueString()] = node
+		if parent, ok := byID[p.ParentID.ValueString()]; ok {
+			parent.children = append(parent.children, node)
+		} else {
+			top = append(top, node)
+		}
+	}
+	return top
+}
+
+// pageIndexBullet returns the bullet linking to page p.
+func pageIndexBullet(p PageTreePageModel) notionapi.Block {
+	title := p.Title.ValueString()
+	if title == "" {
+		title = "Untitled"
+	}
+	return &notionapi.BulletedListItemBlock{
+		BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeBulletedListItem},
+		BulletedListItem: notionapi.ListItem{
+			RichText: []notionapi.RichText{textRun(title, notionapi.Annotations{}, &notionapi.Link{Url: p.URL.ValueString()})},
+		},
+	}
+}
+
+// appendPageIndex appends a bullet for each of nodes under parentID, after
+// the block after, then the bullets of their children under each of them.
+// Notion only accepts two levels of nested children in one request, so
+// each level is appended on its own. It returns the IDs of the bullets
+// appended directly under parentID.
+func appendPageIndex(ctx context.Context, client *notionapi.Client, parentID, after notionapi.BlockID, nodes []*pageIndexNode) ([]string, error) {
+	bullets := make([]notionapi.Block, len(nodes))
+	for i, n := range nodes {
+		bullets[i] = pageIndexBullet(n.page)
+	}
+	created, err := appendBlocks(ctx, client, parentID, after, bullets)
+	ids := make([]string, len(created))
+	for i, b := range created {
+		ids[i] = normalizeID(string(b.GetID()))
+	}
+	if err != nil {
+		return ids, err
+	}
+	for i, n := range nodes {
+		if len(n.children) == 0 {
+			continue
+		}
+		if _, err := appendPageIndex(ctx, client, created[i].GetID(), "", n.children); err != nil {
+			return ids, err
+		}
+	}
+	return ids, nil
+}
+
+func (r *PageIndexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PageIndexResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() || len(ids) == 0 {
+		return
+	}
+
+	// Trashed bullets aren't listed. An index missing some of its bullets
+	// records no pages, so the next plan rewrites it.
+	children, err := listChildBlocks(ctx, r.client, state.ParentID.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading page index", err.Error())
+		return
+	}
+	listed := make(map[string]bool, len(children))
+	for _, b := range children {
+		listed[normalizeID(string(b.GetID()))] = true
+	}
+	var kept []string
+	for _, id := range ids {
+		if listed[id] {
+			kept = append(kept, id)
+		}
+	}
+	if len(kept) == len(ids) {
+		return
+	}
+	resp.Diagnostics.Append(setIndexState(ctx, &state, kept, false, &resp.State)...)
+}
+
+func (r *PageIndexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state PageIndexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan leaves block_ids known when the pages haven't changed,
+	// such as when depth changes without adding or removing any.
+	if !plan.BlockIDs.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	var old []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &old, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The new bullets go right after the old ones, which are deleted once
+	// they're written, so the index keeps its place in the parent.
+	var after notionapi.BlockID
+	if len(old) > 0 {
+		after = notionapi.BlockID(old[len(old)-1])
+	}
+	ids, diags := r.writeIndex(ctx, &plan, after)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		// Keep tracking the old bullets with the new ones, so the next
+		// apply deletes both.
+		resp.Diagnostics.Append(setIndexState(ctx, &plan, append(old, ids...), false, &resp.State)...)
+		return
+	}
+
+	var stale []string
+	for _, id := range old {
+		if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(id)); err != nil {
+			resp.Diagnostics.AddError("Error deleting block", fmt.Sprintf("Block %s: %s", id, err))
+			stale = append(stale, id)
+		}
+	}
+	resp.Diagnostics.Append(setIndexState(ctx, &plan, append(stale, ids...), len(stale) == 0, &resp.State)...)
+}
+
+func (r *PageIndexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PageIndexResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, id := range ids {
+		if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(id)); err != nil {
+			resp.Diagnostics.AddError("Error deleting block", fmt.Sprintf("Block %s: %s", id, err))
+		}
+	}
+}
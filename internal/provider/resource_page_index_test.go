@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccPageIndexResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPageIndexConfig(parentPageID, []string{"Alpha", "Beta"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_page_index.test", "pages.#", "2"),
+					resource.TestCheckResourceAttr("notion_page_index.test", "pages.0.title", "Alpha"),
+					resource.TestCheckResourceAttr("notion_page_index.test", "block_ids.#", "2"),
+				),
+			},
+			{
+				// The index was planned before the new page existed, so it
+				// is only listed by the next plan.
+				Config:             testAccPageIndexConfig(parentPageID, []string{"Alpha", "Beta", "Gamma"}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// A new page under the root rewrites the index.
+				Config: testAccPageIndexConfig(parentPageID, []string{"Alpha", "Beta", "Gamma"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_page_index.test", "pages.#", "3"),
+					resource.TestCheckResourceAttr("notion_page_index.test", "block_ids.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPageIndexConfig(parentPageID string, titles []string) string {
+	quoted := make([]string, len(titles))
+	for i, title := range titles {
+		quoted[i] = fmt.Sprintf("%q", title)
+	}
+	return fmt.Sprintf(`
+resource "notion_page" "root" {
+  parent_page_id = %q
+  title          = "Page Index Root"
+}
+
+resource "notion_page" "toc" {
+  parent_page_id = %q
+  title          = "Page Index Contents"
+}
+
+resource "notion_page" "child" {
+  for_each       = { for i, title in [%s] : i => title }
+  parent_page_id = notion_page.root.id
+  title          = each.value
+}
+
+resource "notion_page_index" "test" {
+  root_page_id = notion_page.root.id
+  parent_id    = notion_page.toc.id
+  depends_on   = [notion_page.child]
+}
+`, parentPageID, parentPageID, strings.Join(quoted, ", "))
+}
+
+func TestPageIndexTree(t *testing.T) {
+	page := func(id, parent string) PageTreePageModel {
+		return PageTreePageModel{ID: types.StringValue(id), ParentID: types.StringValue(parent)}
+	}
+	top := pageIndexTree([]PageTreePageModel{
+		page("a", "root"), page("a1", "a"), page("a2", "a"), page("a21", "a2"), page("b", "root"),
+	})
+	var render func(nodes []*pageIndexNode) string
+	render = func(nodes []*pageIndexNode) string {
+		var out []string
+		for _, n := range nodes {
+			s := n.page.ID.ValueString()
+			if len(n.children) > 0 {
+				s += "(" + render(n.children) + ")"
+			}
+			out = append(out, s)
+		}
+		return strings.Join(out, " ")
+	}
+	if got, want := render(top), "a(a1 a2(a21)) b"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestPageIndexBullet(t *testing.T) {
+	b := pageIndexBullet(PageTreePageModel{Title: types.StringValue(""), URL: types.StringValue("https://www.notion.so/abc")})
+	item, ok := b.(*notionapi.BulletedListItemBlock)
+	if !ok {
+		t.Fatalf("got %T, want a bulleted list item", b)
+	}
+	rt := item.BulletedListItem.RichText
+	if len(rt) != 1 || rt[0].Text.Content != "Untitled" || rt[0].Text.Link == nil || rt[0].Text.Link.Url != "https://www.notion.so/abc" {
+		t.Errorf("got %+v, want an Untitled link to the page", rt)
+	}
+}
+
+func TestAppendPageIndex(t *testing.T) {
+	// Each append returns its bullets with IDs b0, b1, ... in order.
+	var appends []string
+	n := 0
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Children []json.RawMessage `json:"children"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding request: %s", err)
+			}
+			parent := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v1/blocks/"), "/children")
+			appends = append(appends, fmt.Sprintf("%s:%d", parent, len(body.Children)))
+			var results []string
+			for range body.Children {
+				results = append(results, fmt.Sprintf(`{"object":"block","id":"b%d","type":"bulleted_list_item","bulleted_list_item":{"rich_text":[]}}`, n))
+				n++
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"object":"list","results":[` + strings.Join(results, ",") + `]}`)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+
+	page := func(id, parent string) PageTreePageModel {
+		return PageTreePageModel{ID: types.StringValue(id), Title: types.StringValue(id), ParentID: types.StringValue(parent),
+			URL: types.StringValue("https://www.notion.so/" + id)}
+	}
+	nodes := pageIndexTree([]PageTreePageModel{page("a", "root"), page("a1", "a"), page("b", "root")})
+	ids, err := appendPageIndex(context.Background(), client, "target", "", nodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"b0", "b1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if want := []string{"target:2", "b0:1"}; !reflect.DeepEqual(appends, want) {
+		t.Errorf("appends = %v, want %v", appends, want)
+	}
+}
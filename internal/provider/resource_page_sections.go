@@ -0,0 +1,409 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                   = &PageSectionsResource{}
+	_ resource.ResourceWithModifyPlan     = &PageSectionsResource{}
+	_ resource.ResourceWithValidateConfig = &PageSectionsResource{}
+)
+
+// PageSectionsResource lays out a standard page skeleton: an optional title
+// heading, a table of contents and a divider, then one heading_2 per section
+// followed by that section's blocks. It expands to the same ordered block
+// list notion_blocks manages, and is created and updated the same way, so
+// pages generated from one module come out identically structured.
+type PageSectionsResource struct {
+	client *notionapi.Client
+}
+
+type PageSectionsResourceModel struct {
+	ID              types.String        `tfsdk:"id"`
+	ParentID        NotionIDValue       `tfsdk:"parent_id"`
+	After           types.String        `tfsdk:"after"`
+	Title           RichTextStringValue `tfsdk:"title"`
+	TableOfContents types.Bool          `tfsdk:"table_of_contents"`
+	SectionDividers types.Bool          `tfsdk:"section_dividers"`
+	Sections        []PageSectionModel  `tfsdk:"sections"`
+	BlockIDs        types.List          `tfsdk:"block_ids"`
+}
+
+// PageSectionModel is one element of notion_page_sections.sections.
+type PageSectionModel struct {
+	Heading RichTextStringValue  `tfsdk:"heading"`
+	Blocks  []BlockListItemModel `tfsdk:"blocks"`
+}
+
+func NewPageSectionsResource() resource.Resource {
+	return &PageSectionsResource{}
+}
+
+func (r *PageSectionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_sections"
+}
+
+func (r *PageSectionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lays out a page skeleton: a title heading, a table of contents and a divider, then a heading and content for each section.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the first block of the layout.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "The ID of the page the layout is created on.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"after": schema.StringAttribute{
+				Description: "Insert the layout after the specified block ID. If omitted, appends to the end.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "Text of the heading_1 block the layout starts with. If omitted, there is no title heading. " +
+					"Supports inline markdown: [text](url) links, **bold**, *italic*, ~~strikethrough~~ and `code`.",
+				CustomType: RichTextStringType{},
+				Optional:   true,
+			},
+			"table_of_contents": schema.BoolAttribute{
+				Description: "Whether to add a table of contents after the title. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"section_dividers": schema.BoolAttribute{
+				Description: "Whether to add a divider between sections. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"sections": schema.ListNestedAttribute{
+				Description: "The sections, in page order. Adding, removing or reordering sections or their blocks, or changing a " +
+					"block's type, replaces the layout; other changes update the affected blocks in place.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"heading": schema.StringAttribute{
+							Description: "Text of the section's heading_2 block. Supports the same inline markdown as title.",
+							CustomType:  RichTextStringType{},
+							Required:    true,
+						},
+						"blocks": schema.ListNestedAttribute{
+							Description: "The blocks under the section heading, in order.",
+							Optional:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: blockListItemAttributes(),
+							},
+						},
+					},
+				},
+			},
+			"block_ids": schema.ListAttribute{
+				Description: "The IDs of every block of the layout, in page order.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig requires at least one section and applies the notion_block
+// type/attribute checks to each section's blocks.
+func (r *PageSectionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sections types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sections"), &sections)...)
+	if resp.Diagnostics.HasError() || sections.IsNull() || sections.IsUnknown() {
+		return
+	}
+	if len(sections.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("sections"), "Empty Section List", "sections must contain at least one section.")
+		return
+	}
+
+	for i, elem := range sections.Elements() {
+		section, ok := elem.(types.Object)
+		if !ok || section.IsUnknown() {
+			continue
+		}
+		blocks, ok := section.Attributes()["blocks"].(types.List)
+		if !ok || blocks.IsNull() || blocks.IsUnknown() {
+			continue
+		}
+		var items []BlockListItemModel
+		resp.Diagnostics.Append(blocks.ElementsAs(ctx, &items, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for j, item := range items {
+			model := item.toBlockModel(NewNotionIDNull())
+			resp.Diagnostics.Append(blockTypeAttributeDiagnostics(&model, path.Root("sections").AtListIndex(i).AtName("blocks").AtListIndex(j))...)
+		}
+	}
+}
+
+func (r *PageSectionsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+// ModifyPlan replaces the layout when its block list changes shape, as
+// notion_blocks does, or when blocks of it were deleted outside Terraform.
+func (r *PageSectionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state PageSectionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	next := plan.layout()
+	if blockListNeedsReplace(state.layout(), next) || len(state.BlockIDs.Elements()) != len(next) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("sections"))
+	}
+}
+
+// newLayoutBlock returns a block list item of blockType with text and every
+// other attribute at its schema default.
+func newLayoutBlock(blockType string, text RichTextStringValue) BlockListItemModel {
+	return BlockListItemModel{
+		Type:         types.StringValue(blockType),
+		RichText:     text,
+		RichTextJSON: types.StringNull(),
+		Color:        types.StringValue(""),
+		IsToggleable: types.BoolValue(false),
+		Checked:      types.BoolValue(false),
+		Icon:         types.StringValue(""),
+		Language:     types.StringValue(""),
+		Caption:      NewRichTextStringValue(""),
+		URL:          types.StringValue(""),
+		Expression:   types.StringValue(""),
+		SyncedFrom:   NewNotionIDNull(),
+	}
+}
+
+// hasHeader reports whether the layout starts with a title or a table of
+// contents, which are followed by a divider.
+func (m PageSectionsResourceModel) hasHeader() bool {
+	return !m.Title.IsNull() || m.TableOfContents.ValueBool()
+}
+
+// layout expands the model into the blocks it lays out, in page order.
+func (m PageSectionsResourceModel) layout() []BlockListItemModel {
+	var items []BlockListItemModel
+	if !m.Title.IsNull() {
+		items = append(items, newLayoutBlock("heading_1", m.Title))
+	}
+	if m.TableOfContents.ValueBool() {
+		items = append(items, newLayoutBlock("table_of_contents", NewRichTextStringValue("")))
+	}
+	if m.hasHeader() {
+		items = append(items, newLayoutBlock("divider", NewRichTextStringValue("")))
+	}
+	for i, section := range m.Sections {
+		if i > 0 && m.SectionDividers.ValueBool() {
+			items = append(items, newLayoutBlock("divider", NewRichTextStringValue("")))
+		}
+		items = append(items, newLayoutBlock("heading_2", section.Heading))
+		items = append(items, section.Blocks...)
+	}
+	return items
+}
+
+// setLayout writes items, which pair up with layout() by position, back
+// into the title, section headings and section blocks.
+func (m *PageSectionsResourceModel) setLayout(items []BlockListItemModel) {
+	i := 0
+	if !m.Title.IsNull() {
+		m.Title = items[i].RichText
+		i++
+	}
+	if m.TableOfContents.ValueBool() {
+		i++
+	}
+	if m.hasHeader() {
+		i++
+	}
+	for s := range m.Sections {
+		if s > 0 && m.SectionDividers.ValueBool() {
+			i++
+		}
+		m.Sections[s].Heading = items[i].RichText
+		i++
+		for b := range m.Sections[s].Blocks {
+			m.Sections[s].Blocks[b] = items[i]
+			i++
+		}
+	}
+}
+
+func (r *PageSectionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PageSectionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items := plan.layout()
+	children, diags := buildBlockList(items, plan.ParentID, path.Root("sections"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var after notionapi.BlockID
+	if !plan.After.IsNull() && !plan.After.IsUnknown() {
+		after = notionapi.BlockID(plan.After.ValueString())
+	}
+
+	created, err := appendBlocks(ctx, r.client, notionapi.BlockID(plan.ParentID.ValueNotionID()), after, children)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating page sections", err.Error())
+		if len(created) == 0 {
+			return
+		}
+		// Blocks from earlier chunks already exist; record them so the next
+		// apply replaces them instead of leaving duplicates behind.
+	}
+
+	ids := make([]string, len(created))
+	for i, block := range created {
+		ids[i], items[i] = readBlockListItem(block, items[i], plan.ParentID)
+	}
+	if len(created) == len(items) {
+		plan.setLayout(items)
+	}
+	plan.ID = types.StringValue(ids[0])
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.BlockIDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PageSectionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PageSectionsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	children, err := listChildBlocks(ctx, r.client, state.ParentID.ValueNotionID())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading page sections", err.Error())
+		return
+	}
+
+	items := state.layout()
+	keptIDs, kept := refreshBlockList(children, ids, items, state.ParentID)
+	if len(kept) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Blocks deleted outside Terraform leave block_ids shorter than the
+	// layout, which plans a replacement; the configured values are kept
+	// as they are until then.
+	if len(kept) == len(items) {
+		state.setLayout(kept)
+	}
+	idList, diags := types.ListValueFrom(ctx, types.StringType, keptIDs)
+	resp.Diagnostics.Append(diags...)
+	state.BlockIDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *PageSectionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state PageSectionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	items := plan.layout()
+	if len(ids) != len(items) {
+		// ModifyPlan replaces the resource in this case.
+		resp.Diagnostics.AddError("Error updating page sections",
+			fmt.Sprintf("State tracks %d blocks but the plan has %d. Please report this to the provider developers.", len(ids), len(items)))
+		return
+	}
+
+	resp.Diagnostics.Append(updateBlockList(ctx, r.client, ids, state.layout(), items, plan.ParentID, path.Root("sections"))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.setLayout(items)
+	plan.ID = state.ID
+	plan.BlockIDs = state.BlockIDs
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PageSectionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PageSectionsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, id := range ids {
+		if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(id)); err != nil {
+			resp.Diagnostics.AddError("Error deleting block", fmt.Sprintf("Block %s: %s", id, err))
+		}
+	}
+}
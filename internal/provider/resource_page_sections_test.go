@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPageSectionsResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPageSectionsConfig(parentPageID, "Runs the billing pipeline."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("notion_page_sections.test", "id"),
+					// heading_1, table_of_contents, divider, then 2 + 1 blocks per section.
+					resource.TestCheckResourceAttr("notion_page_sections.test", "block_ids.#", "6"),
+				),
+			},
+			{
+				Config: testAccPageSectionsConfig(parentPageID, "Runs the billing and invoicing pipelines."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_page_sections.test", "sections.0.blocks.0.rich_text", "Runs the billing and invoicing pipelines."),
+					resource.TestCheckResourceAttr("notion_page_sections.test", "block_ids.#", "6"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPageSectionsConfig(parentPageID, overview string) string {
+	return fmt.Sprintf(`
+resource "notion_page" "test" {
+  parent_page_id = %[1]q
+  title          = "TF Acc Page Sections"
+}
+
+resource "notion_page_sections" "test" {
+  parent_id = notion_page.test.id
+  title     = "Billing service"
+  sections = [
+    {
+      heading = "Overview"
+      blocks  = [{ type = "paragraph", rich_text = %[2]q }]
+    },
+    {
+      heading = "Owners"
+    },
+  ]
+}
+`, parentPageID, overview)
+}
+
+func TestPageSectionsLayout(t *testing.T) {
+	model := PageSectionsResourceModel{
+		Title:           NewRichTextStringValue("Service"),
+		TableOfContents: types.BoolValue(true),
+		SectionDividers: types.BoolValue(true),
+		Sections: []PageSectionModel{
+			{Heading: NewRichTextStringValue("Overview"), Blocks: []BlockListItemModel{newLayoutBlock("paragraph", NewRichTextStringValue("Body"))}},
+			{Heading: NewRichTextStringValue("Owners")},
+		},
+	}
+
+	items := model.layout()
+	var got []string
+	for _, item := range items {
+		got = append(got, item.Type.ValueString())
+	}
+	want := []string{"heading_1", "table_of_contents", "divider", "heading_2", "paragraph", "divider", "heading_2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("layout() types = %v, want %v", got, want)
+	}
+
+	items[0].RichText = NewRichTextStringValue("Service (renamed)")
+	items[4].RichText = NewRichTextStringValue("New body")
+	items[6].RichText = NewRichTextStringValue("Team")
+	model.setLayout(items)
+	if model.Title.ValueString() != "Service (renamed)" {
+		t.Errorf("title = %q", model.Title.ValueString())
+	}
+	if model.Sections[0].Blocks[0].RichText.ValueString() != "New body" {
+		t.Errorf("section block = %q", model.Sections[0].Blocks[0].RichText.ValueString())
+	}
+	if model.Sections[1].Heading.ValueString() != "Team" {
+		t.Errorf("second heading = %q", model.Sections[1].Heading.ValueString())
+	}
+
+	bare := PageSectionsResourceModel{
+		Title:           RichTextStringValue{StringValue: basetypes.NewStringNull()},
+		TableOfContents: types.BoolValue(false),
+		SectionDividers: types.BoolValue(false),
+		Sections:        []PageSectionModel{{Heading: NewRichTextStringValue("Only")}},
+	}
+	if n := len(bare.layout()); n != 1 {
+		t.Errorf("layout() without header has %d blocks, want 1", n)
+	}
+}
@@ -22,6 +22,7 @@ func TestAccPageResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("notion_page.test", "id"),
 					resource.TestCheckResourceAttr("notion_page.test", "title", "Test Page"),
+					resource.TestCheckResourceAttr("notion_page.test", "parent_type", "page_id"),
 					resource.TestCheckResourceAttrSet("notion_page.test", "url"),
 				),
 			},
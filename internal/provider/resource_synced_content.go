@@ -0,0 +1,493 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+var (
+	_ resource.Resource                     = &SyncedContentResource{}
+	_ resource.ResourceWithConfigValidators = &SyncedContentResource{}
+	_ resource.ResourceWithModifyPlan       = &SyncedContentResource{}
+	_ resource.ResourceWithValidateConfig   = &SyncedContentResource{}
+)
+
+// SyncedContentResource manages an original synced block, its content, and
+// synced copies of it under other parents. With separate notion_block
+// resources each copy needs synced_from pointing at the original and has to
+// be created after it; here the copies are created once the original exists
+// and are replaced along with it.
+type SyncedContentResource struct {
+	client *notionapi.Client
+}
+
+type SyncedContentResourceModel struct {
+	ID            types.String         `tfsdk:"id"`
+	ParentID      NotionIDValue        `tfsdk:"parent_id"`
+	After         types.String         `tfsdk:"after"`
+	Blocks        []BlockListItemModel `tfsdk:"blocks"`
+	BlockIDs      types.List           `tfsdk:"block_ids"`
+	CopyParentIDs types.List           `tfsdk:"copy_parent_ids"`
+	CopyIDs       types.Map            `tfsdk:"copy_ids"`
+}
+
+func NewSyncedContentResource() resource.Resource {
+	return &SyncedContentResource{}
+}
+
+func (r *SyncedContentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synced_content"
+}
+
+func (r *SyncedContentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an original synced block with its content, and synced copies of it under other parents.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the original synced block.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "The ID of the page or block the original synced block is created under.",
+				CustomType:  NotionIDType{},
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceIfNotionIDChanged(),
+				},
+			},
+			"after": schema.StringAttribute{
+				Description: "Insert the original after the specified block ID. If omitted, appends to the end.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"blocks": schema.ListNestedAttribute{
+				Description: "The content of the synced block, in order. Adding, removing or reordering blocks, or changing " +
+					"a block's type, replaces the original and every copy; other changes update the affected blocks in place.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: blockListItemAttributes(),
+				},
+			},
+			"block_ids": schema.ListAttribute{
+				Description: "The IDs of the content blocks, in the same order as blocks.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"copy_parent_ids": schema.ListAttribute{
+				Description: "The IDs of the pages or blocks to add a synced copy to. Each copy is appended to the end of its parent.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"copy_ids": schema.MapAttribute{
+				Description: "The ID of each synced copy, keyed by the unhyphenated ID of its parent.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *SyncedContentResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		BlockListAttributesValidator(),
+	}
+}
+
+// ValidateConfig rejects synced blocks in the content, which Notion doesn't
+// allow to nest.
+func (r *SyncedContentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var blocks []BlockListItemModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("blocks"), &blocks)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, block := range blocks {
+		if block.Type.ValueString() == "synced_block" {
+			resp.Diagnostics.AddAttributeError(path.Root("blocks").AtListIndex(i).AtName("type"), "Nested Synced Block",
+				"A synced block can't contain another synced block.")
+		}
+	}
+}
+
+func (r *SyncedContentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+// ModifyPlan replaces the resource when the content changes shape, as
+// notion_blocks does, and keeps copy_ids when the copy parents are the same
+// set as before.
+func (r *SyncedContentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state SyncedContentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if blockListNeedsReplace(state.Blocks, plan.Blocks) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("blocks"))
+		return
+	}
+
+	if plan.CopyParentIDs.IsUnknown() {
+		return
+	}
+	parents, diags := copyParentIDs(ctx, plan.CopyParentIDs)
+	resp.Diagnostics.Append(diags...)
+	copies, diags := copyIDs(ctx, state.CopyIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	toCreate, toDelete := diffSyncedCopies(copies, parents)
+	if len(toCreate) == 0 && len(toDelete) == 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("copy_ids"), state.CopyIDs)...)
+	}
+}
+
+// copyParentIDs returns the configured copy parents as canonical IDs.
+func copyParentIDs(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	var raw []string
+	diags := list.ElementsAs(ctx, &raw, false)
+	parents := make([]string, len(raw))
+	for i, id := range raw {
+		parents[i] = canonicalNotionID(id)
+	}
+	return parents, diags
+}
+
+// copyIDs returns the copy_ids map as a Go map.
+func copyIDs(ctx context.Context, m types.Map) (map[string]string, diag.Diagnostics) {
+	copies := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return copies, nil
+	}
+	diags := m.ElementsAs(ctx, &copies, false)
+	return copies, diags
+}
+
+// diffSyncedCopies returns, sorted, the parents that need a copy and the
+// parents whose copy is no longer wanted.
+func diffSyncedCopies(copies map[string]string, parents []string) (toCreate, toDelete []string) {
+	wanted := map[string]bool{}
+	for _, parent := range parents {
+		if wanted[parent] {
+			continue
+		}
+		wanted[parent] = true
+		if _, ok := copies[parent]; !ok {
+			toCreate = append(toCreate, parent)
+		}
+	}
+	for parent := range copies {
+		if !wanted[parent] {
+			toDelete = append(toDelete, parent)
+		}
+	}
+	sort.Strings(toCreate)
+	sort.Strings(toDelete)
+	return toCreate, toDelete
+}
+
+func (r *SyncedContentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SyncedContentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	children, diags := buildBlockList(plan.Blocks, plan.ParentID, path.Root("blocks"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var after notionapi.BlockID
+	if !plan.After.IsNull() && !plan.After.IsUnknown() {
+		after = notionapi.BlockID(plan.After.ValueString())
+	}
+
+	// The original is created with as much content as one request accepts;
+	// any remainder is appended to it afterwards.
+	first := children
+	if len(first) > maxAppendChildren {
+		first = first[:maxAppendChildren]
+	}
+	result, err := r.client.Block.AppendChildren(ctx, notionapi.BlockID(plan.ParentID.ValueNotionID()), &notionapi.AppendBlockChildrenRequest{
+		After: after,
+		Children: []notionapi.Block{&notionapi.SyncedBlock{
+			BasicBlock:  notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeSyncedBlock},
+			SyncedBlock: notionapi.Synced{Children: first},
+		}},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating synced block", err.Error())
+		return
+	}
+	if len(result.Results) != 1 {
+		resp.Diagnostics.AddError("Error creating synced block",
+			fmt.Sprintf("Notion API returned %d blocks for 1 appended", len(result.Results)))
+		return
+	}
+	originalID := normalizeID(string(result.Results[0].GetID()))
+	plan.ID = types.StringValue(originalID)
+	plan.BlockIDs = types.ListNull(types.StringType)
+	plan.CopyIDs = types.MapNull(types.StringType)
+
+	// From here on the original exists, so partial progress is saved to
+	// state before returning an error; the next apply replaces it.
+	for start := maxAppendChildren; start < len(children); start += maxAppendChildren {
+		end := min(start+maxAppendChildren, len(children))
+		if _, err := r.client.Block.AppendChildren(ctx, notionapi.BlockID(originalID), &notionapi.AppendBlockChildrenRequest{
+			Children: children[start:end],
+		}); err != nil {
+			resp.Diagnostics.AddError("Error creating synced block content", err.Error())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.readContent(ctx, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	parents, diags := copyParentIDs(ctx, plan.CopyParentIDs)
+	resp.Diagnostics.Append(diags...)
+	toCreate, _ := diffSyncedCopies(nil, parents)
+	resp.Diagnostics.Append(r.syncCopies(ctx, &plan, map[string]string{}, toCreate, nil)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// readContent refreshes plan.Blocks and plan.BlockIDs from the original's
+// children. ids are the content block IDs already tracked; nil means the
+// content was just created and every child belongs to it, in order.
+func (r *SyncedContentResource) readContent(ctx context.Context, plan *SyncedContentResourceModel, ids []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ordered, err := listChildBlocks(ctx, r.client, plan.ID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading synced block content", err.Error())
+		return diags
+	}
+
+	if ids == nil {
+		if len(ordered) != len(plan.Blocks) {
+			diags.AddError("Error creating synced block content",
+				fmt.Sprintf("Notion API returned %d blocks for %d appended", len(ordered), len(plan.Blocks)))
+			return diags
+		}
+		for _, block := range ordered {
+			ids = append(ids, normalizeID(string(block.GetID())))
+		}
+	}
+
+	keptIDs, kept := refreshBlockList(ordered, ids, plan.Blocks, NewNotionIDValue(plan.ID.ValueString()))
+	if kept == nil {
+		kept = []BlockListItemModel{}
+	}
+
+	idList, d := types.ListValueFrom(ctx, types.StringType, keptIDs)
+	diags.Append(d...)
+	plan.Blocks = kept
+	plan.BlockIDs = idList
+	return diags
+}
+
+// syncCopies deletes the copies under toDelete and creates copies under
+// toCreate, starting from copies, and records the result in plan.CopyIDs
+// even when a request fails.
+func (r *SyncedContentResource) syncCopies(ctx context.Context, plan *SyncedContentResourceModel, copies map[string]string, toCreate, toDelete []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, parent := range toDelete {
+		if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(copies[parent])); err != nil {
+			diags.AddError("Error deleting synced copy", fmt.Sprintf("Copy under %s: %s", parent, err))
+			continue
+		}
+		delete(copies, parent)
+	}
+
+	for _, parent := range toCreate {
+		result, err := r.client.Block.AppendChildren(ctx, notionapi.BlockID(parent), &notionapi.AppendBlockChildrenRequest{
+			Children: []notionapi.Block{&notionapi.SyncedBlock{
+				BasicBlock: notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeSyncedBlock},
+				SyncedBlock: notionapi.Synced{
+					SyncedFrom: &notionapi.SyncedFrom{BlockID: notionapi.BlockID(plan.ID.ValueString())},
+				},
+			}},
+		})
+		if err != nil {
+			diags.AddError("Error creating synced copy", fmt.Sprintf("Copy under %s: %s", parent, err))
+			continue
+		}
+		if len(result.Results) == 1 {
+			copies[parent] = normalizeID(string(result.Results[0].GetID()))
+		}
+	}
+
+	copyMap, d := types.MapValueFrom(ctx, types.StringType, copies)
+	diags.Append(d...)
+	plan.CopyIDs = copyMap
+	return diags
+}
+
+func (r *SyncedContentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SyncedContentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	original, err := r.client.Block.Get(ctx, notionapi.BlockID(state.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading synced block", err.Error())
+		return
+	}
+	if original.GetArchived() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var ids []string
+	if !state.BlockIDs.IsNull() {
+		resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	copies, diags := copyIDs(ctx, state.CopyIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Content blocks deleted outside Terraform drop out of the list, which
+	// plans a replacement.
+	resp.Diagnostics.Append(r.readContent(ctx, &state, ids)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Copies deleted outside Terraform drop out of copy_ids and are
+	// recreated by the next apply.
+	for parent, id := range copies {
+		block, err := r.client.Block.Get(ctx, notionapi.BlockID(id))
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading synced copy", fmt.Sprintf("Copy under %s: %s", parent, err))
+			return
+		}
+		if block.GetArchived() {
+			delete(copies, parent)
+		}
+	}
+	copyMap, diags := types.MapValueFrom(ctx, types.StringType, copies)
+	resp.Diagnostics.Append(diags...)
+	state.CopyIDs = copyMap
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SyncedContentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SyncedContentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.BlockIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(ids) != len(plan.Blocks) {
+		// ModifyPlan replaces the resource in this case.
+		resp.Diagnostics.AddError("Error updating synced block",
+			fmt.Sprintf("State tracks %d blocks but the plan has %d. Please report this to the provider developers.", len(ids), len(plan.Blocks)))
+		return
+	}
+
+	original := NewNotionIDValue(state.ID.ValueString())
+	resp.Diagnostics.Append(updateBlockList(ctx, r.client, ids, state.Blocks, plan.Blocks, original, path.Root("blocks"))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	plan.BlockIDs = state.BlockIDs
+
+	parents, diags := copyParentIDs(ctx, plan.CopyParentIDs)
+	resp.Diagnostics.Append(diags...)
+	copies, diags := copyIDs(ctx, state.CopyIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	toCreate, toDelete := diffSyncedCopies(copies, parents)
+	resp.Diagnostics.Append(r.syncCopies(ctx, &plan, copies, toCreate, toDelete)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SyncedContentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SyncedContentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	copies, diags := copyIDs(ctx, state.CopyIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Copies first: a copy whose original is gone shows an error in Notion.
+	for parent, id := range copies {
+		if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(id)); err != nil {
+			resp.Diagnostics.AddError("Error deleting synced copy", fmt.Sprintf("Copy under %s: %s", parent, err))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(state.ID.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Error deleting synced block", err.Error())
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSyncedContentResource(t *testing.T) {
+	parentPageID := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if parentPageID == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSyncedContentConfig(parentPageID, "Shared notice", "[notion_page.copy_a.id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("notion_synced_content.test", "id"),
+					resource.TestCheckResourceAttr("notion_synced_content.test", "block_ids.#", "2"),
+					resource.TestCheckResourceAttr("notion_synced_content.test", "copy_ids.%", "1"),
+				),
+			},
+			{
+				Config: testAccSyncedContentConfig(parentPageID, "Shared notice, revised", "[notion_page.copy_a.id, notion_page.copy_b.id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("notion_synced_content.test", "blocks.1.rich_text", "Shared notice, revised"),
+					resource.TestCheckResourceAttr("notion_synced_content.test", "copy_ids.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSyncedContentConfig(parentPageID, text, copyParents string) string {
+	return fmt.Sprintf(`
+resource "notion_page" "original" {
+  parent_page_id = %[1]q
+  title          = "TF Acc Synced Original"
+}
+
+resource "notion_page" "copy_a" {
+  parent_page_id = %[1]q
+  title          = "TF Acc Synced Copy A"
+}
+
+resource "notion_page" "copy_b" {
+  parent_page_id = %[1]q
+  title          = "TF Acc Synced Copy B"
+}
+
+resource "notion_synced_content" "test" {
+  parent_id = notion_page.original.id
+  blocks = [
+    { type = "heading_2", rich_text = "Notice" },
+    { type = "paragraph", rich_text = %[2]q },
+  ]
+  copy_parent_ids = %[3]s
+}
+`, parentPageID, text, copyParents)
+}
+
+func TestDiffSyncedCopies(t *testing.T) {
+	copies := map[string]string{"a": "copy-a", "b": "copy-b"}
+
+	toCreate, toDelete := diffSyncedCopies(copies, []string{"c", "a", "c"})
+	if !reflect.DeepEqual(toCreate, []string{"c"}) {
+		t.Errorf("toCreate = %v, want [c]", toCreate)
+	}
+	if !reflect.DeepEqual(toDelete, []string{"b"}) {
+		t.Errorf("toDelete = %v, want [b]", toDelete)
+	}
+
+	toCreate, toDelete = diffSyncedCopies(copies, []string{"b", "a"})
+	if toCreate != nil || toDelete != nil {
+		t.Errorf("expected no changes, got create %v delete %v", toCreate, toDelete)
+	}
+}
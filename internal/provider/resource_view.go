@@ -31,17 +31,17 @@ type ViewResource struct {
 }
 
 type ViewResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	DatabaseID    types.String `tfsdk:"database_id"`
-	ParentViewID  types.String `tfsdk:"parent_view_id"`
-	DataSourceID  types.String `tfsdk:"data_source_id"`
-	Name          types.String `tfsdk:"name"`
-	Type          types.String `tfsdk:"type"`
-	Filter        types.String `tfsdk:"filter"`
-	Sorts         types.String `tfsdk:"sorts"`
-	QuickFilters  types.String `tfsdk:"quick_filters"`
-	Configuration types.String `tfsdk:"configuration"`
-	URL           types.String `tfsdk:"url"`
+	ID            types.String  `tfsdk:"id"`
+	DatabaseID    NotionIDValue `tfsdk:"database_id"`
+	ParentViewID  NotionIDValue `tfsdk:"parent_view_id"`
+	DataSourceID  NotionIDValue `tfsdk:"data_source_id"`
+	Name          types.String  `tfsdk:"name"`
+	Type          types.String  `tfsdk:"type"`
+	Filter        types.String  `tfsdk:"filter"`
+	Sorts         types.String  `tfsdk:"sorts"`
+	QuickFilters  types.String  `tfsdk:"quick_filters"`
+	Configuration types.String  `tfsdk:"configuration"`
+	URL           types.String  `tfsdk:"url"`
 }
 
 func NewViewResource() resource.Resource {
@@ -69,26 +69,29 @@ func (r *ViewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"database_id": schema.StringAttribute{
 				Description: "ID of the database to create the view in. Mutually exclusive with `parent_view_id`. " +
 					"Changing this forces a new resource.",
-				Optional: true,
+				CustomType: NotionIDType{},
+				Optional:   true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"parent_view_id": schema.StringAttribute{
 				Description: "ID of a dashboard view to add this view to as a widget. Mutually exclusive with " +
 					"`database_id`. Changing this forces a new resource.",
-				Optional: true,
+				CustomType: NotionIDType{},
+				Optional:   true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"data_source_id": schema.StringAttribute{
 				Description: "ID of the data source this view is scoped to (required by the create endpoint). " +
 					"Under the v2025-09-03 multi-source databases model the data source is distinct from the database. " +
 					"Changing this forces a new resource.",
-				Required: true,
+				CustomType: NotionIDType{},
+				Required:   true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					RequiresReplaceIfNotionIDChanged(),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -142,13 +145,13 @@ func (r *ViewResource) Configure(_ context.Context, req resource.ConfigureReques
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*notionapi.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
 
 func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -168,9 +171,9 @@ func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	payload := viewCreate{
-		DatabaseID:   plan.DatabaseID.ValueString(),
-		ViewID:       plan.ParentViewID.ValueString(),
-		DataSourceID: plan.DataSourceID.ValueString(),
+		DatabaseID:   plan.DatabaseID.ValueNotionID(),
+		ViewID:       plan.ParentViewID.ValueNotionID(),
+		DataSourceID: plan.DataSourceID.ValueNotionID(),
 		Name:         plan.Name.ValueString(),
 		Type:         plan.Type.ValueString(),
 	}
@@ -281,7 +284,7 @@ func (r *ViewResource) viewToState(v *viewObject, state *ViewResourceModel) {
 	state.Type = types.StringValue(v.Type)
 	state.URL = types.StringValue(v.URL)
 	if v.DataSourceID != "" {
-		state.DataSourceID = types.StringValue(v.DataSourceID)
+		state.DataSourceID = NewNotionIDValue(v.DataSourceID)
 	}
 	state.Filter = jsonRawToTFString(v.Filter)
 	state.Sorts = jsonRawToTFString(v.Sorts)
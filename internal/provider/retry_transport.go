@@ -55,19 +55,19 @@ import (
 // Exponential with jitter, capped at maxDelay. If the response carries a
 // Retry-After header, we honour it (capped to maxDelay).
 type retryTransport struct {
-	next       http.RoundTripper // underlying transport; defaults to http.DefaultTransport
+	next       http.RoundTripper // underlying transport; notionTransport in production
 	maxRetries int               // total attempts = maxRetries + 1
 	baseDelay  time.Duration     // initial backoff for the first retry
 	maxDelay   time.Duration     // upper bound on any single sleep
 }
 
-// newRetryHTTPClient returns a *http.Client wired with retryTransport. Use
-// this anywhere we'd otherwise reach for http.DefaultClient or pass a
-// *http.Client to the notionapi SDK.
+// newRetryHTTPClient returns a *http.Client wired with retryTransport. The
+// provider builds one, notionHTTPClient (notion_api_client.go), and uses it
+// for the SDK and every raw call instead of http.DefaultClient.
 func newRetryHTTPClient() *http.Client {
 	return &http.Client{
 		Transport: &retryTransport{
-			next:       http.DefaultTransport,
+			next:       notionTransport,
 			maxRetries: 5,
 			baseDelay:  500 * time.Millisecond,
 			maxDelay:   30 * time.Second,
@@ -129,6 +129,12 @@ func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			continue
 		}
 
+		// 429s pass through to the caller's own Retry-After loop (see
+		// the type comment), but are counted for the throttling logs.
+		if resp.StatusCode == http.StatusTooManyRequests {
+			recordRateLimited(req.Context(), req, resp)
+		}
+
 		if !shouldRetryResponse(resp) {
 			return resp, nil
 		}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Notion re-serializes rich text on write: a single run can come back split
+// into several runs with identical annotations, a link can be split across
+// runs, and whitespace (CRLF, non-breaking spaces, trailing blanks) is
+// normalized. RichTextStringType compares the normalized plain-text+markdown
+// form of two values rather than the raw strings, so those rewrites don't
+// show up as diffs.
+
+var (
+	_ basetypes.StringTypable                    = RichTextStringType{}
+	_ basetypes.StringValuableWithSemanticEquals = RichTextStringValue{}
+)
+
+// RichTextStringType is a string type holding plain text with optional
+// inline markdown, as accepted by plainToRichText.
+type RichTextStringType struct {
+	basetypes.StringType
+}
+
+func (t RichTextStringType) Equal(o attr.Type) bool {
+	other, ok := o.(RichTextStringType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t RichTextStringType) String() string {
+	return "RichTextStringType"
+}
+
+func (t RichTextStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return RichTextStringValue{StringValue: in}, nil
+}
+
+func (t RichTextStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return RichTextStringValue{StringValue: stringValue}, nil
+}
+
+func (t RichTextStringType) ValueType(_ context.Context) attr.Value {
+	return RichTextStringValue{}
+}
+
+// RichTextStringValue is the value counterpart of RichTextStringType.
+type RichTextStringValue struct {
+	basetypes.StringValue
+}
+
+// NewRichTextStringValue returns a known RichTextStringValue.
+func NewRichTextStringValue(s string) RichTextStringValue {
+	return RichTextStringValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v RichTextStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(RichTextStringValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v RichTextStringValue) Type(_ context.Context) attr.Type {
+	return RichTextStringType{}
+}
+
+// StringSemanticEquals reports whether two rich text strings render to the
+// same normalized plain text, links and formatting.
+func (v RichTextStringValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(RichTextStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return normalizeRichTextPlain(v.ValueString()) == normalizeRichTextPlain(newValue.ValueString()), diags
+}
+
+// normalizeRichTextPlain canonicalizes a plain-text+markdown string:
+// adjacent runs with the same link and formatting are merged and re-rendered,
+// line endings and non-breaking spaces are unified, and trailing whitespace
+// is dropped from each line and from the end of the text. Leading
+// indentation is kept since it is meaningful in code blocks.
+func normalizeRichTextPlain(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+
+	runs := plainToRichText(s)
+	for i := range runs {
+		runs[i].PlainText = runs[i].Text.Content
+	}
+	s = richTextToPlain(runs)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+func TestNormalizeRichTextPlain(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"hello world", "hello world", true},
+		{"hello world", "hello world  ", true},
+		{"line one\r\nline two", "line one\nline two", true},
+		{"non\u00a0breaking", "non breaking", true},
+		{"see [docs](https://x.io)", "see [do](https://x.io)[cs](https://x.io)", true},
+		{"    indented", "indented", false},
+		{"see [docs](https://x.io)", "see [docs](https://y.io)", false},
+		{"hello", "Hello", false},
+		{"**[docs](https://x.io)**", "[**docs**](https://x.io)", true},
+		{"**bold** *it*", "**bold** *it*  ", true},
+		{"**bold** text", "bold text", false},
+		{"*italic*", "~~italic~~", false},
+	}
+	for _, tc := range cases {
+		got := normalizeRichTextPlain(tc.a) == normalizeRichTextPlain(tc.b)
+		if got != tc.want {
+			t.Errorf("normalize(%q) == normalize(%q): got %t, want %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Search can't be limited to part of the workspace, so the page and
+// database data sources scope their lookups afterwards: a search hit is
+// kept only if walking up its parents reaches the given page. Each parent
+// is fetched once per lookup, so sibling hits share the walk.
+
+// maxAncestorDepth bounds a walk up the parents, in case of a cycle.
+const maxAncestorDepth = 64
+
+// searchScope decides whether search hits sit under a page.
+type searchScope struct {
+	// parentOf returns the parent of the page, database or block p points
+	// at.
+	parentOf func(ctx context.Context, p rawParent) (rawParent, error)
+	parents  map[string]rawParent
+}
+
+// newSearchScope returns a searchScope that looks parents up through the
+// API.
+func newSearchScope(token string) *searchScope {
+	return &searchScope{
+		parentOf: func(ctx context.Context, p rawParent) (rawParent, error) {
+			return fetchParent(ctx, token, p)
+		},
+	}
+}
+
+// under reports whether an object with parent p is a descendant of the page
+// ancestorID, at any depth.
+func (s *searchScope) under(ctx context.Context, p rawParent, ancestorID string) (bool, error) {
+	ancestorID = normalizeID(ancestorID)
+	for range maxAncestorDepth {
+		id := p.objectID()
+		if id == "" {
+			return false, nil
+		}
+		if p.Type == "page_id" && id == ancestorID {
+			return true, nil
+		}
+		next, ok := s.parents[id]
+		if !ok {
+			var err error
+			next, err = s.parentOf(ctx, p)
+			if err != nil {
+				return false, err
+			}
+			if s.parents == nil {
+				s.parents = map[string]rawParent{}
+			}
+			s.parents[id] = next
+		}
+		p = next
+	}
+	return false, fmt.Errorf("parent chain deeper than %d levels", maxAncestorDepth)
+}
+
+// objectID returns the normalized ID of the object p points at, or "" for
+// the workspace and parents it doesn't know.
+func (p rawParent) objectID() string {
+	switch p.Type {
+	case "page_id":
+		return normalizeID(p.PageID)
+	case "database_id":
+		return normalizeID(p.DatabaseID)
+	case "block_id":
+		return normalizeID(p.BlockID)
+	default:
+		return ""
+	}
+}
+
+// fetchParent retrieves the object p points at and returns its parent.
+func fetchParent(ctx context.Context, token string, p rawParent) (rawParent, error) {
+	var endpoint string
+	switch p.Type {
+	case "page_id":
+		endpoint = "pages"
+	case "database_id":
+		endpoint = "databases"
+	default:
+		endpoint = "blocks"
+	}
+	body, err := notionAPICall(ctx, http.MethodGet, notionAPIBaseURL+"/"+endpoint+"/"+p.objectID(), token, notionSDKAPIVersion, nil)
+	if err != nil {
+		return rawParent{}, err
+	}
+	var obj struct {
+		Parent rawParent `json:"parent"`
+	}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return rawParent{}, fmt.Errorf("decoding %s/%s: %w", endpoint, p.objectID(), err)
+	}
+	return obj.Parent, nil
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Resource schemas evolve (an options map becoming an ordered list, date
+// strings becoming objects, ...). Rather than redeclaring every prior schema,
+// each resource keeps an ordered list of stateMigrations that rewrite the raw
+// JSON state one version at a time. The resource's schema version is
+// len(migrations), and migratedStateUpgraders registers an upgrader for every
+// prior version that replays the remaining steps in order, so a state written
+// at v0 reaches the current version in a single UpgradeResourceState call.
+//
+// To change a resource's schema incompatibly, append a stateMigration to its
+// list that rewrites the affected attributes into the new shape; the schema
+// Version and UpgradeState entries are derived from the list.
+
+// stateMigration rewrites a resource's state attributes in place from schema
+// version N (its index in the migration list) to N+1. Attributes are the
+// decoded JSON state object, so strings, json.Number, bools, nil, []any and
+// map[string]any are the only value types.
+type stateMigration func(attrs map[string]any) error
+
+// Per-resource migration lists. Empty lists keep the resource at schema
+// version 0; append to one to bump that resource's version.
+var (
+	databaseEntryStateMigrations               []stateMigration
+	databasePropertySelectStateMigrations      []stateMigration
+	databasePropertyMultiSelectStateMigrations []stateMigration
+	databasePropertyStatusStateMigrations      []stateMigration
+)
+
+// schemaVersion returns the schema version implied by a migration list.
+func schemaVersion(migrations []stateMigration) int64 {
+	return int64(len(migrations))
+}
+
+// migratedStateUpgraders returns an UpgradeState map with one entry per prior
+// schema version, each applying migrations[version:] to the raw state.
+func migratedStateUpgraders(migrations []stateMigration) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(migrations))
+	for v := range migrations {
+		from := v
+		upgraders[int64(from)] = resource.StateUpgrader{
+			StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil || req.RawState.JSON == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("State at schema version %d is not in JSON format. Refresh it with a Terraform release from 0.12 onwards and try again.", from),
+					)
+					return
+				}
+				upgraded, err := applyStateMigrations(req.RawState.JSON, from, migrations)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Upgrading state from schema version %d to %d: %s", from, len(migrations), err),
+					)
+					return
+				}
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+			},
+		}
+	}
+	return upgraders
+}
+
+// applyStateMigrations decodes a JSON state object, runs migrations[from:]
+// over it in order and re-encodes the result.
+func applyStateMigrations(raw []byte, from int, migrations []stateMigration) ([]byte, error) {
+	var attrs map[string]any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&attrs); err != nil {
+		return nil, fmt.Errorf("decoding prior state: %w", err)
+	}
+	for v := from; v < len(migrations); v++ {
+		if err := migrations[v](attrs); err != nil {
+			return nil, fmt.Errorf("migration %d -> %d: %w", v, v+1, err)
+		}
+	}
+	return json.Marshal(attrs)
+}
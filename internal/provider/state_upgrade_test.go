@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApplyStateMigrations(t *testing.T) {
+	migrations := []stateMigration{
+		// v0 -> v1: options map becomes a list of {name, color} objects.
+		func(attrs map[string]any) error {
+			opts, _ := attrs["options"].(map[string]any)
+			var list []any
+			for _, name := range []string{"a", "b"} {
+				if color, ok := opts[name]; ok {
+					list = append(list, map[string]any{"name": name, "color": color})
+				}
+			}
+			attrs["options"] = list
+			return nil
+		},
+		// v1 -> v2: rename an attribute.
+		func(attrs map[string]any) error {
+			attrs["database_id"] = attrs["database"]
+			delete(attrs, "database")
+			return nil
+		},
+	}
+
+	raw := []byte(`{"database":"db1","big":12345678901234567890,"options":{"a":"red","b":"blue"}}`)
+
+	got, err := applyStateMigrations(raw, 0, migrations)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `{"big":12345678901234567890,"database_id":"db1","options":[{"color":"red","name":"a"},{"color":"blue","name":"b"}]}`
+	if string(got) != want {
+		t.Errorf("from v0:\n got %s\nwant %s", got, want)
+	}
+
+	got, err = applyStateMigrations([]byte(`{"database":"db1"}`), 1, migrations)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(got) != `{"database_id":"db1"}` {
+		t.Errorf("from v1: got %s", got)
+	}
+}
+
+func TestApplyStateMigrations_Error(t *testing.T) {
+	migrations := []stateMigration{
+		func(map[string]any) error { return fmt.Errorf("boom") },
+	}
+	if _, err := applyStateMigrations([]byte(`{}`), 0, migrations); err == nil {
+		t.Error("expected migration error to propagate")
+	}
+	if _, err := applyStateMigrations([]byte(`not json`), 0, migrations); err == nil {
+		t.Error("expected decode error")
+	}
+}
+
+func TestMigratedStateUpgraders_Versions(t *testing.T) {
+	migrations := make([]stateMigration, 3)
+	upgraders := migratedStateUpgraders(migrations)
+	if len(upgraders) != 3 {
+		t.Fatalf("expected 3 upgraders, got %d", len(upgraders))
+	}
+	for v := int64(0); v < 3; v++ {
+		if _, ok := upgraders[v]; !ok {
+			t.Errorf("missing upgrader for version %d", v)
+		}
+	}
+	if schemaVersion(migrations) != 3 {
+		t.Errorf("schemaVersion = %d, want 3", schemaVersion(migrations))
+	}
+}
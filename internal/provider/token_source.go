@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A provider process lives as long as the plan or apply it serves, which on
+// a long-running agent can outlast the token it was configured with. A
+// token given through token_file (or NOTION_TOKEN_FILE) is therefore read
+// again whenever the file changes, and every raw call and SDK request
+// authenticates with the token as it is at the time, so rotating the token
+// by rewriting the file doesn't strand the rest of the run with 401s.
+//
+// A token given through token or NOTION_TOKEN stays fixed: a running
+// process doesn't see later changes to its environment.
+
+// tokenSource supplies the API token of a client.
+type tokenSource struct {
+	// path is the file the token is read from, or "" for a fixed token.
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// staticTokenSource returns a tokenSource that always supplies token.
+func staticTokenSource(token string) *tokenSource {
+	return &tokenSource{token: token}
+}
+
+// fileTokenSource returns a tokenSource that reads the token from the file
+// at path, and the error reading it for the first time.
+func fileTokenSource(path string) (*tokenSource, error) {
+	s := &tokenSource{path: path}
+	_, err := s.Token()
+	return s, err
+}
+
+// rotates reports whether the token can change after Configure.
+func (s *tokenSource) rotates() bool {
+	return s.path != ""
+}
+
+// Token returns the current token. A file that is briefly missing, empty
+// or unreadable, e.g. while it is being replaced, leaves the last token
+// read from it in use.
+func (s *tokenSource) Token() (string, error) {
+	if s.path == "" {
+		return s.token, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err == nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size && s.token != "" {
+		return s.token, nil
+	}
+	var data []byte
+	if err == nil {
+		data, err = os.ReadFile(s.path)
+	}
+	token := strings.TrimSpace(string(data))
+	if err == nil && token == "" {
+		err = fmt.Errorf("token file %s is empty", s.path)
+	}
+	if err != nil {
+		if s.token != "" {
+			return s.token, nil
+		}
+		return "", fmt.Errorf("reading Notion API token: %w", err)
+	}
+	s.token = token
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return s.token, nil
+}
+
+// tokenSourceHTTPClient returns a copy of base that authenticates every
+// request with the current token of source, in place of the token the SDK
+// was constructed with.
+func tokenSourceHTTPClient(base *http.Client, source *tokenSource) *http.Client {
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client := *base
+	client.Transport = &tokenSourceTransport{base: transport, source: source}
+	return &client
+}
+
+// tokenSourceTransport sets the Authorization header of each request from a
+// tokenSource.
+type tokenSourceTransport struct {
+	base   http.RoundTripper
+	source *tokenSource
+}
+
+func (t *tokenSourceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("ntn_first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := fileTokenSource(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, _ := source.Token(); got != "ntn_first" {
+		t.Errorf("got %q, want the trimmed file contents", got)
+	}
+
+	if err := os.WriteFile(path, []byte("ntn_rotated\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := source.Token(); got != "ntn_rotated" {
+		t.Errorf("got %q after rotation, want ntn_rotated", got)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := source.Token(); err != nil || got != "ntn_rotated" {
+		t.Errorf("got %q, %v while the file is missing, want the last token", got, err)
+	}
+
+	if _, err := fileTokenSource(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing token file")
+	}
+}
+
+func TestTokenSourceTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("ntn_current"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source, err := fileTokenSource(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var auth string
+	base := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			auth = req.Header.Get("Authorization")
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		}),
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.notion.com/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer ntn_configured")
+	resp, err := tokenSourceHTTPClient(base, source).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if auth != "Bearer ntn_current" {
+		t.Errorf("got Authorization %q, want the token from the file", auth)
+	}
+}
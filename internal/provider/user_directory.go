@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jomei/notionapi"
+)
+
+// The API can only list users, not look one up by email, so the
+// workspace's user list is cached on provider data and shared by notion_user
+// and notion_users: 30 lookups in a workspace of 2,000 users fetch the list
+// once rather than 30 times.
+//
+// The list is fetched as far as it is needed: a lookup stops paging once it
+// finds its user, and a later lookup searches the pages fetched so far
+// before fetching more. The pages can't be fetched concurrently, since each
+// page's cursor comes from the previous page, and 100 users per page is the
+// most the API returns.
+
+type userDirectory struct {
+	mu     sync.Mutex
+	users  []notionapi.User
+	cursor notionapi.Cursor
+	loaded bool
+}
+
+// listWorkspaceUsers returns every user visible to the provider's client,
+// fetching the rest of the list on first use. Concurrent callers wait for a
+// single fetch. A failed fetch isn't cached, so the next caller tries again.
+func listWorkspaceUsers(ctx context.Context, provider *providerData) ([]notionapi.User, error) {
+	dir := &provider.users
+
+	dir.mu.Lock()
+	defer dir.mu.Unlock()
+	if _, err := dir.find(ctx, provider.client, func(*notionapi.User) bool { return false }); err != nil {
+		return nil, err
+	}
+	return dir.users, nil
+}
+
+// findWorkspaceUser returns the first user visible to the provider's client
+// that match accepts, or nil if there is none, fetching only as much of the
+// list as it takes to find it.
+func findWorkspaceUser(ctx context.Context, provider *providerData, match func(*notionapi.User) bool) (*notionapi.User, error) {
+	dir := &provider.users
+
+	dir.mu.Lock()
+	defer dir.mu.Unlock()
+	return dir.find(ctx, provider.client, match)
+}
+
+// find searches the users fetched so far, then fetches the remaining pages
+// one at a time until one has a user match accepts. dir.mu must be held.
+func (dir *userDirectory) find(ctx context.Context, client *notionapi.Client, match func(*notionapi.User) bool) (*notionapi.User, error) {
+	for i := range dir.users {
+		if match(&dir.users[i]) {
+			user := dir.users[i]
+			return &user, nil
+		}
+	}
+
+	for !dir.loaded {
+		page, err := client.User.List(ctx, &notionapi.Pagination{
+			StartCursor: dir.cursor,
+			PageSize:    100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		dir.users = append(dir.users, page.Results...)
+		if page.HasMore {
+			dir.cursor = notionapi.Cursor(page.NextCursor)
+		} else {
+			dir.loaded = true
+		}
+
+		for i := range page.Results {
+			if match(&page.Results[i]) {
+				user := page.Results[i]
+				return &user, nil
+			}
+		}
+	}
+	return nil, nil
+}
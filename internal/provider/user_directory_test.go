@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestListWorkspaceUsers_FetchesOnce(t *testing.T) {
+	var requests atomic.Int64
+	var failNext atomic.Bool
+	failNext.Store(true)
+
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			status, body := http.StatusOK, `{"object":"list","results":[{"object":"user","id":"u1","type":"person","name":"Ada","person":{"email":"ada@example.com"}}],"has_more":true,"next_cursor":"c2"}`
+			switch {
+			case failNext.CompareAndSwap(true, false):
+				status, body = http.StatusBadRequest, `{"object":"error","status":400,"code":"validation_error","message":"boom"}`
+			case req.URL.Query().Get("start_cursor") == "c2":
+				body = `{"object":"list","results":[{"object":"user","id":"u2","type":"bot","name":"Bot"}],"has_more":false}`
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+	data := newProviderData(client)
+
+	// A failed fetch is not cached.
+	if _, err := listWorkspaceUsers(context.Background(), data); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected the API error, got %v", err)
+	}
+	requests.Store(0)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users, err := listWorkspaceUsers(context.Background(), data)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(users) != 2 || users[0].Person == nil || users[0].Person.Email != "ada@example.com" {
+				t.Errorf("unexpected users: %+v", users)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected the two pages to be fetched once, got %d requests", got)
+	}
+}
+
+func TestFindWorkspaceUser_StopsPaging(t *testing.T) {
+	var cursors []string
+	client := notionapi.NewClient("test-token", notionapi.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			cursor := req.URL.Query().Get("start_cursor")
+			cursors = append(cursors, cursor)
+			body := `{"object":"list","results":[{"object":"user","id":"u1","type":"person","name":"Ada","person":{"email":"ada@example.com"}}],"has_more":true,"next_cursor":"c2"}`
+			switch cursor {
+			case "c2":
+				body = `{"object":"list","results":[{"object":"user","id":"u2","type":"person","name":"Grace","person":{"email":"Grace@Example.com"}}],"has_more":true,"next_cursor":"c3"}`
+			case "c3":
+				body = `{"object":"list","results":[{"object":"user","id":"u3","type":"bot","name":"Bot"}],"has_more":false}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}))
+	data := newProviderData(client)
+	ctx := context.Background()
+	byEmail := func(email string) func(*notionapi.User) bool {
+		return func(u *notionapi.User) bool {
+			return u.Person != nil && strings.EqualFold(u.Person.Email, email)
+		}
+	}
+
+	user, err := findWorkspaceUser(ctx, data, byEmail("grace@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user == nil || user.ID != "u2" {
+		t.Fatalf("expected to find u2, got %+v", user)
+	}
+	if len(cursors) != 2 {
+		t.Errorf("expected paging to stop after the second page, got %d requests", len(cursors))
+	}
+
+	// A user on a page fetched already costs no requests.
+	if user, err := findWorkspaceUser(ctx, data, byEmail("ada@example.com")); err != nil || user == nil || user.ID != "u1" {
+		t.Fatalf("expected to find u1, got %+v, %v", user, err)
+	}
+	if len(cursors) != 2 {
+		t.Errorf("expected no more requests, got %d", len(cursors)-2)
+	}
+
+	// A missing user fetches the rest of the list, which listing then reuses.
+	if user, err := findWorkspaceUser(ctx, data, byEmail("nobody@example.com")); err != nil || user != nil {
+		t.Fatalf("expected no user, got %+v, %v", user, err)
+	}
+	users, err := listWorkspaceUsers(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 3 || len(cursors) != 3 {
+		t.Errorf("expected 3 users from 3 requests, got %d users from %d requests", len(users), len(cursors))
+	}
+}
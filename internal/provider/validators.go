@@ -3,9 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Valid Notion colors for select/multi-select options.
@@ -146,7 +153,9 @@ var validBlockTypes = []string{
 	"tabs", "tab",
 }
 
-// Valid block colors (10 foreground + 10 background).
+// Valid block colors: the 10 text colors and the background variants of
+// every one except default. Callout and table_of_contents blocks take the
+// same set as text blocks.
 var validBlockColors = []string{
 	"default", "gray", "brown", "orange", "yellow",
 	"green", "blue", "purple", "pink", "red",
@@ -254,6 +263,102 @@ func MarkdownInsertPositionValidator() validator.String {
 	return markdownInsertPositionValidator{}
 }
 
+// onRemoveValidator validates that a string is "clear" or "ignore".
+type onRemoveValidator struct{}
+
+func (v onRemoveValidator) Description(_ context.Context) string {
+	return `value must be "clear" or "ignore"`
+}
+
+func (v onRemoveValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v onRemoveValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	val := req.ConfigValue.ValueString()
+	if val == "clear" || val == "ignore" {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid On Remove Behavior",
+		fmt.Sprintf(`Expected "clear" or "ignore", got: %s`, val),
+	)
+}
+
+// OnRemoveValidator returns a validator for the on_remove field.
+func OnRemoveValidator() validator.String {
+	return onRemoveValidator{}
+}
+
+// searchSortValidator validates that a string is a timestamp search results
+// can be sorted by. The API only supports "last_edited_time".
+type searchSortValidator struct{}
+
+func (v searchSortValidator) Description(_ context.Context) string {
+	return `value must be "last_edited_time"`
+}
+
+func (v searchSortValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v searchSortValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	val := req.ConfigValue.ValueString()
+	if val == "last_edited_time" {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Search Sort",
+		fmt.Sprintf(`Expected "last_edited_time", got: %s`, val),
+	)
+}
+
+// SearchSortValidator returns a validator for the search data sources' sort
+// field.
+func SearchSortValidator() validator.String {
+	return searchSortValidator{}
+}
+
+// sortDirectionValidator validates that a string is "ascending" or
+// "descending".
+type sortDirectionValidator struct{}
+
+func (v sortDirectionValidator) Description(_ context.Context) string {
+	return `value must be "ascending" or "descending"`
+}
+
+func (v sortDirectionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v sortDirectionValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	val := req.ConfigValue.ValueString()
+	if val == "ascending" || val == "descending" {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Sort Direction",
+		fmt.Sprintf(`Expected "ascending" or "descending", got: %s`, val),
+	)
+}
+
+// SortDirectionValidator returns a validator for sort_direction fields.
+func SortDirectionValidator() validator.String {
+	return sortDirectionValidator{}
+}
+
 // Valid Notion view types per the 2026-03-19 Views API launch.
 var validViewTypes = []string{
 	"table", "board", "list", "calendar", "timeline",
@@ -291,3 +396,395 @@ func (v viewTypeValidator) ValidateString(_ context.Context, req validator.Strin
 func ViewTypeValidator() validator.String {
 	return viewTypeValidator{}
 }
+
+// blockAttributeRule lists the type-specific notion_block attributes a block
+// type accepts, and which of those it cannot be created without.
+type blockAttributeRule struct {
+	allowed  []string
+	required []string
+}
+
+var (
+	textBlockAttributes    = []string{"rich_text", "rich_text_json", "color"}
+	headingBlockAttributes = []string{"rich_text", "rich_text_json", "color", "is_toggleable"}
+)
+
+// blockTypeAttributeRules maps each block type to its attribute rule. Types
+// absent from the map (or mapped to the zero rule) accept none of the
+// type-specific attributes.
+var blockTypeAttributeRules = map[string]blockAttributeRule{
+	"paragraph":          {allowed: textBlockAttributes},
+	"heading_1":          {allowed: headingBlockAttributes},
+	"heading_2":          {allowed: headingBlockAttributes},
+	"heading_3":          {allowed: headingBlockAttributes},
+	"heading_4":          {allowed: headingBlockAttributes},
+	"bulleted_list_item": {allowed: textBlockAttributes},
+	"numbered_list_item": {allowed: textBlockAttributes},
+	"to_do":              {allowed: []string{"rich_text", "rich_text_json", "color", "checked"}},
+	"toggle":             {allowed: textBlockAttributes},
+	"quote":              {allowed: textBlockAttributes},
+	"callout":            {allowed: []string{"rich_text", "rich_text_json", "color", "icon"}},
+	"code":               {allowed: []string{"rich_text", "rich_text_json", "language", "caption"}},
+	"equation":           {allowed: []string{"expression"}, required: []string{"expression"}},
+	"table_of_contents":  {allowed: []string{"color"}},
+	"bookmark":           {allowed: []string{"url", "caption"}, required: []string{"url"}},
+	"embed":              {allowed: []string{"url"}, required: []string{"url"}},
+	"image":              {allowed: []string{"url", "caption"}, required: []string{"url"}},
+	"synced_block":       {allowed: []string{"synced_from"}},
+}
+
+// blockTypeSpecificAttributes is every notion_block attribute whose validity
+// depends on the block type, in schema order.
+var blockTypeSpecificAttributes = []string{
+	"rich_text", "rich_text_json", "color", "is_toggleable", "checked",
+	"icon", "language", "caption", "url", "expression", "synced_from",
+}
+
+// checkBlockTypeAttributes returns the configured attributes that blockType
+// does not accept, and the required attributes that are not configured.
+func checkBlockTypeAttributes(blockType string, configured map[string]bool) (forbidden, missing []string) {
+	rule := blockTypeAttributeRules[blockType]
+	for _, name := range blockTypeSpecificAttributes {
+		if configured[name] && !containsString(rule.allowed, name) {
+			forbidden = append(forbidden, name)
+		}
+	}
+	for _, name := range rule.required {
+		if !configured[name] {
+			missing = append(missing, name)
+		}
+	}
+	return forbidden, missing
+}
+
+// blockTypesAccepting returns the block types whose rule allows name.
+func blockTypesAccepting(name string) []string {
+	var out []string
+	for _, t := range validBlockTypes {
+		if containsString(blockTypeAttributeRules[t].allowed, name) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// blockTypeAttributesValidator rejects notion_block configs that set
+// attributes the chosen type ignores (e.g. expression on a paragraph) or omit
+// attributes it needs (e.g. url on a bookmark), so the mistake surfaces at
+// plan time instead of as an API error during apply. Attributes set to their
+// zero value ("" / false) are treated as unset, matching the schema defaults.
+type blockTypeAttributesValidator struct{}
+
+func (v blockTypeAttributesValidator) Description(_ context.Context) string {
+	return "type-specific attributes must match the block type"
+}
+
+func (v blockTypeAttributesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v blockTypeAttributesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BlockResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(blockTypeAttributeDiagnostics(&config, path.Empty())...)
+}
+
+// blockTypeAttributeDiagnostics checks one block's type-specific attributes
+// against its type. Attribute paths are built under base, so the same check
+// serves notion_block (base is the root) and the elements of notion_blocks.
+func blockTypeAttributeDiagnostics(config *BlockResourceModel, base path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.Type.IsNull() || config.Type.IsUnknown() {
+		return diags
+	}
+
+	configured := map[string]bool{}
+	for _, name := range blockTypeSpecificAttributes {
+		val := blockModelAttribute(config, name)
+		if val.IsUnknown() {
+			// Can't judge an unknown value; treat it as satisfying any
+			// requirement and violating nothing.
+			configured[name] = true
+			continue
+		}
+		configured[name] = !val.IsNull() && !isZeroAttrValue(val)
+	}
+	// An expression of only whitespace is as empty to Notion as "", and the
+	// equation is rejected with a 400 at apply.
+	if expr := config.Expression; !expr.IsNull() && !expr.IsUnknown() && strings.TrimSpace(expr.ValueString()) == "" {
+		configured["expression"] = false
+	}
+
+	blockType := config.Type.ValueString()
+	forbidden, missing := checkBlockTypeAttributes(blockType, configured)
+	for _, name := range forbidden {
+		if blockModelAttribute(config, name).IsUnknown() {
+			continue
+		}
+		diags.AddAttributeError(
+			base.AtName(name),
+			"Attribute Not Supported For Block Type",
+			fmt.Sprintf("%q is not used by %s blocks. It applies to: %s.", name, blockType, strings.Join(blockTypesAccepting(name), ", ")),
+		)
+	}
+	for _, name := range missing {
+		detail := fmt.Sprintf("%s blocks require %q to be set.", blockType, name)
+		if name == "expression" {
+			detail = fmt.Sprintf("%s blocks require a non-empty LaTeX %q; Notion rejects an empty equation.", blockType, name)
+		}
+		diags.AddAttributeError(base.AtName(name), "Missing Attribute For Block Type", detail)
+	}
+	return diags
+}
+
+// BlockTypeAttributesValidator returns a resource-level validator tying
+// notion_block's type-specific attributes to its type.
+func BlockTypeAttributesValidator() resource.ConfigValidator {
+	return blockTypeAttributesValidator{}
+}
+
+// blockListAttributesValidator applies the notion_block type/attribute
+// checks to every element of notion_blocks.blocks, and rejects an empty list.
+type blockListAttributesValidator struct{}
+
+func (v blockListAttributesValidator) Description(_ context.Context) string {
+	return "blocks must not be empty and each block's type-specific attributes must match its type"
+}
+
+func (v blockListAttributesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v blockListAttributesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var blocks types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("blocks"), &blocks)...)
+	if resp.Diagnostics.HasError() || blocks.IsNull() || blocks.IsUnknown() {
+		return
+	}
+	if len(blocks.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("blocks"), "Empty Block List", "blocks must contain at least one block.")
+		return
+	}
+
+	var items []BlockListItemModel
+	resp.Diagnostics.Append(blocks.ElementsAs(ctx, &items, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, item := range items {
+		model := item.toBlockModel(NewNotionIDNull())
+		resp.Diagnostics.Append(blockTypeAttributeDiagnostics(&model, path.Root("blocks").AtListIndex(i))...)
+	}
+}
+
+// BlockListAttributesValidator returns a resource-level validator for
+// notion_blocks.
+func BlockListAttributesValidator() resource.ConfigValidator {
+	return blockListAttributesValidator{}
+}
+
+// databaseImportSourceValidator checks that notion_database_import reads
+// its CSV from exactly one source and that key_column is one of the mapped
+// columns.
+type databaseImportSourceValidator struct{}
+
+func (v databaseImportSourceValidator) Description(_ context.Context) string {
+	return "exactly one of csv_path and csv_content must be set, and key_column must be a key of columns"
+}
+
+func (v databaseImportSourceValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v databaseImportSourceValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DatabaseImportResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.CSVPath.IsUnknown() && !config.CSVContent.IsUnknown() && config.CSVPath.IsNull() == config.CSVContent.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("csv_path"),
+			"Invalid CSV Source",
+			"Exactly one of csv_path and csv_content must be set.",
+		)
+	}
+
+	if config.Columns.IsNull() || config.Columns.IsUnknown() || config.KeyColumn.IsUnknown() {
+		return
+	}
+	if _, ok := config.Columns.Elements()[config.KeyColumn.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_column"),
+			"Unmapped Key Column",
+			fmt.Sprintf("key_column %q must be one of the columns mapped in columns.", config.KeyColumn.ValueString()),
+		)
+	}
+}
+
+// DatabaseImportSourceValidator returns a resource-level validator for
+// notion_database_import.
+func DatabaseImportSourceValidator() resource.ConfigValidator {
+	return databaseImportSourceValidator{}
+}
+
+// blockModelAttribute returns the model value for a type-specific attribute.
+func blockModelAttribute(m *BlockResourceModel, name string) attr.Value {
+	switch name {
+	case "rich_text":
+		return m.RichText
+	case "rich_text_json":
+		return m.RichTextJSON
+	case "color":
+		return m.Color
+	case "is_toggleable":
+		return m.IsToggleable
+	case "checked":
+		return m.Checked
+	case "icon":
+		return m.Icon
+	case "language":
+		return m.Language
+	case "caption":
+		return m.Caption
+	case "url":
+		return m.URL
+	case "expression":
+		return m.Expression
+	case "synced_from":
+		return m.SyncedFrom
+	default:
+		return types.StringNull()
+	}
+}
+
+// isZeroAttrValue reports whether a known string or bool value is "" / false.
+func isZeroAttrValue(v attr.Value) bool {
+	switch tv := v.(type) {
+	case basetypes.StringValuable:
+		s, diags := tv.ToStringValue(context.Background())
+		return !diags.HasError() && s.ValueString() == ""
+	case basetypes.BoolValuable:
+		b, diags := tv.ToBoolValue(context.Background())
+		return !diags.HasError() && !b.ValueBool()
+	}
+	return false
+}
+
+// httpURLValidator validates that a string, or every element of a string
+// map, is an absolute http(s) URL. Empty strings are accepted since they are
+// the schema's "unset" value.
+type httpURLValidator struct{}
+
+func (v httpURLValidator) Description(_ context.Context) string {
+	return "value must be an absolute http:// or https:// URL"
+}
+
+func (v httpURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v httpURLValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := checkHTTPURL(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", err.Error())
+	}
+}
+
+func (v httpURLValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for key, elem := range req.ConfigValue.Elements() {
+		s, ok := elem.(basetypes.StringValue)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		if err := checkHTTPURL(s.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtMapKey(key), "Invalid URL", err.Error())
+		}
+	}
+}
+
+// HTTPURLValidator returns a string validator for absolute http(s) URLs.
+func HTTPURLValidator() validator.String {
+	return httpURLValidator{}
+}
+
+// HTTPURLMapValidator returns a map validator requiring every element to be
+// an absolute http(s) URL.
+func HTTPURLMapValidator() validator.Map {
+	return httpURLValidator{}
+}
+
+// checkHTTPURL returns an error unless s is empty or an absolute http(s) URL
+// with a host.
+func checkHTTPURL(s string) error {
+	if s == "" {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %s", s, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must be an absolute URL starting with http:// or https://", s)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", s)
+	}
+	return nil
+}
+
+// notionDateMapValidator validates that every element of a string map is an
+// ISO 8601 date or RFC3339 datetime, as accepted by parseNotionDate.
+type notionDateMapValidator struct{}
+
+func (v notionDateMapValidator) Description(_ context.Context) string {
+	return "each value must be an ISO 8601 date (2006-01-02) or RFC3339 datetime (2006-01-02T15:04:05Z)"
+}
+
+func (v notionDateMapValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v notionDateMapValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for key, elem := range req.ConfigValue.Elements() {
+		sv, ok := elem.(basetypes.StringValuable)
+		if !ok {
+			continue
+		}
+		s, diags := sv.ToStringValue(ctx)
+		if diags.HasError() || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		if _, _, err := parseNotionDate(s.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtMapKey(key), "Invalid Date Value", err.Error()+".")
+		}
+	}
+}
+
+// NotionDateMapValidator returns a map validator for date_properties-style
+// maps of ISO 8601 dates.
+func NotionDateMapValidator() validator.Map {
+	return notionDateMapValidator{}
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestCheckBlockTypeAttributes(t *testing.T) {
+	cases := []struct {
+		name          string
+		blockType     string
+		configured    map[string]bool
+		wantForbidden []string
+		wantMissing   []string
+	}{
+		{"paragraph with text", "paragraph", map[string]bool{"rich_text": true, "color": true}, nil, nil},
+		{"expression on paragraph", "paragraph", map[string]bool{"rich_text": true, "expression": true}, []string{"expression"}, nil},
+		{"bookmark without url", "bookmark", map[string]bool{"caption": true}, nil, []string{"url"}},
+		{"equation with expression", "equation", map[string]bool{"expression": true}, nil, nil},
+		{"checked on heading", "heading_2", map[string]bool{"is_toggleable": true, "checked": true}, []string{"checked"}, nil},
+		{"divider with color", "divider", map[string]bool{"color": true}, []string{"color"}, nil},
+		{"image missing url with language", "image", map[string]bool{"language": true}, []string{"language"}, []string{"url"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			forbidden, missing := checkBlockTypeAttributes(tc.blockType, tc.configured)
+			if !reflect.DeepEqual(forbidden, tc.wantForbidden) {
+				t.Errorf("forbidden = %v, want %v", forbidden, tc.wantForbidden)
+			}
+			if !reflect.DeepEqual(missing, tc.wantMissing) {
+				t.Errorf("missing = %v, want %v", missing, tc.wantMissing)
+			}
+		})
+	}
+}
+
+func TestBlockTypeAttributeDiagnosticsEquation(t *testing.T) {
+	cases := []struct {
+		expression types.String
+		wantError  bool
+	}{
+		{types.StringValue(`E = mc^2`), false},
+		{types.StringValue(""), true},
+		{types.StringValue(" \n\t"), true},
+		{types.StringNull(), true},
+		{types.StringUnknown(), false},
+	}
+	for _, tc := range cases {
+		config := &BlockResourceModel{Type: types.StringValue("equation"), Expression: tc.expression}
+		diags := blockTypeAttributeDiagnostics(config, path.Empty())
+		if diags.HasError() != tc.wantError {
+			t.Errorf("expression %s: got errors %v, want error %t", tc.expression, diags, tc.wantError)
+		}
+	}
+}
+
+func TestCheckHTTPURL(t *testing.T) {
+	cases := []struct {
+		in      string
+		wantErr bool
+	}{
+		{"", false},
+		{"https://example.com", false},
+		{"http://example.com/path?q=1#frag", false},
+		{"example.com", true},
+		{"ftp://example.com/file", true},
+		{"/relative/path", true},
+		{"https://", true},
+		{"https://exa mple.com", true},
+	}
+	for _, tc := range cases {
+		if err := checkHTTPURL(tc.in); (err != nil) != tc.wantErr {
+			t.Errorf("checkHTTPURL(%q) error = %v, wantErr %t", tc.in, err, tc.wantErr)
+		}
+	}
+}
+
+func TestNotionDateMapValidator(t *testing.T) {
+	ctx := context.Background()
+	value := types.MapValueMust(DateStringType{}, map[string]attr.Value{
+		"Due":     NewDateStringValue("2025-01-15"),
+		"Start":   NewDateStringValue("2025-01-15T10:30:00Z"),
+		"Broken":  NewDateStringValue("15/01/2025"),
+		"Pending": DateStringValue{StringValue: basetypes.NewStringUnknown()},
+	})
+	req := validator.MapRequest{Path: path.Root("date_properties"), ConfigValue: value}
+	resp := &validator.MapResponse{}
+	NotionDateMapValidator().ValidateMap(ctx, req, resp)
+
+	if got := resp.Diagnostics.ErrorsCount(); got != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", got, resp.Diagnostics)
+	}
+	errPath := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path()
+	if want := path.Root("date_properties").AtMapKey("Broken"); !errPath.Equal(want) {
+		t.Errorf("error path = %s, want %s", errPath, want)
+	}
+}
+
+func TestBlockColorValidator(t *testing.T) {
+	ctx := context.Background()
+	valid := append([]string{""}, validBlockColors...)
+	for _, color := range valid {
+		resp := &validator.StringResponse{}
+		BlockColorValidator().ValidateString(ctx, validator.StringRequest{Path: path.Root("color"), ConfigValue: types.StringValue(color)}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("color %q rejected: %v", color, resp.Diagnostics)
+		}
+	}
+	if len(validBlockColors) != 19 {
+		t.Errorf("expected 10 text colors and 9 background colors, got %d", len(validBlockColors))
+	}
+
+	for _, color := range []string{"Red", "default_background", "teal", "red-background"} {
+		resp := &validator.StringResponse{}
+		BlockColorValidator().ValidateString(ctx, validator.StringRequest{Path: path.Root("color"), ConfigValue: types.StringValue(color)}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("color %q accepted", color)
+		}
+	}
+}
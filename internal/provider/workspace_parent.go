@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Pages and databases at the top level of a workspace have a workspace
+// parent, which has no ID. An integration can import them, but the API
+// doesn't let it create one there or move one there, so an unset parent is
+// only accepted on a resource that is already at the top level.
+
+// planParent checks the planned parent (attr, parent_page_id or parent) of
+// a notion_page or notion_database, kind. A created or moved resource always
+// ends up under a page, so its parent_type is planned as page_id.
+func planParent(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attr, kind string) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	creating := req.State.Raw.IsNull()
+
+	var planned, prior NotionIDValue
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr), &planned)...)
+	if !creating {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attr), &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planned.IsNull() {
+		switch {
+		case creating:
+			resp.Diagnostics.AddAttributeError(path.Root(attr), "Workspace-level "+kind+" not allowed",
+				fmt.Sprintf("The Notion API doesn't let integrations create a %s at the top level of the workspace. "+
+					"Set %s to the ID of the page to create it under. %s only needs to be left unset for an "+
+					"imported %s that is already at the top level.", kind, attr, attr, kind))
+		case !prior.IsNull():
+			resp.Diagnostics.AddAttributeError(path.Root(attr), "Workspace-level "+kind+" not allowed",
+				fmt.Sprintf("The Notion API doesn't let integrations move a %s to the top level of the workspace, "+
+					"so %s can't be removed. Move the %s in Notion and import it again instead.", kind, attr, kind))
+		}
+		return
+	}
+
+	if creating || planned.IsUnknown() || planned.ValueNotionID() != prior.ValueNotionID() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("parent_type"), types.StringValue("page_id"))...)
+	}
+}